@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	_ "time/tzdata"
 
 	"github.com/ofstudio/dancegobot/internal/app"
 	"github.com/ofstudio/dancegobot/internal/config"
@@ -66,6 +66,7 @@ func (a *App) Start(ctx context.Context) error {
 	// 4. Start background tasks
 	a.srv.Event.Start(ctx)
 	a.srv.Render.Start(ctx)
+	a.srv.Notifier.Start(ctx)
 
 	// 5. Initialize middleware and handlers
 	m := telegram.NewMiddleware(a.cfg.Settings, a.srv.Event, a.srv.User).WithLogger(a.log)
@@ -81,6 +82,7 @@ func (a *App) Start(ctx context.Context) error {
 
 	bot.Handle("/start", h.Start)
 	bot.Handle("/partner", h.Partner)
+	bot.Handle("/events", h.Events)
 	bot.Handle("/settings", h.Settings)
 
 	bot.Handle(tele.OnText, h.Text)
@@ -89,9 +91,55 @@ func (a *App) Start(ctx context.Context) error {
 	bot.Handle(tele.OnInlineResult, h.InlineResult)
 
 	bot.Handle(&telegram.BtnCbSignup, h.CbSignup)
+	bot.Handle(&telegram.BtnCbConsentAccept, h.CbConsentAccept)
+	bot.Handle(&telegram.BtnCbConsentDecline, h.CbConsentDecline)
+	bot.Handle(&telegram.BtnCbSingleRefresh, h.CbSingleRefresh)
+	bot.Handle(&telegram.BtnCbNotifyMute, h.CbNotifyMute)
+	bot.Handle(&telegram.BtnCbEvents, h.CbEvents)
+	bot.Handle(&telegram.BtnCbEventManage, h.CbEventManage)
+	bot.Handle(&telegram.BtnCbEventClose, h.CbEventClose)
+	bot.Handle(&telegram.BtnCbEventLimit, h.CbEventLimit)
+	bot.Handle(&telegram.BtnCbEventForbid, h.CbEventForbid)
+	bot.Handle(&telegram.BtnCbEventAllow, h.CbEventAllow)
+	bot.Handle(&telegram.BtnCbEventCaption, h.CbEventCaption)
+	bot.Handle(&telegram.BtnCbEventStartsAt, h.CbEventStartsAt)
+	bot.Handle(&telegram.BtnCbEventRecurrence, h.CbEventRecurrence)
+	bot.Handle(&telegram.BtnCbEventLevels, h.CbEventLevels)
+	bot.Handle(&telegram.BtnCbEventLevelsSet, h.CbEventLevelsSet)
+	bot.Handle(&telegram.BtnCbEventSolo, h.CbEventSolo)
+	bot.Handle(&telegram.BtnCbEventConsent, h.CbEventConsent)
+	bot.Handle(&telegram.BtnCbEventDeadlines, h.CbEventDeadlines)
+	bot.Handle(&telegram.BtnCbEventSingleTTL, h.CbEventSingleTTL)
+	bot.Handle(&telegram.BtnCbEventClone, h.CbEventClone)
+	bot.Handle(&telegram.BtnCbEventTemplate, h.CbEventTemplate)
+	bot.Handle(&telegram.BtnCbEventDancers, h.CbEventDancers)
+	bot.Handle(&telegram.BtnCbEventDancerAdd, h.CbEventDancerAdd)
+	bot.Handle(&telegram.BtnCbEventDancerPair, h.CbEventDancerPair)
+	bot.Handle(&telegram.BtnCbEventDancerRemove, h.CbEventDancerRemove)
+	bot.Handle(&telegram.BtnCbEventPairing, h.CbEventPairing)
+	bot.Handle(&telegram.BtnCbNoop, h.CbNoop)
+	bot.Handle(&telegram.BtnCbEventCoOrganizers, h.CbEventCoOrganizers)
+	bot.Handle(&telegram.BtnCbEventCoOrganizerRemove, h.CbEventCoOrganizerRemove)
+	bot.Handle(&telegram.BtnCbEventCoOrganizerDefault, h.CbEventCoOrganizerDefault)
+	bot.Handle(&telegram.BtnCbEventInviteRenew, h.CbEventInviteRenew)
 	bot.Handle(&telegram.BtnCbSettingsAutoPair, h.CbSettingsAutoPair)
+	bot.Handle(&telegram.BtnCbSettingsPairing, h.CbSettingsPairing)
+	bot.Handle(&telegram.BtnCbSettingsOwnerAlerts, h.CbSettingsOwnerAlerts)
+	bot.Handle(&telegram.BtnCbSettingsNotify, h.CbSettingsNotify)
+	bot.Handle(&telegram.BtnCbSettingsNotifyCategory, h.CbSettingsNotifyCategory)
+	bot.Handle(&telegram.BtnCbSettingsNotifyUnmute, h.CbSettingsNotifyUnmute)
 	bot.Handle(&telegram.BtnCbSettingsHelp, h.CbSettingsHelp)
 	bot.Handle(&telegram.BtnCbSettingsBack, h.CbSettingsBack)
+	bot.Handle(&telegram.BtnCbSettingsBlocklist, h.CbSettingsBlocklist)
+	bot.Handle(&telegram.BtnCbSettingsUnblock, h.CbSettingsUnblock)
+	bot.Handle(&telegram.BtnCbSettingsCoOrganizers, h.CbSettingsCoOrganizers)
+	bot.Handle(&telegram.BtnCbSettingsCoOrganizerRemove, h.CbSettingsCoOrganizerRemove)
+	bot.Handle(&telegram.BtnCbSettingsTemplates, h.CbSettingsTemplates)
+	bot.Handle(&telegram.BtnCbSettingsTemplateApply, h.CbSettingsTemplateApply)
+	bot.Handle(&telegram.BtnCbSettingsTemplateRemove, h.CbSettingsTemplateRemove)
+	bot.Handle(&telegram.BtnCbSettingsDancer, h.CbSettingsDancer)
+	bot.Handle(&telegram.BtnCbSettingsDancerSet, h.CbSettingsDancerSet)
+	bot.Handle(&telegram.BtnCbSettingsSingleAlerts, h.CbSettingsSingleAlerts)
 
 	// This is needed to handle channel posts
 	bot.Handle(tele.OnChannelPost, func(_ tele.Context) error { return nil })
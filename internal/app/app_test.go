@@ -35,6 +35,12 @@ func (suite *AppTestSuite) SetupSubTest() {
 	cfg.ReRenderOnStartup = 0
 	cfg.DraftCleanupEvery = 0
 	cfg.DraftCleanupOlderThan = 0
+	cfg.AutoCloseEvery = 0
+	cfg.ReminderEvery = 0
+	cfg.RecurrenceEvery = 0
+	cfg.ConsentExpireEvery = 0
+	cfg.SingleExpireEvery = 0
+	cfg.DigestEvery = 0
 
 	gock.New(telegock.GetMe).
 		Reply(200).
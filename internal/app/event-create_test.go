@@ -10,6 +10,7 @@ import (
 
 	"github.com/ofstudio/dancegobot/internal/locale"
 	"github.com/ofstudio/dancegobot/internal/models"
+	"github.com/ofstudio/dancegobot/internal/store"
 	"github.com/ofstudio/dancegobot/pkg/telegock"
 )
 
@@ -81,10 +82,10 @@ func (suite *AppTestSuite) TestEventDraft() {
 
 		suite.NoPending()
 		suite.NoUnmatched()
-		event, err := suite.app.srv.Event.Get(context.Background(), eventID)
-		suite.NoError(err)
-		suite.NotNil(event)
-		suite.Equal("Test text", event.Caption)
+
+		// the draft is saved only when it is published
+		_, err := suite.app.srv.Event.Get(context.Background(), eventID)
+		suite.ErrorIs(err, store.ErrNotFound)
 	})
 
 	suite.Run("quite long inline query", func() {
@@ -249,11 +250,8 @@ func (suite *AppTestSuite) TestEventPostAdd() {
 		suite.NoPending()
 		suite.NoUnmatched()
 
-		event, err := suite.app.srv.Event.Get(context.Background(), eventID)
-		suite.Require().NoError(err)
-		suite.Require().NotNil(event)
-		suite.Equal(eventID, event.ID)
-		suite.Require().Nil(event.Post)
+		_, err := suite.app.srv.Event.Get(context.Background(), eventID)
+		suite.ErrorIs(err, store.ErrNotFound)
 	})
 }
 
@@ -0,0 +1,388 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/h2non/gock"
+	"github.com/tidwall/gjson"
+	tele "gopkg.in/telebot.v4"
+
+	"github.com/ofstudio/dancegobot/internal/locale"
+	"github.com/ofstudio/dancegobot/pkg/telegock"
+)
+
+func (suite *AppTestSuite) TestEvents() {
+	suite.Run("no events", func() {
+		// <- bot should call `sendMessage`
+		gock.New(telegock.SendMessage).
+			Reply(200).
+			Filter(func(res *http.Response) bool {
+				body := suite.Decode(res.Request.Body)
+				suite.Equal(fmt.Sprintf(locale.EventsEmpty, botUser.Username), body.Get("text").String())
+				return true
+			}).JSON(telegock.Result(&tele.Message{}))
+
+		// -> bot update `message`
+		gock.New(telegock.GetUpdates).
+			Reply(200).
+			JSON(telegock.Updates().Message(tele.Message{
+				Sender: userJohn,
+				Chat:   &tele.Chat{ID: userJohn.ID, Type: tele.ChatPrivate},
+				Text:   "/events",
+			}))
+
+		suite.NoPending()
+		suite.NoUnmatched()
+	})
+
+	suite.Run("event list and scene", func() {
+		eventID := suite.eventPublish(queryA)
+
+		// <- bot should call `sendMessage`
+		var cbData string
+		gock.New(telegock.SendMessage).
+			Reply(200).
+			Filter(func(res *http.Response) bool {
+				body := suite.Decode(res.Request.Body)
+				suite.Equal(locale.EventsCaption, body.Get("text").String())
+				kbd := gjson.Parse(body.Get("reply_markup").String()).Get("inline_keyboard")
+				suite.Len(kbd.Array(), 1)
+				suite.Contains(kbd.Get("0.0.text").String(), queryA.Text)
+				cbData = kbd.Get("0.0.callback_data").String()
+				suite.Contains(cbData, "\fmanage|"+eventID+"|")
+				return true
+			}).JSON(telegock.Result(&tele.Message{}))
+
+		// -> bot update `message`
+		gock.New(telegock.GetUpdates).
+			Reply(200).
+			JSON(telegock.Updates().Message(tele.Message{
+				Sender: userJohn,
+				Chat:   &tele.Chat{ID: userJohn.ID, Type: tele.ChatPrivate},
+				Text:   "/events",
+			}))
+
+		suite.NoPending()
+		suite.NoUnmatched()
+
+		// <- bot should call `answerCallbackQuery` and `editMessageText`
+		gock.New(telegock.AnswerCallbackQuery).Reply(200).JSON(telegock.Result(true))
+		gock.New(telegock.EditMessageText).
+			Reply(200).
+			Filter(func(res *http.Response) bool {
+				body := suite.Decode(res.Request.Body)
+				suite.Equal(fmt.Sprintf(locale.EventScene, queryA.Text, 0, 0), body.Get("text").String())
+				return true
+			}).JSON(telegock.Result(true))
+
+		// -> bot update `callback_query`
+		gock.New(telegock.GetUpdates).
+			Reply(200).
+			JSON(telegock.Updates().CallbackQuery(tele.Callback{
+				Sender:  userJohn,
+				Message: &tele.Message{ID: 1, Chat: &tele.Chat{ID: userJohn.ID, Type: tele.ChatPrivate}},
+				Data:    cbData,
+			}))
+
+		suite.NoPending()
+		suite.NoUnmatched()
+	})
+
+	suite.Run("scene access denied", func() {
+		eventID := suite.eventPublish(queryA)
+
+		// <- bot should call `answerCallbackQuery`
+		gock.New(telegock.AnswerCallbackQuery).
+			Reply(200).
+			Filter(func(res *http.Response) bool {
+				body := suite.Decode(res.Request.Body)
+				suite.Equal(locale.ErrAccessDenied, body.Get("text").String())
+				return true
+			}).JSON(telegock.Result(true))
+
+		// -> bot update `callback_query`
+		gock.New(telegock.GetUpdates).
+			Reply(200).
+			JSON(telegock.Updates().CallbackQuery(tele.Callback{
+				Sender:  userJane,
+				Message: &tele.Message{ID: 1, Chat: &tele.Chat{ID: userJane.ID, Type: tele.ChatPrivate}},
+				Data:    "\fmanage|" + eventID + "|rand",
+			}))
+
+		suite.NoPending()
+		suite.NoUnmatched()
+	})
+}
+
+func (suite *AppTestSuite) TestEventCaption() {
+	suite.Run("edit caption", func() {
+		eventID := suite.eventPublish(queryA)
+
+		// <- bot should call `answerCallbackQuery` and `editMessageText`
+		gock.New(telegock.AnswerCallbackQuery).Reply(200).JSON(telegock.Result(true))
+		gock.New(telegock.EditMessageText).
+			Reply(200).
+			Filter(func(res *http.Response) bool {
+				body := suite.Decode(res.Request.Body)
+				suite.Equal(locale.EventCaptionAsk, body.Get("text").String())
+				return true
+			}).JSON(telegock.Result(true))
+
+		// -> bot update `callback_query`
+		gock.New(telegock.GetUpdates).
+			Reply(200).
+			JSON(telegock.Updates().CallbackQuery(tele.Callback{
+				Sender:  userJohn,
+				Message: &tele.Message{ID: 1, Chat: &tele.Chat{ID: userJohn.ID, Type: tele.ChatPrivate}},
+				Data:    "\fevent_caption|" + eventID + "|rand",
+			}))
+
+		suite.NoPending()
+		suite.NoUnmatched()
+
+		// <- bot should call `editMessageText` to re-render the post and `sendMessage`
+		gock.New(telegock.EditMessageText).
+			Reply(200).
+			Filter(func(res *http.Response) bool {
+				body := suite.Decode(res.Request.Body)
+				suite.Contains(body.Get("text").String(), "New caption")
+				return true
+			}).JSON(telegock.Result(true))
+		gock.New(telegock.SendMessage).
+			Reply(200).
+			Filter(func(res *http.Response) bool {
+				body := suite.Decode(res.Request.Body)
+				suite.Equal(fmt.Sprintf(locale.EventScene, "New caption", 0, 0), body.Get("text").String())
+				return true
+			}).JSON(telegock.Result(&tele.Message{}))
+
+		// -> bot update `message`
+		gock.New(telegock.GetUpdates).
+			Reply(200).
+			JSON(telegock.Updates().Message(tele.Message{
+				Sender: userJohn,
+				Chat:   &tele.Chat{ID: userJohn.ID, Type: tele.ChatPrivate},
+				Text:   "New caption",
+			}))
+
+		suite.NoPending()
+		suite.NoUnmatched()
+	})
+}
+
+func (suite *AppTestSuite) TestEventClone() {
+	suite.Run("clone and publish", func() {
+		eventID := suite.eventPublish(queryA)
+
+		// <- bot should call `answerCallbackQuery` and `editMessageText`
+		var query string
+		gock.New(telegock.AnswerCallbackQuery).Reply(200).JSON(telegock.Result(true))
+		gock.New(telegock.EditMessageText).
+			Reply(200).
+			Filter(func(res *http.Response) bool {
+				body := suite.Decode(res.Request.Body)
+				suite.Equal(fmt.Sprintf(locale.EventCloned, queryA.Text), body.Get("text").String())
+				kbd := gjson.Parse(body.Get("reply_markup").String()).Get("inline_keyboard")
+				suite.Equal(locale.BtnPublish, kbd.Get("0.0.text").String())
+				query = kbd.Get("0.0.switch_inline_query").String()
+				suite.Regexp(`^#[a-zA-Z0-9]{12}$`, query)
+				return true
+			}).JSON(telegock.Result(true))
+
+		// -> bot update `callback_query`
+		gock.New(telegock.GetUpdates).
+			Reply(200).
+			JSON(telegock.Updates().CallbackQuery(tele.Callback{
+				Sender:  userJohn,
+				Message: &tele.Message{ID: 1, Chat: &tele.Chat{ID: userJohn.ID, Type: tele.ChatPrivate}},
+				Data:    "\fevent_clone|" + eventID + "|rand",
+			}))
+
+		suite.NoPending()
+		suite.NoUnmatched()
+
+		// <- bot should call `answerInlineQuery` with the cloned draft
+		gock.New(telegock.AnswerInlineQuery).
+			Reply(200).
+			Filter(func(res *http.Response) bool {
+				body := suite.Decode(res.Request.Body)
+				suite.Equal(query[1:], body.Get("results.0.id").String())
+				suite.Equal(queryA.Text, body.Get("results.0.input_message_content.message_text").String())
+				return true
+			}).JSON(telegock.Result(true))
+
+		// -> bot update `inline_query`
+		gock.New(telegock.GetUpdates).
+			Reply(200).
+			JSON(telegock.Updates().InlineQuery(tele.Query{
+				Sender: userJohn,
+				Text:   query,
+			}))
+
+		suite.NoPending()
+		suite.NoUnmatched()
+	})
+}
+
+// eventPublish creates an event draft and publishes it via chosen inline result.
+func (suite *AppTestSuite) eventPublish(query tele.Query) string {
+	eventID := suite.eventDraftCreate(query)
+
+	// <- bot should call `editMessageText`
+	gock.New(telegock.EditMessageText).Reply(200).JSON(telegock.Result(true))
+
+	// -> bot update `chosen_inline_result`
+	gock.New(telegock.GetUpdates).
+		Reply(200).
+		JSON(telegock.Updates().InlineResult(tele.InlineResult{
+			Sender:   query.Sender,
+			ResultID: eventID,
+			Query:    query.Text,
+		}))
+
+	suite.NoPending()
+	return eventID
+}
+
+func (suite *AppTestSuite) TestEventDancers() {
+	suite.Run("organizer adds and removes couple", func() {
+		eventID := suite.eventPublish(queryA)
+
+		// <- bot should call `answerCallbackQuery` and `editMessageText`
+		gock.New(telegock.AnswerCallbackQuery).Reply(200).JSON(telegock.Result(true))
+		gock.New(telegock.EditMessageText).
+			Reply(200).
+			Filter(func(res *http.Response) bool {
+				body := suite.Decode(res.Request.Body)
+				suite.Equal(locale.DancerCoupleAsk, body.Get("text").String())
+				return true
+			}).JSON(telegock.Result(true))
+
+		// -> bot update `callback_query`
+		gock.New(telegock.GetUpdates).
+			Reply(200).
+			JSON(telegock.Updates().CallbackQuery(tele.Callback{
+				Sender:  userJohn,
+				Message: &tele.Message{ID: 1, Chat: &tele.Chat{ID: userJohn.ID, Type: tele.ChatPrivate}},
+				Data:    "\fevent_dancer_add|" + eventID + "|couple|rand",
+			}))
+
+		suite.NoPending()
+		suite.NoUnmatched()
+
+		// <- bot should call `editMessageText` to re-render the post and `sendMessage`
+		gock.New(telegock.EditMessageText).
+			Reply(200).
+			Filter(func(res *http.Response) bool {
+				body := suite.Decode(res.Request.Body)
+				suite.Contains(body.Get("text").String(), "Ivan Petrov")
+				return true
+			}).JSON(telegock.Result(true))
+		var removeData string
+		gock.New(telegock.SendMessage).
+			Reply(200).
+			Filter(func(res *http.Response) bool {
+				body := suite.Decode(res.Request.Body)
+				suite.Contains(body.Get("text").String(), "1. Ivan Petrov + Maria Ivanova")
+				kbd := gjson.Parse(body.Get("reply_markup").String()).Get("inline_keyboard")
+				suite.Equal(locale.BtnRemoveItem+"Ivan Petrov", kbd.Get("0.0.text").String())
+				suite.Equal(locale.BtnRemoveItem+"Maria Ivanova", kbd.Get("0.1.text").String())
+				removeData = kbd.Get("0.0.callback_data").String()
+				return true
+			}).JSON(telegock.Result(&tele.Message{}))
+
+		// -> bot update `message`
+		gock.New(telegock.GetUpdates).
+			Reply(200).
+			JSON(telegock.Updates().Message(tele.Message{
+				Sender: userJohn,
+				Chat:   &tele.Chat{ID: userJohn.ID, Type: tele.ChatPrivate},
+				Text:   "Ivan Petrov + Maria Ivanova",
+			}))
+
+		suite.NoPending()
+		suite.NoUnmatched()
+
+		// <- bot should call `answerCallbackQuery`, `editMessageText` for the scene
+		// and `editMessageText` to re-render the post
+		gock.New(telegock.AnswerCallbackQuery).Reply(200).JSON(telegock.Result(true))
+		for range 2 {
+			gock.New(telegock.EditMessageText).
+				Reply(200).
+				Filter(func(res *http.Response) bool {
+					body := suite.Decode(res.Request.Body)
+					suite.NotContains(body.Get("text").String(), "Ivan Petrov")
+					return true
+				}).JSON(telegock.Result(true))
+		}
+
+		// -> bot update `callback_query`
+		gock.New(telegock.GetUpdates).
+			Reply(200).
+			JSON(telegock.Updates().CallbackQuery(tele.Callback{
+				Sender:  userJohn,
+				Message: &tele.Message{ID: 1, Chat: &tele.Chat{ID: userJohn.ID, Type: tele.ChatPrivate}},
+				Data:    removeData,
+			}))
+
+		suite.NoPending()
+		suite.NoUnmatched()
+	})
+
+	suite.Run("access denied", func() {
+		eventID := suite.eventPublish(queryA)
+
+		// <- bot should call `answerCallbackQuery`
+		gock.New(telegock.AnswerCallbackQuery).
+			Reply(200).
+			Filter(func(res *http.Response) bool {
+				body := suite.Decode(res.Request.Body)
+				suite.Equal(locale.ErrAccessDenied, body.Get("text").String())
+				return true
+			}).JSON(telegock.Result(true))
+
+		// -> bot update `callback_query`
+		gock.New(telegock.GetUpdates).
+			Reply(200).
+			JSON(telegock.Updates().CallbackQuery(tele.Callback{
+				Sender:  userJane,
+				Message: &tele.Message{ID: 1, Chat: &tele.Chat{ID: userJane.ID, Type: tele.ChatPrivate}},
+				Data:    "\fevent_dancers|" + eventID + "|rand",
+			}))
+
+		suite.NoPending()
+		suite.NoUnmatched()
+	})
+}
+
+func (suite *AppTestSuite) TestEventPairing() {
+	suite.Run("no singles to pair", func() {
+		eventID := suite.eventPublish(queryA)
+
+		// <- bot should call `answerCallbackQuery` and `editMessageText`
+		gock.New(telegock.AnswerCallbackQuery).Reply(200).JSON(telegock.Result(true))
+		gock.New(telegock.EditMessageText).
+			Reply(200).
+			Filter(func(res *http.Response) bool {
+				body := suite.Decode(res.Request.Body)
+				suite.Equal(locale.EventPairing+locale.EventPairingEmpty, body.Get("text").String())
+				kbd := gjson.Parse(body.Get("reply_markup").String()).Get("inline_keyboard")
+				suite.Len(kbd.Array(), 1)
+				suite.Equal(locale.BtnBack, kbd.Get("0.0.text").String())
+				return true
+			}).JSON(telegock.Result(true))
+
+		// -> bot update `callback_query`
+		gock.New(telegock.GetUpdates).
+			Reply(200).
+			JSON(telegock.Updates().CallbackQuery(tele.Callback{
+				Sender:  userJohn,
+				Message: &tele.Message{ID: 1, Chat: &tele.Chat{ID: userJohn.ID, Type: tele.ChatPrivate}},
+				Data:    "\fevent_pairing|" + eventID + "|-|-|rand",
+			}))
+
+		suite.NoPending()
+		suite.NoUnmatched()
+	})
+}
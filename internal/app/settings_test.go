@@ -0,0 +1,277 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/h2non/gock"
+	"github.com/tidwall/gjson"
+	tele "gopkg.in/telebot.v4"
+
+	"github.com/ofstudio/dancegobot/internal/locale"
+	"github.com/ofstudio/dancegobot/internal/models"
+	"github.com/ofstudio/dancegobot/pkg/telegock"
+)
+
+func (suite *AppTestSuite) TestSettingsBlocklist() {
+	suite.Run("blocked dancer can not sign up", func() {
+		eventID := suite.eventPublish(queryA)
+
+		// <- bot should call `answerCallbackQuery` and `editMessageText`
+		gock.New(telegock.AnswerCallbackQuery).Reply(200).JSON(telegock.Result(true))
+		gock.New(telegock.EditMessageText).
+			Reply(200).
+			Filter(func(res *http.Response) bool {
+				body := suite.Decode(res.Request.Body)
+				suite.Equal(locale.Blocklist, body.Get("text").String())
+				return true
+			}).JSON(telegock.Result(true))
+
+		// -> bot update `callback_query`
+		gock.New(telegock.GetUpdates).
+			Reply(200).
+			JSON(telegock.Updates().CallbackQuery(tele.Callback{
+				Sender:  userJohn,
+				Message: &tele.Message{ID: 1, Chat: &tele.Chat{ID: userJohn.ID, Type: tele.ChatPrivate}},
+				Data:    "\fblocklist|rand",
+			}))
+
+		suite.NoPending()
+		suite.NoUnmatched()
+
+		// <- bot should call `sendMessage`
+		gock.New(telegock.SendMessage).
+			Reply(200).
+			Filter(func(res *http.Response) bool {
+				body := suite.Decode(res.Request.Body)
+				suite.Equal(locale.Blocklist+locale.BlocklistList+"1. @jane_doe\n", body.Get("text").String())
+				return true
+			}).JSON(telegock.Result(&tele.Message{}))
+
+		// -> bot update `message`
+		gock.New(telegock.GetUpdates).
+			Reply(200).
+			JSON(telegock.Updates().Message(tele.Message{
+				Sender: userJohn,
+				Chat:   &tele.Chat{ID: userJohn.ID, Type: tele.ChatPrivate},
+				Text:   "@jane_doe",
+			}))
+
+		suite.NoPending()
+		suite.NoUnmatched()
+
+		// <- bot should call `sendMessage`
+		gock.New(telegock.SendMessage).
+			Reply(200).
+			Filter(func(res *http.Response) bool {
+				body := suite.Decode(res.Request.Body)
+				suite.Equal(locale.SignupForbidden, body.Get("text").String())
+				return true
+			}).JSON(telegock.Result(&tele.Message{}))
+
+		// -> bot update `message`
+		gock.New(telegock.GetUpdates).
+			Reply(200).
+			JSON(telegock.Updates().Message(tele.Message{
+				Sender: userJane,
+				Chat:   &tele.Chat{ID: userJane.ID, Type: tele.ChatPrivate},
+				Text:   "/start AD6s-signup-" + eventID + "-leader",
+			}))
+
+		suite.NoPending()
+		suite.NoUnmatched()
+	})
+}
+
+func (suite *AppTestSuite) TestSettingsTemplates() {
+	suite.Run("save event as template and create event from template", func() {
+		eventID := suite.eventPublish(queryA)
+		var tmplEventID string
+
+		suite.templateSave(eventID, "Practice")
+
+		// <- bot should call `answerInlineQuery` with the default and the template results
+		gock.New(telegock.AnswerInlineQuery).
+			Reply(200).
+			Filter(func(res *http.Response) bool {
+				body := suite.Decode(res.Request.Body)
+				results := body.Get("results").Array()
+				suite.Require().Len(results, 2)
+				suite.Equal("Tonight", results[0].Get("input_message_content.message_text").String())
+				suite.Equal(locale.QueryTemplate+"Practice", results[1].Get("title").String())
+				suite.Equal("Tonight\n\n"+queryA.Text, results[1].Get("input_message_content.message_text").String())
+				suite.NotEqual(results[0].Get("id").String(), results[1].Get("id").String())
+				tmplEventID = results[1].Get("id").String()
+				return true
+			}).JSON(telegock.Result(true))
+
+		// -> bot update `inline_query`
+		gock.New(telegock.GetUpdates).
+			Reply(200).
+			JSON(telegock.Updates().InlineQuery(tele.Query{
+				Sender:   userJohn,
+				Text:     "Tonight",
+				ChatType: "supergroup",
+			}))
+
+		suite.NoPending()
+		suite.NoUnmatched()
+
+		// <- bot should call `editMessageText`
+		gock.New(telegock.EditMessageText).Reply(200).JSON(telegock.Result(true))
+
+		// -> bot update `chosen_inline_result`
+		gock.New(telegock.GetUpdates).
+			Reply(200).
+			JSON(telegock.Updates().InlineResult(tele.InlineResult{
+				Sender:    userJohn,
+				ResultID:  tmplEventID,
+				Query:     "Tonight",
+				MessageID: "test-inline-message-template",
+			}))
+
+		suite.NoPending()
+		suite.NoUnmatched()
+
+		event, err := suite.app.srv.Event.Get(context.Background(), tmplEventID)
+		suite.Require().NoError(err)
+		suite.Equal("Tonight\n\n"+queryA.Text, event.Caption)
+		suite.Require().NotNil(event.Post)
+		suite.Equal("test-inline-message-template", event.Post.InlineMessageID)
+	})
+
+	suite.Run("apply template settings", func() {
+		eventID := suite.eventPublish(queryA)
+		suite.templateSave(eventID, "Practice")
+
+		user, err := suite.app.srv.User.Get(context.Background(), models.NewProfile(*userJohn))
+		suite.Require().NoError(err)
+		suite.Require().NotEmpty(user.Settings.Templates)
+		tmpl := user.Settings.Templates[0]
+
+		// <- bot should call `answerCallbackQuery` and `editMessageText`
+		gock.New(telegock.AnswerCallbackQuery).
+			Reply(200).
+			Filter(func(res *http.Response) bool {
+				body := suite.Decode(res.Request.Body)
+				suite.Equal(fmt.Sprintf(locale.TemplateApplied, tmpl.Name), body.Get("text").String())
+				return true
+			}).JSON(telegock.Result(true))
+		gock.New(telegock.EditMessageText).Reply(200).JSON(telegock.Result(true))
+
+		// -> bot update `callback_query`
+		gock.New(telegock.GetUpdates).
+			Reply(200).
+			JSON(telegock.Updates().CallbackQuery(tele.Callback{
+				Sender:  userJohn,
+				Message: &tele.Message{ID: 1, Chat: &tele.Chat{ID: userJohn.ID, Type: tele.ChatPrivate}},
+				Data:    "\fsettings_template_apply|" + tmpl.ID + "|rand",
+			}))
+
+		suite.NoPending()
+		suite.NoUnmatched()
+	})
+}
+
+// templateSave saves the event as the template with the given name.
+func (suite *AppTestSuite) templateSave(eventID, name string) {
+	// <- bot should call `answerCallbackQuery` and `editMessageText`
+	gock.New(telegock.AnswerCallbackQuery).Reply(200).JSON(telegock.Result(true))
+	gock.New(telegock.EditMessageText).
+		Reply(200).
+		Filter(func(res *http.Response) bool {
+			body := suite.Decode(res.Request.Body)
+			suite.Equal(locale.TemplateAsk, body.Get("text").String())
+			return true
+		}).JSON(telegock.Result(true))
+
+	// -> bot update `callback_query`
+	gock.New(telegock.GetUpdates).
+		Reply(200).
+		JSON(telegock.Updates().CallbackQuery(tele.Callback{
+			Sender:  userJohn,
+			Message: &tele.Message{ID: 1, Chat: &tele.Chat{ID: userJohn.ID, Type: tele.ChatPrivate}},
+			Data:    "\fevent_template|" + eventID + "|rand",
+		}))
+
+	suite.NoPending()
+	suite.NoUnmatched()
+
+	// <- bot should call `sendMessage` twice: confirmation and event scene
+	gock.New(telegock.SendMessage).
+		Reply(200).
+		Filter(func(res *http.Response) bool {
+			body := suite.Decode(res.Request.Body)
+			suite.Equal(fmt.Sprintf(locale.TemplateSaved, name), body.Get("text").String())
+			return true
+		}).JSON(telegock.Result(&tele.Message{}))
+	gock.New(telegock.SendMessage).Reply(200).JSON(telegock.Result(&tele.Message{}))
+
+	// -> bot update `message`
+	gock.New(telegock.GetUpdates).
+		Reply(200).
+		JSON(telegock.Updates().Message(tele.Message{
+			Sender: userJohn,
+			Chat:   &tele.Chat{ID: userJohn.ID, Type: tele.ChatPrivate},
+			Text:   " " + name + " ",
+		}))
+
+	suite.NoPending()
+	suite.NoUnmatched()
+}
+
+func (suite *AppTestSuite) TestSettingsDancer() {
+	suite.Run("set dance level and partner level", func() {
+		// <- bot should call `answerCallbackQuery` and `editMessageText`
+		gock.New(telegock.AnswerCallbackQuery).Reply(200).JSON(telegock.Result(true))
+		gock.New(telegock.EditMessageText).
+			Reply(200).
+			Filter(func(res *http.Response) bool {
+				body := suite.Decode(res.Request.Body)
+				suite.Equal(fmt.Sprintf(locale.DancerProfile,
+					locale.Level[models.LevelIntermediate],
+					locale.PartnerLevel[models.LevelNone]), body.Get("text").String())
+				kbd := gjson.Parse(body.Get("reply_markup").String()).Get("inline_keyboard")
+				suite.Equal(locale.BtnCheckMark+locale.BtnDancerLevel[models.LevelIntermediate], kbd.Get("2.0.text").String())
+				suite.Equal(locale.BtnCheckMark+locale.BtnPartnerLevel[models.LevelNone], kbd.Get("0.1.text").String())
+				return true
+			}).JSON(telegock.Result(true))
+
+		// -> bot update `callback_query`
+		gock.New(telegock.GetUpdates).
+			Reply(200).
+			JSON(telegock.Updates().CallbackQuery(tele.Callback{
+				Sender:  userJohn,
+				Message: &tele.Message{ID: 1, Chat: &tele.Chat{ID: userJohn.ID, Type: tele.ChatPrivate}},
+				Data:    "\fsettings_dancer_set|level|intermediate|rand",
+			}))
+
+		suite.NoPending()
+		suite.NoUnmatched()
+
+		// <- bot should call `answerCallbackQuery` and `editMessageText`
+		gock.New(telegock.AnswerCallbackQuery).Reply(200).JSON(telegock.Result(true))
+		gock.New(telegock.EditMessageText).
+			Reply(200).
+			Filter(func(res *http.Response) bool {
+				body := suite.Decode(res.Request.Body)
+				suite.Equal(fmt.Sprintf(locale.DancerProfile,
+					locale.Level[models.LevelIntermediate],
+					locale.PartnerLevel[models.LevelAdvanced]), body.Get("text").String())
+				return true
+			}).JSON(telegock.Result(true))
+
+		// -> bot update `callback_query`
+		gock.New(telegock.GetUpdates).
+			Reply(200).
+			JSON(telegock.Updates().CallbackQuery(tele.Callback{
+				Sender:  userJohn,
+				Message: &tele.Message{ID: 1, Chat: &tele.Chat{ID: userJohn.ID, Type: tele.ChatPrivate}},
+				Data:    "\fsettings_dancer_set|partner|advanced|rand",
+			}))
+
+		suite.NoPending()
+		suite.NoUnmatched()
+	})
+}
@@ -24,13 +24,34 @@ type DB struct {
 // Settings - application settings
 type Settings struct {
 	QueryThumbUrl         string          `env:"THUMBNAIL_URL"` // URL for thumbnail image for query answer
+	Timezone              string          `env:"TIMEZONE"`      // Default IANA time zone for the event start time
 	EventIDLen            int             // Length of event ID
+	InviteTokenLen        int             // Length of co-organizer invite token
+	ClaimTokenLen         int             // Length of the token to claim the registration by the partner registered by name
 	EventTextMaxLen       int             // Maximum length for event text in runes
 	DancerNameMaxLen      int             // Maximum length for dancer name in runes
+	EventsPageSize        int             // Number of events per page in the owner events list
+	DancersPageSize       int             // Number of dancer button rows per page in the event dancers scene
+	RecentPartnersEvents  int             // Number of the owner recent events to look for the recent partners on auto pairing
+	TemplatesMax          int             // Maximum number of event templates per user
+	TemplateIDLen         int             // Length of event template ID
+	TemplateNameMaxLen    int             // Maximum length for event template name in runes
 	RendererRepeats       []time.Duration // Time intervals for event rendering repeats
 	ReRenderOnStartup     time.Duration   // Re-render on startup the recent events that were updated not older than this duration
 	DraftCleanupOlderThan time.Duration   // Cleanup event drafts that were created older than this duration
 	DraftCleanupEvery     time.Duration   // Cleanup event drafts every this duration since startup
+	AutoCloseBefore       time.Duration   // Close the registration automatically this duration before the event start
+	AutoCloseEvery        time.Duration   // Check the events to be closed automatically every this duration since startup
+	ReminderOffsets       []time.Duration // Remind registered dancers these durations before the event start
+	ReminderEvery         time.Duration   // Check the events to send reminders every this duration since startup
+	RecurrenceEvery       time.Duration   // Check the recurring events to create the next ones every this duration since startup
+	ConsentTimeout        time.Duration   // Remove the couples not confirmed by the partner after this duration
+	ConsentExpireEvery    time.Duration   // Check the couples waiting for the partner confirmation every this duration since startup
+	SingleExpireGrace     time.Duration   // Remove the stale singles this duration after the warning unless they refresh the registration
+	SingleExpireEvery     time.Duration   // Check the stale singles every this duration since startup
+	SingleAlertThrottle   time.Duration   // Notify the single about the compatible singles not more often than this duration
+	DigestPeriod          time.Duration   // Send the activity digest to the subscribed owners once per this duration
+	DigestEvery           time.Duration   // Check the owners due for the activity digest every this duration since startup
 }
 
 // Bot is Telegram bot configuration
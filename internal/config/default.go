@@ -28,20 +28,30 @@ func Default() Config {
 			},
 			CommandsPrivate: []tele.Command{
 				{Text: "start", Description: locale.CmdDescriptionStart},
+				{Text: "events", Description: locale.CmdDescriptionEvents},
 				{Text: "settings", Description: locale.CmdDescriptionSettings},
 			},
 		},
 
 		// Database default configuration
 		DB: DB{
-			Version: 2,
+			Version: 4,
 		},
 
 		// Application default settings
 		Settings: Settings{
-			EventIDLen:       12,
-			EventTextMaxLen:  2048,
-			DancerNameMaxLen: 64,
+			Timezone:             "Europe/Moscow",
+			EventIDLen:           12,
+			InviteTokenLen:       8,
+			ClaimTokenLen:        8,
+			EventTextMaxLen:      2048,
+			DancerNameMaxLen:     64,
+			EventsPageSize:       5,
+			DancersPageSize:      20,
+			RecentPartnersEvents: 5,
+			TemplatesMax:         10,
+			TemplateIDLen:        6,
+			TemplateNameMaxLen:   32,
 			RendererRepeats: []time.Duration{
 				03 * time.Second,
 				10 * time.Second,
@@ -53,6 +63,21 @@ func Default() Config {
 			ReRenderOnStartup:     12 * time.Hour,
 			DraftCleanupOlderThan: 72 * time.Hour,
 			DraftCleanupEvery:     6 * time.Hour,
+			AutoCloseBefore:       1 * time.Hour,
+			AutoCloseEvery:        1 * time.Minute,
+			ReminderOffsets: []time.Duration{
+				24 * time.Hour,
+				02 * time.Hour,
+			},
+			ReminderEvery:       1 * time.Minute,
+			RecurrenceEvery:     1 * time.Minute,
+			ConsentTimeout:      12 * time.Hour,
+			ConsentExpireEvery:  1 * time.Minute,
+			SingleExpireGrace:   12 * time.Hour,
+			SingleExpireEvery:   10 * time.Minute,
+			SingleAlertThrottle: 1 * time.Hour,
+			DigestPeriod:        24 * time.Hour,
+			DigestEvery:         10 * time.Minute,
 		},
 	}
 }
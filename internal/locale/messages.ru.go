@@ -20,32 +20,66 @@ const (
 …и нажми «Опубликовать»
 `
 	CmdDescriptionStart    = "📖 Справка"
+	CmdDescriptionEvents   = "📋 Мои мероприятия"
 	CmdDescriptionSettings = "⚙️ Настройки"
 
 	BtnTry   = "👉 Попробовать"
 	BtnClose = "✖️Закрыть"
 	BtnBack  = "🔙 Назад"
 	Ok       = "Ок"
+	DoneOK   = "Готово 👌"
 
 	ErrNotImplemented    = "Пока в разработке 🚧"
 	ErrSomethingWrong    = "Что-то пошло не так 👾"
 	ErrStartPayload      = "Некорректные параметры 👾"
 	ErrDancerNameTooLong = "Имя партнера слишком длинное 🤔"
 	ErrSingleNotFound    = "Такой танцор не найден 🤷‍♀️"
+	ErrAccessDenied      = "Управлять мероприятием может только организатор 🤷‍♀️"
+	ErrNotOwner          = "Это может сделать только автор мероприятия 🤷‍♀️"
+	ErrInviteInvalid     = "Ссылка-приглашение недействительна 🤷‍♀️\n\nПопроси организатора прислать новую."
 
-	PostCouples = "👫 <b>Пары</b>\n"
+	PostCouples      = "👫 <b>Пары</b>\n"
+	PostWaitlist     = "⏳ <b>Лист ожидания</b>\n"
+	PostParticipants = "🙋 <b>Участники</b>\n"
+	PostPending      = "🕓 <b>Ждут подтверждения</b>\n"
+	PostHeadcount    = "\nВсего: %d · 🕺 %d · 💃 %d\n"
 
 	SignupPlaceholder   = "Введи имя партнера…"
 	SignupNotRegistered = "Отправь мне имя партнера или выбери из списка..."
 	SignupSingle        = "%s Ты в поиске пары. Если пара уже нашлась, отправь мне имя партнера или выбери из списка..."
 	SignupInCouple      = "👫Вы записаны в паре с %s"
+	SignupInWaitlist    = "⏳ Вы в листе ожидания в паре с %s. Если освободится место, я сообщу 🤗"
 	SignupForbidden     = "Тебе запрещено записываться на это мероприятие 😔\n\nОбратись к организатору, чтобы уточнить причину."
 	BtnSignupContact    = "👥 Из списка контактов"
 	BtnRemove           = "🗑️ Удалить регистрацию"
+	SignupPending       = "🕓 Ждем, когда %s подтвердит регистрацию. Если передумаешь, запрос можно отменить."
+	SignupSolo          = "Чтобы записаться на это мероприятие, нажми «Записаться»"
+	SignupParticipant   = "🙋 Ты записан на это мероприятие"
+	BtnRegister         = "🙋 Записаться"
+	BtnParticipant      = "🙋"
+	BtnReplacePartner   = "🔁 Сменить партнера"
+	BtnRoleSwitch       = "🔃 Поменяться ролями"
+	SignupReplace       = "Отправь мне имя нового партнера или выбери из списка контактов. Ваша пара останется на своем месте в списке."
+	SignupPair          = "🙋 %s тоже ищет пару. Чтобы записаться вместе, выбери в списке ниже."
+	ErrPairNotFound     = "Этот танцор уже нашел пару или удалил регистрацию 🤷‍♀️"
+	BtnPairWith         = "👫 Записаться вместе"
 
 	ResultSuccessCouple       = "👫 Вы зарегистрировались в паре с %s"
+	ResultSuccessWaitlist     = "⏳ Свободных мест нет, поэтому я записал вас с %s в лист ожидания.\n\nЕсли освободится место, я сообщу 🤗"
 	ResultSuccessSingle       = "%s Добавил тебя в список ищущих пару.\n\nЕсли кто-то зарегистрируется вместе с тобой, я об этом сообщу 🤗"
 	ResultSuccessRemoved      = "Регистрация удалена 🗑"
+	ResultSuccessPending      = "🕓 Отправил %s запрос на регистрацию в паре. Как только он будет подтвержден, я сообщу 🤗"
+	ResultAlreadyPending      = "Ты уже ждешь подтверждения от другого партнера 🤓\n\nЕсли нужно записаться с кем-то другим, отмени запрос и начни заново."
+	ResultPendingNotFound     = "Этот запрос уже неактуален 🤷‍♀️"
+	ResultSuccessClaimed      = "👫 Готово! Теперь ты записан в паре с %s и можешь сам управлять регистрацией"
+	ResultSuccessReplaced     = "🔁 Теперь ты в паре с %s. Ваша пара осталась на своем месте в списке"
+	ResultSuccessRoleSwitched = "🔃 Поменял роли в вашей паре: теперь ты %s, а %s %s"
+	ResultDeadlinePassed      = "Запись на это мероприятие уже закончилась ⏰"
+	ResultCancelClosed        = "Отменить регистрацию самостоятельно уже нельзя ⏰\n\nЕсли планы изменились, напиши организатору: %s"
+	ResultClaimNotFound       = "Эта ссылка уже неактуальна: регистрацию уже подтвердили или отменили 🤷‍♀️"
+	ClaimLink                 = "\n\n🔗 Перешли эту ссылку партнеру, чтобы он мог сам управлять регистрацией и получать уведомления:\n%s"
+	ResultSuccessParticipant  = "🙋 Записал тебя на мероприятие 🎉"
+	ResultAlreadyParticipant  = "Ты уже записан на это мероприятие 🤓"
 	ResultAlreadyAsSingle     = "%s Ты в поиске пары. Если пара уже нашлась, отправь мне имя партнера или выбери из списка..."
 	ResultAlreadyInCouple     = "Вы уже записаны в паре с %s 🤔\n\nЕсли нужно записаться кем-то другим, удали регистрацию и начни заново."
 	ResultAlreadyInSameCouple = "Вы уже записаны в паре с этим партнером 🤓"
@@ -61,8 +95,39 @@ const (
 )
 
 const (
-	SettingsCaption = "🔧 <b>Настройки для организаторов</b>\n\n"
-	BtnSettingsHelp = "Подробнее о настройках"
+	SettingsCaption      = "🔧 <b>Настройки для организаторов</b>\n\n"
+	SettingsBlocklist    = "\n🚫 В черном списке: %d"
+	SettingsCoOrganizers = "\n👥 Соорганизаторов по умолчанию: %d"
+	SettingsTemplates    = "\n📑 Шаблонов мероприятий: %d"
+	BtnSettingsHelp      = "Подробнее о настройках"
+	BtnBlocklist         = "🚫 Черный список"
+	BtnDefaultCoOrgs     = "👥 Соорганизаторы по умолчанию"
+	BtnTemplates         = "📑 Шаблоны мероприятий"
+	BtnDancerProfile     = "📶 Мой уровень"
+	BtnNotifications     = "🔔 Уведомления"
+	SettingsMuted        = "\n🔕 Отключено уведомлений: %d"
+	SettingsLevel        = "\n📶 Мой уровень: %s"
+
+	DancerProfile = "📶 <b>Мой уровень</b>\n\nУровень и предпочтения по уровню партнера помогают организаторам подбирать пары. " +
+		"Я сохраняю их вместе с каждой записью на мероприятие.\n\n" +
+		"Мой уровень: %s\nУровень партнера: %s"
+
+	DefaultCoOrganizers = "👥 <b>Соорганизаторы по умолчанию</b>\n\nСоорганизаторы по умолчанию добавляются ко всем твоим новым мероприятиям.\n\nЧтобы добавить соорганизатора по умолчанию, нажми «☆ По умолчанию» рядом с его именем в списке соорганизаторов мероприятия."
+
+	Templates        = "📑 <b>Шаблоны мероприятий</b>\n\nШаблон хранит настройки мероприятия и текст, который добавляется к анонсу. Шаблоны появляются в списке вариантов, когда ты публикуешь анонс через меня в чате.\n\nНажми на шаблон, чтобы его настройки применялись ко всем новым мероприятиям.\n\nЧтобы создать шаблон, открой мероприятие в /events и нажми «💾 Сохранить как шаблон»."
+	TemplatesList    = "\n\n<b>Шаблоны:</b>\n"
+	TemplateApplied  = "Настройки шаблона «%s» будут применяться к новым мероприятиям 👌"
+	BtnTemplateApply = "📑 "
+
+	NotifySettings = "🔔 <b>Уведомления</b>\n\nВыбери, какие уведомления тебе присылать. " +
+		"Уведомления о записи, подтверждениях и отменах приходят всегда.\n\n" +
+		"Чтобы отключить все уведомления по одному мероприятию, кроме запросов на подтверждение пары, " +
+		"нажми «🔕 Не уведомлять об этом мероприятии» под уведомлением."
+	NotifySettingsMuted = "\n\n<b>Без уведомлений:</b>\n"
+	BtnUnmute           = "🔔 Включить: "
+
+	Blocklist     = "🚫 <b>Черный список</b>\n\nТанцорам из черного списка запрещено записываться на все твои мероприятия. Если танцор уже записан, его регистрация сохранится.\n\nЧтобы добавить танцора, отправь мне его @username или перешли сообщение от него."
+	BlocklistList = "\n\n<b>В черном списке:</b>\n"
 
 	SettingsHelp = `🔧 <b>Настройки для организаторов</b>
 
@@ -71,6 +136,27 @@ const (
 
 Если включить автоматический подбор пар, то бот будет самостоятельно составлять пары из танцоров, которые ищут партнера.
 
+📶 <b>Мой уровень</b>
+Твой уровень и предпочтения по уровню партнера. Уровень виден в списке ожидания и учитывается при автоматическом подборе пар.
+
+🎲 <b>Способ подбора</b>
+• <i>по очереди</i> — в пару встает тот, кто дольше всех ждет партнера;
+• <i>случайно</i> — партнер выбирается случайно из списка ожидания;
+• <i>новые партнеры</i> — бот старается не ставить в пару тех, кто уже танцевал вместе на твоих последних мероприятиях;
+• <i>по уровню</i> — в пару встает танцор с ближайшим уровнем с учетом предпочтений.
+
+🔔 <b>Уведомления</b>
+Можно отключить уведомления о смене партнера, автоподборе пары, поиске пары, активности на твоих мероприятиях и напоминания, а также все уведомления по отдельному мероприятию, кроме запросов на подтверждение пары.
+
+🚫 <b>Черный список</b>
+Танцорам из черного списка запрещено записываться на все твои мероприятия, включая ранее созданные.
+
+👥 <b>Соорганизаторы по умолчанию</b>
+Соорганизаторы по умолчанию добавляются ко всем твоим новым мероприятиям.
+
+📑 <b>Шаблоны мероприятий</b>
+Шаблон хранит настройки мероприятия и текст, который добавляется к анонсу. Сохранить мероприятие как шаблон можно в /events.
+
 ℹ️ <i>Изменение настроек влияет только на новые мероприятия и не влияет на ранее созданные.</i>
 
 👉 Если добавить бота в группу, то танцоры будут получать уведомления со ссылкой на пост в группе.
@@ -82,17 +168,182 @@ var SettingsAutoPairing = map[bool]string{
 	true:  "🙋‍♀️ Пары подбираются автоматически",
 }
 
+var BtnCoOrganizerDefault = map[bool]string{
+	false: "☆ По умолчанию",
+	true:  "★ По умолчанию",
+}
+
+var SettingsPairing = map[models.Pairing]string{
+	models.PairingFIFO:   "\n🎲 Способ подбора: по очереди",
+	models.PairingRandom: "\n🎲 Способ подбора: случайно",
+	models.PairingRecent: "\n🎲 Способ подбора: новые партнеры",
+	models.PairingLevel:  "\n🎲 Способ подбора: по уровню",
+}
+
+var SettingsOwnerAlerts = map[models.OwnerAlerts]string{
+	models.OwnerAlertsOff:      "\n🔕 Уведомления о записи: выкл.",
+	models.OwnerAlertsRealtime: "\n🔔 Уведомления о записи: сразу",
+	models.OwnerAlertsDigest:   "\n📊 Уведомления о записи: сводка раз в день",
+}
+
+var BtnOwnerAlerts = map[models.OwnerAlerts]string{
+	models.OwnerAlertsOff:      "🔕 Не уведомлять о записи",
+	models.OwnerAlertsRealtime: "🔔 Уведомлять о каждой записи",
+	models.OwnerAlertsDigest:   "📊 Присылать сводку раз в день",
+}
+
+var BtnPairingMode = map[models.Pairing]string{
+	models.PairingFIFO:   "🎲 Подбирать по очереди",
+	models.PairingRandom: "🎲 Подбирать случайно",
+	models.PairingRecent: "🎲 Подбирать новых партнеров",
+	models.PairingLevel:  "🎲 Подбирать по уровню",
+}
+
+var BtnSolo = map[bool]string{
+	false: "🙋 Запись без пар",
+	true:  "👫 Запись в парах",
+}
+
+var BtnConsent = map[bool]string{
+	false: "🤝 Подтверждение партнером: выкл.",
+	true:  "🤝 Подтверждение партнером: вкл.",
+}
+
+var NotificationCategory = map[models.NotificationCategory]string{
+	models.CategoryPartner:   "Смена партнера",
+	models.CategoryAutoPair:  "Автоподбор пары",
+	models.CategorySingles:   "Поиск пары",
+	models.CategoryOrganizer: "Мои мероприятия",
+	models.CategoryReminder:  "Напоминания",
+}
+
+var BtnNotificationCategory = map[bool]string{
+	false: "🔕 %s: выкл.",
+	true:  "🔔 %s: вкл.",
+}
+
+var BtnSingleAlerts = map[bool]string{
+	false: "🔔 Сообщать о новых ищущих пару: выкл.",
+	true:  "🔔 Сообщать о новых ищущих пару: вкл.",
+}
+
+var BtnConsentAnswer = map[bool]string{
+	false: "❌ Отклонить",
+	true:  "✅ Подтвердить",
+}
+
 var BtnAutoPairing = map[bool]string{
 	false: "🙋‍♀️ Подбирать пару автоматически",
 	true:  "🙋‍♀️ Разрешить выбор из списка ожидания",
 }
 
+const (
+	EventsCaption   = "📋 <b>Мои мероприятия</b>\n\nВыбери мероприятие, чтобы управлять им:"
+	EventsEmpty     = "📋 <b>Мои мероприятия</b>\n\nУ тебя пока нет опубликованных мероприятий.\n\nЧтобы опубликовать анонс, напиши в своей группе или канале <b>@%s [Текст анонса]</b>"
+	EventScene      = "📋 <b>Мероприятие</b>\n\n%s\n\n👫 Пар: %d\n🙋 Ищут пару: %d"
+	EventSceneSolo  = "📋 <b>Мероприятие</b>\n\n%s\n\n🙋 Участников: %d"
+	EventClosedOK   = "Готово 👌"
+	ErrSoloNotEmpty = "Режим записи можно изменить, только пока на мероприятие никто не записан 🤓"
+	EventWaitlist   = "\n⏳ В листе ожидания: %d"
+	EventPending    = "\n🕓 Ждут подтверждения: %d"
+	ConsentDeclined = "Хорошо, регистрация отклонена 👌"
+	EventLimit      = "\n👫 Лимит пар: %d"
+	EventLimitAsk   = "👫 Отправь мне максимальное количество пар.\n\nЕсли лимит не нужен, отправь 0."
+	ErrLimit        = "Нужно отправить целое число, например: 10 🤓"
+	BtnLimit        = "👫 Лимит пар"
+	EventCaptionAsk = "✏️ Отправь мне новый текст анонса.\n\nЯ обновлю анонс в чате, все записи сохранятся."
+	ErrCaption      = "Текст анонса не должен быть пустым или длиннее %d символов 🤓"
+	BtnCaption      = "✏️ Изменить текст"
+
+	EventRecurrence = "\n🔁 Повтор: %s"
+	EventCloned     = "📑 <b>Следующее мероприятие готово</b>\n\n%s\n\n" +
+		"Пары, которые захотели записаться на следующее мероприятие, уже записаны. " +
+		"Нажми кнопку ниже и выбери чат, чтобы опубликовать анонс 📣"
+	BtnClone   = "📑 Клонировать"
+	BtnPublish = "📣 Опубликовать"
+	RepeatOn   = "🔁 Готово! Я запишу вас на следующее мероприятие автоматически."
+	RepeatOff  = "Хорошо, на следующее мероприятие я вас записывать не буду 👌"
+
+	TemplateAsk       = "💾 Отправь мне название шаблона, например: Открытая практика\n\nВ шаблон сохранятся текст анонса и настройки мероприятия. Если шаблон с таким названием уже есть, я его заменю."
+	TemplateSaved     = "💾 Шаблон «%s» сохранен"
+	ErrTemplateName   = "Название шаблона не должно быть пустым или длиннее %d символов 🤓"
+	ErrTemplatesLimit = "Можно сохранить не больше %d шаблонов. Удали ненужные шаблоны в /settings 🤓"
+	BtnTemplateSave   = "💾 Сохранить как шаблон"
+
+	EventLevels      = "\n📶 Уровень для автоподбора: %s — %s"
+	EventLevelsScene = "📶 <b>Уровень для автоподбора</b>\n\n" +
+		"При автоматическом подборе пар я буду ставить в пары только танцоров с уровнем в этих пределах. " +
+		"Остальные танцоры останутся в списке ожидания, их можно поставить в пару вручную.\n\n" +
+		"Танцоры указывают свой уровень в /settings."
+	BtnLevels = "📶 Уровень для автоподбора"
+
+	EventStartsAt    = "\n🗓 Начало: %s"
+	EventStartsAtAsk = "🗓 Отправь мне дату и время начала мероприятия в формате <b>ДД.ММ.ГГГГ ЧЧ:ММ</b>, например: 31.12.2025 19:30\n\n" +
+		"Время указывается в часовом поясе <b>%s</b>. Чтобы указать другой часовой пояс, добавь его название после времени, например: 31.12.2025 19:30 Europe/Berlin\n\n" +
+		"Незадолго до начала я автоматически закрою запись.\n\nЧтобы удалить время начала, отправь 0."
+	ErrStartsAt = "Не получилось разобрать дату 🤓 Отправь её в формате ДД.ММ.ГГГГ ЧЧ:ММ, например: 31.12.2025 19:30"
+	BtnStartsAt = "🗓 Дата и время"
+	BtnPrev     = "◀️"
+	BtnNext     = "▶️"
+
+	EventSignupDeadline = "\n⏰ Запись до: %s"
+	EventCancelDeadline = "\n↩️ Отмена регистрации до: %s"
+	BtnSignupDeadline   = "⏰ Запись: %s"
+	BtnCancelDeadline   = "↩️ Отмена: %s"
+	DeadlineNone        = "до начала"
+	DeadlineBefore      = "за %d ч"
+	PostSignupDeadline  = "⏰ Запись до %s\n"
+	PostCancelDeadline  = "↩️ Отмена регистрации до %s\n"
+
+	EventSingleTTL        = "\n⌛️ Ищущие пару удаляются через: %s"
+	BtnSingleTTL          = "⌛️ Ищущие пару: %s"
+	SingleTTLNone         = "без ограничения"
+	SingleTTLDays         = "%d дн."
+	BtnSingleStill        = "🙋 Все еще ищу пару"
+	SingleRefreshed       = "Отлично, ты остаешься среди ищущих пару 👌"
+	BtnMuteEvent          = "🔕 Не уведомлять об этом мероприятии"
+	EventMuted            = "Уведомления об этом мероприятии отключены. Включить их можно в /settings"
+	SingleRefreshNotFound = "Тебя уже нет среди ищущих пару на этом мероприятии 🤷"
+
+	EventForbiddenCount = "\n🚫 Запрещена запись: %d"
+	EventForbidden      = "🚫 <b>Запрет записи</b>\n\nЧтобы запретить танцору записываться на мероприятие, отправь мне его @username или перешли сообщение от него.\n\nЕсли танцор уже записан, я удалю его регистрацию и сообщу об этом."
+	EventForbiddenList  = "\n\n<b>Запрещена запись:</b>\n"
+	ErrForbidUsername   = "Нужно отправить @username танцора или переслать сообщение от него 🤓"
+	BtnForbidden        = "🚫 Запрет записи"
+	BtnAllow            = "🔓 "
+
+	EventDancers         = "👥 <b>Участники</b>\n\nЗдесь можно записать танцоров, которые записались не через меня, пересадить танцора из списка ищущих пару в пару или удалить регистрацию.\n\nТанцоры с профилем в Telegram получат уведомление."
+	EventDancersCouples  = "\n\n<b>Пары:</b>\n"
+	EventDancersWaitlist = "\n\n<b>Лист ожидания:</b>\n"
+	EventDancersSingles  = "\n\n<b>Ищут пару:</b>\n"
+	EventDancersSolo     = "\n\n<b>Записались:</b>\n"
+	DancerCoupleAsk      = "👫 Отправь мне имена партнера и партнерши через «+», например: Иван Петров + @jane_doe"
+	DancerSingleAsk      = "%s Отправь мне имя или @username танцора или перешли сообщение от него."
+	DancerPartnerAsk     = "👫 Отправь мне имя или @username пары для %s или перешли сообщение от него."
+	ErrDancerCouple      = "Нужно отправить два имени через «+», например: Иван Петров + @jane_doe 🤓"
+	EventPairing         = "🤝 <b>Составить пары</b>\n\nВыбери партнера и партнершу из списка ищущих пару. Я запишу их в пару и сообщу им об этом."
+	EventPairingEmpty    = "\n\nСейчас некого поставить в пару 🤷"
+	BtnPairing           = "🤝 Составить пары"
+	BtnPairingEmpty      = " "
+	BtnDancers           = "🧑‍🤝‍🧑 Участники"
+	BtnDancerCoupleAdd   = "➕ 👫"
+	BtnDancerPair        = "👫"
+
+	CoOrganizers     = "👥 <b>Соорганизаторы</b>\n\nСоорганизаторы могут управлять мероприятием так же, как и ты.\n\nЧтобы добавить соорганизатора, отправь ему ссылку-приглашение:\n%s"
+	CoOrganizersList = "\n\n<b>Соорганизаторы:</b>\n"
+	InviteAccepted   = "👥 Теперь ты соорганизатор мероприятия 🎉"
+	BtnCoOrganizers  = "👥 Соорганизаторы"
+	BtnInviteRenew   = "🔄 Новая ссылка-приглашение"
+	BtnRemoveItem    = "✖️ "
+)
+
 const (
 	QueryTextEmpty        = "✏️ Напиши текст анонса"
 	QueryDescriptionEmpty = "Например: Класс по основам танца 1 марта"
 	QueryDescription      = "Нажми для публикации анонса"
 	QueryRemaining        = "Осталось %d %s"
 	QueryOverflow         = "⚠️ Длина сообщения превышена!"
+	QueryTemplate         = "📑 "
 )
 
 var NumSymbols = numerals.Ru("символ", "символа", "символов")
@@ -102,6 +353,7 @@ type roleMap map[models.Role]string
 var RoleIcon = roleMap{
 	models.RoleLeader:   "🕺",
 	models.RoleFollower: "💃",
+	models.RoleSwitch:   "🔄",
 }
 
 var PostSingles = roleMap{
@@ -112,11 +364,110 @@ var PostSingles = roleMap{
 var BtnAsSingle = roleMap{
 	models.RoleLeader:   "🙋‍♂️ Ищу партнершу",
 	models.RoleFollower: "🙋‍♀️ Ищу партнера",
+	models.RoleSwitch:   "🔄 Ищу пару, могу в любой роли",
+}
+
+var BtnDancerSingleAdd = roleMap{
+	models.RoleLeader:   "➕ 🕺",
+	models.RoleFollower: "➕ 💃",
+	models.RoleSwitch:   "➕ 🔄",
+}
+
+// ResultByOrganizer - messages to the organizer on the failed registration changes.
+var ResultByOrganizer = map[models.RegistrationResult]string{
+	models.ResultAlreadyAsSingle:     "Этот танцор уже в списке ищущих пару 🤓",
+	models.ResultAlreadyInCouple:     "Этот танцор уже записан в пару 🤓",
+	models.ResultAlreadyInSameCouple: "Эта пара уже записана 🤓",
+	models.ResultPartnerTaken:        "Пара этого танцора уже записана с другим танцором 🤓",
+	models.ResultSelfNotAllowed:      "Нельзя записать танцора в пару с самим собой 🤓",
+	models.ResultDancerForbidden:     "Этому танцору запрещена запись на мероприятие 🤓",
+	models.ResultPartnerForbidden:    "Этому танцору запрещена запись на мероприятие 🤓",
+	models.ResultWasNotRegistered:    "Этот танцор уже не записан на мероприятие 🤓",
+	models.ResultAlreadyParticipant:  "Этот танцор уже записан на мероприятие 🤓",
+	models.ResultEventClosed:         "На это мероприятие записываются без пар 🤓",
 }
 
 var IconSingle = roleMap{
 	models.RoleLeader:   "🙋‍♂️",
 	models.RoleFollower: "🙋‍♀️",
+	models.RoleSwitch:   "🔄",
 }
 
 const BtnChatLink = "Посмотреть"
+
+type closedForMap map[models.ClosedFor]string
+
+var PostClosed = closedForMap{
+	models.ClosedForAll:             "🔒 <b>Запись закрыта</b>",
+	models.ClosedForSingles:         "🔒 <b>Запись только в паре</b>",
+	models.ClosedForSingleLeaders:   "🔒 <b>Партнеры записываются только в паре</b>",
+	models.ClosedForSingleFollowers: "🔒 <b>Партнерши записываются только в паре</b>",
+}
+
+var BtnClosedFor = closedForMap{
+	models.ClosedForNone:            "🔓 Запись открыта",
+	models.ClosedForAll:             "🔒 Запись закрыта",
+	models.ClosedForSingles:         "👫 Только в паре",
+	models.ClosedForSingleLeaders:   "🕺 Партнеры только в паре",
+	models.ClosedForSingleFollowers: "💃 Партнерши только в паре",
+}
+
+const BtnCheckMark = "✅ "
+
+var Recurrence = map[models.Recurrence]string{
+	models.RecurrenceWeekly:   "каждую неделю",
+	models.RecurrenceBiweekly: "раз в две недели",
+}
+
+var BtnRecurrence = map[models.Recurrence]string{
+	models.RecurrenceNone:     "Не повторять",
+	models.RecurrenceWeekly:   "Каждую неделю",
+	models.RecurrenceBiweekly: "Раз в 2 недели",
+}
+
+var Level = map[models.Level]string{
+	models.LevelNone:         "не указан",
+	models.LevelBeginner:     "начинающий",
+	models.LevelIntermediate: "средний",
+	models.LevelAdvanced:     "продвинутый",
+}
+
+var PartnerLevel = map[models.Level]string{
+	models.LevelNone:         "любой",
+	models.LevelBeginner:     "начинающий",
+	models.LevelIntermediate: "средний",
+	models.LevelAdvanced:     "продвинутый",
+}
+
+var BtnLevelMin = map[models.Level]string{
+	models.LevelNone:         "От: любой",
+	models.LevelBeginner:     "От: начинающий",
+	models.LevelIntermediate: "От: средний",
+	models.LevelAdvanced:     "От: продвинутый",
+}
+
+var BtnLevelMax = map[models.Level]string{
+	models.LevelNone:         "До: любой",
+	models.LevelBeginner:     "До: начинающий",
+	models.LevelIntermediate: "До: средний",
+	models.LevelAdvanced:     "До: продвинутый",
+}
+
+var BtnDancerLevel = map[models.Level]string{
+	models.LevelNone:         "Я: не указан",
+	models.LevelBeginner:     "Я: начинающий",
+	models.LevelIntermediate: "Я: средний",
+	models.LevelAdvanced:     "Я: продвинутый",
+}
+
+var BtnPartnerLevel = map[models.Level]string{
+	models.LevelNone:         "Партнер: любой",
+	models.LevelBeginner:     "Партнер: начинающий",
+	models.LevelIntermediate: "Партнер: средний",
+	models.LevelAdvanced:     "Партнер: продвинутый",
+}
+
+var BtnRepeat = map[bool]string{
+	false: "🔁 Записывать нас на следующие",
+	true:  "⏹ Не записывать нас на следующие",
+}
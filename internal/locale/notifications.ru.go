@@ -35,4 +35,168 @@ var Notifications = map[models.NotificationTmpl]string{
 
 {{template "dancer" .Partner}} отменил вашу регистрацию. 
 Я записал тебя вместе с {{template "dancer" .NewPartner}} 👌`,
+
+	// language=GoTemplate
+	models.TmplWaitlistPromoted: `🔔 {{.Event.Caption}}
+
+Освободилось место! Вы с {{template "dancer" .Partner}} перешли из листа ожидания в список пар 🎉`,
+
+	// language=GoTemplate
+	models.TmplDancerForbidden: `🔔 {{.Event.Caption}}
+
+Организатор запретил тебе записываться на это мероприятие. Твоя регистрация удалена 😔`,
+
+	// language=GoTemplate
+	models.TmplPartnerRemoved: `🔔 {{.Event.Caption}}
+
+Организатор удалил {{template "dancer" .Partner}} из списка участников, поэтому ваша регистрация отменена 😔`,
+
+	// language=GoTemplate
+	models.TmplPartnerRemovedWithSingle: `🔔 {{.Event.Caption}}
+
+Организатор удалил {{template "dancer" .Partner}} из списка участников. Я вернул тебя в список ищущих пару 🤗`,
+
+	// language=GoTemplate
+	models.TmplPartnerRemovedAutoPair: `🔔 {{.Event.Caption}}
+
+Организатор удалил {{template "dancer" .Partner}} из списка участников. 
+Я записал тебя вместе с {{template "dancer" .NewPartner}} 👌`,
+
+	// language=GoTemplate
+	models.TmplCoupleAddedByOrganizer: `🔔 {{.Event.Caption}}
+
+Организатор записал тебя в пару с {{template "dancer" .Partner}} 🎉`,
+
+	// language=GoTemplate
+	models.TmplSingleAddedByOrganizer: `🔔 {{.Event.Caption}}
+
+Организатор записал тебя в список ищущих пару 🙋`,
+
+	// language=GoTemplate
+	models.TmplParticipantAddedByOrganizer: `🔔 {{.Event.Caption}}
+
+Организатор записал тебя на это мероприятие 🙋`,
+
+	// language=GoTemplate
+	models.TmplRemovedByOrganizer: `🔔 {{.Event.Caption}}
+
+Организатор удалил тебя из списка участников 😔`,
+
+	// language=GoTemplate
+	models.TmplPairedByOrganizer: `🔔 {{.Event.Caption}}
+
+Организатор подобрал тебе пару: {{template "dancer" .Partner}} 👌`,
+
+	// language=GoTemplate
+	models.TmplCoOrganizerAdded: `🔔 {{.Event.Caption}}
+
+{{template "dancer" .Partner}} теперь соорганизатор мероприятия 👥`,
+
+	// language=GoTemplate
+	models.TmplNextEventCreated: `🔁 {{.Event.Caption}}
+
+Я подготовил анонс следующего мероприятия. Нажми кнопку ниже и выбери чат, чтобы опубликовать его 📣`,
+
+	// language=GoTemplate
+	models.TmplConsentRequest: `🔔 {{.Event.Caption}}
+
+{{template "dancer" .Partner}} хочет записаться с тобой в паре. Подтверди, пожалуйста 🤝`,
+
+	// language=GoTemplate
+	models.TmplConsentAccepted: `🔔 {{.Event.Caption}}
+
+{{template "dancer" .Partner}} подтвердил регистрацию, вы записаны в паре! 🎉`,
+
+	// language=GoTemplate
+	models.TmplConsentDeclined: `🔔 {{.Event.Caption}}
+
+{{template "dancer" .Partner}} отклонил регистрацию в паре с тобой 😔`,
+
+	// language=GoTemplate
+	models.TmplConsentFailed: `🔔 {{.Event.Caption}}
+
+{{template "dancer" .Partner}} подтвердил регистрацию, но записать вас в паре не получилось: запись закрыта или кто-то из вас уже записан 😔`,
+
+	// language=GoTemplate
+	models.TmplConsentExpired: `🔔 {{.Event.Caption}}
+
+{{template "dancer" .Partner}} не подтвердил регистрацию вовремя, поэтому я ее отменил 😔`,
+
+	// language=GoTemplate
+	models.TmplPartnerReplaced: `🔔 {{.Event.Caption}}
+
+{{template "dancer" .Partner}} записался в паре с {{template "dancer" .NewPartner}}, поэтому ваша регистрация отменена.`,
+
+	// language=GoTemplate
+	models.TmplRoleSwitched: `🔔 {{.Event.Caption}}
+
+{{template "dancer" .Partner}} поменял роли в вашей паре 🔃`,
+
+	// language=GoTemplate
+	models.TmplSingleExpiring: `🔔 {{.Event.Caption}}
+
+Ты давно ищешь пару на это мероприятие. Если все еще ищешь, нажми кнопку ниже, иначе скоро я удалю твою регистрацию ⌛️`,
+
+	// language=GoTemplate
+	models.TmplSingleExpired: `🔔 {{.Event.Caption}}
+
+Ты долго не подтверждал, что все еще ищешь пару, поэтому я удалил твою регистрацию. Если захочешь, запишись заново 🙂`,
+
+	// language=GoTemplate
+	models.TmplSingleJoined: `🔔 {{.Event.Caption}}
+
+{{template "dancer" .Partner}} тоже ищет пару на это мероприятие. Нажми кнопку ниже, чтобы записаться вместе 👫
+
+Отключить такие сообщения можно в /settings`,
+
+	// language=GoTemplate
+	models.TmplOwnerActivity: `🔔 {{.Event.Caption}}
+{{range .Activity}}
+{{activity .Action}}: {{range $i, $d := .Dancers}}{{if $i}} и {{end}}{{template "dancer" $d}}{{end}}{{end}}`,
+
+	// language=GoTemplate
+	models.TmplOwnerDigest: `📊 <b>Сводка за сутки</b>
+{{range .Digest}}
+<b>{{.Caption}}</b>
+{{range $action, $n := .Counts}}{{digest $action}}: {{$n}}
+{{end}}{{end}}`,
+
+	// language=GoTemplate
+	models.TmplReminderCouple: `🔔 {{.Event.Caption}}
+
+⏰ Напоминаю, что вы с {{template "dancer" .Partner}} записаны на это мероприятие. Начало: {{startsAt .Event}}`,
+
+	// language=GoTemplate
+	models.TmplReminderSingle: `🔔 {{.Event.Caption}}
+
+⏰ Напоминаю, что ты записан на это мероприятие и ищешь пару. Начало: {{startsAt .Event}}`,
+
+	// language=GoTemplate
+	models.TmplReminderParticipant: `🔔 {{.Event.Caption}}
+
+⏰ Напоминаю, что ты записан на это мероприятие. Начало: {{startsAt .Event}}`,
+}
+
+// OwnerActivity - registration changes in the real-time notifications for the event owner
+var OwnerActivity = map[models.HistoryAction]string{
+	models.HistoryCoupleAdded:        "👫 Новая пара",
+	models.HistoryCoupleRemoved:      "🗑 Пара отменила запись",
+	models.HistoryWaitlistAdded:      "⏳ Пара в листе ожидания",
+	models.HistoryWaitlistRemoved:    "🗑 Пара ушла из листа ожидания",
+	models.HistorySingleAdded:        "🙋 Ищет пару",
+	models.HistorySingleRemoved:      "👋 Больше не ищет пару",
+	models.HistoryParticipantAdded:   "🙋 Новый участник",
+	models.HistoryParticipantRemoved: "🗑 Участник отменил запись",
+}
+
+// OwnerDigest - registration changes in the daily digest for the event owner
+var OwnerDigest = map[models.HistoryAction]string{
+	models.HistoryCoupleAdded:        "👫 Новых пар",
+	models.HistoryCoupleRemoved:      "🗑 Отменено пар",
+	models.HistoryWaitlistAdded:      "⏳ Пар в листе ожидания",
+	models.HistoryWaitlistRemoved:    "🗑 Ушло из листа ожидания",
+	models.HistorySingleAdded:        "🙋 Новых ищущих пару",
+	models.HistorySingleRemoved:      "👋 Больше не ищут пару",
+	models.HistoryParticipantAdded:   "🙋 Новых участников",
+	models.HistoryParticipantRemoved: "🗑 Отменено участий",
 }
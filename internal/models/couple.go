@@ -4,8 +4,11 @@ import "time"
 
 // Couple - is a pair of dancers
 type Couple struct {
-	Dancers   []Dancer  `json:"dancers"`             // Dancers in the couple. Should be exactly 2. Leader is the first one.
-	CreatedBy Profile   `json:"created_by"`          // Who created couple
-	AutoPair  bool      `json:"auto_pair,omitempty"` // Couple was paired automatically
-	CreatedAt time.Time `json:"created_at"`          // Creation time
+	Dancers     []Dancer  `json:"dancers"`                // Dancers in the couple. Should be exactly 2. Leader is the first one.
+	CreatedBy   Profile   `json:"created_by"`             // Who created couple
+	AutoPair    bool      `json:"auto_pair,omitempty"`    // Couple was paired automatically
+	ByOrganizer bool      `json:"by_organizer,omitempty"` // Couple was paired by the organizer
+	Repeat      bool      `json:"repeat,omitempty"`       // Couple opted in to be signed up for the next recurring event
+	Replace     bool      `json:"replace,omitempty"`      // Pending couple replaces the partner in the couple of the requester
+	CreatedAt   time.Time `json:"created_at"`             // Creation time
 }
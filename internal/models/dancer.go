@@ -2,16 +2,32 @@ package models
 
 import (
 	"log/slog"
+	"strconv"
 	"time"
 )
 
 // Dancer - is a dancer participating in the event
 type Dancer struct {
-	*Profile            // Telegram profile of the dancer (if available)
-	FullName  string    `json:"full_name"`           // Name of the dancer
-	Role      Role      `json:"role"`                // Role of the dancer
-	AsSingle  bool      `json:"as_single,omitempty"` // If dancer was registered as single
-	CreatedAt time.Time `json:"created_at"`          // Creation time
+	*Profile                // Telegram profile of the dancer (if available)
+	FullName     string     `json:"full_name"`               // Name of the dancer
+	Role         Role       `json:"role"`                    // Role of the dancer
+	AsSingle     bool       `json:"as_single,omitempty"`     // If dancer was registered as single
+	Switch       bool       `json:"switch,omitempty"`        // If dancer was registered as single for either role
+	Level        Level      `json:"level,omitempty"`         // Dance level of the dancer on registration
+	PartnerLevel Level      `json:"partner_level,omitempty"` // Preferred dance level of the partner on registration
+	ClaimToken   string     `json:"claim_token,omitempty"`   // Token of the link to claim the registration by the partner registered by name
+	RefreshedAt  *time.Time `json:"refreshed_at,omitempty"`  // Time when the single confirmed they are still looking for a partner
+	WarnedAt     *time.Time `json:"warned_at,omitempty"`     // Time when the single was warned about the upcoming removal
+	CreatedAt    time.Time  `json:"created_at"`              // Creation time
+}
+
+// Key returns the key of the dancer record that stays the same while the record is stored:
+// the profile ID if the dancer has a profile, otherwise the creation time of the record.
+func (d Dancer) Key() string {
+	if d.Profile != nil {
+		return strconv.FormatInt(d.Profile.ID, 10)
+	}
+	return "t" + strconv.FormatInt(d.CreatedAt.UnixNano(), 36)
 }
 
 // LogValue implements the slog.Valuer interface for Dancer model.
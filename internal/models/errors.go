@@ -0,0 +1,14 @@
+package models
+
+import "errors"
+
+var (
+	ErrAccessDenied     = errors.New("access denied")         // Profile is not allowed to perform the action
+	ErrUsernameRequired = errors.New("username required")     // Name of the person must contain a Telegram @username
+	ErrNotOwner         = errors.New("not an owner")          // Only the event owner is allowed to perform the action
+	ErrInviteInvalid    = errors.New("invalid invite")        // Invite token is invalid or expired
+	ErrCaptionInvalid   = errors.New("invalid caption")       // Event caption is empty or too long
+	ErrTemplateName     = errors.New("invalid template name") // Template name is empty or too long
+	ErrTemplatesLimit   = errors.New("too many templates")    // User has reached the maximum number of templates
+	ErrEventNotEmpty    = errors.New("event not empty")       // Action is not allowed while the event has registrations
+)
@@ -7,14 +7,25 @@ import (
 
 // Event - is a dance event
 type Event struct {
-	ID        string        `json:"id"`         // Random string to identify the event
-	Caption   string        `json:"caption"`    // Event caption
-	Post      *Post         `json:"post"`       // Event post in a Telegram chat
-	Settings  EventSettings `json:"settings"`   // Event settings
-	Couples   []Couple      `json:"couples"`    // List of couples signed in
-	Singles   []Dancer      `json:"singles"`    // List of singles signed in
-	Owner     Profile       `json:"owner"`      // Telegram profile of the event owner
-	CreatedAt time.Time     `json:"created_at"` // Creation time
+	ID           string        `json:"id"`                       // Random string to identify the event
+	Caption      string        `json:"caption"`                  // Event caption
+	Post         *Post         `json:"post"`                     // Event post in a Telegram chat
+	Settings     EventSettings `json:"settings"`                 // Event settings
+	Couples      []Couple      `json:"couples"`                  // List of couples signed in
+	Waitlist     []Couple      `json:"waitlist,omitempty"`       // List of couples waiting for a free place if the limit is reached
+	Pending      []Couple      `json:"pending,omitempty"`        // List of couples waiting for the partner confirmation
+	Singles      []Dancer      `json:"singles"`                  // List of singles signed in
+	Participants []Dancer      `json:"participants,omitempty"`   // List of dancers signed in individually for the solo event
+	Forbidden    []Dancer      `json:"forbidden,omitempty"`      // List of dancers forbidden to sign in
+	Owner        Profile       `json:"owner"`                    // Telegram profile of the event owner
+	CoOrganizers []Profile     `json:"co_organizers,omitempty"`  // Telegram profiles of the users allowed to manage the event
+	InviteToken  string        `json:"invite_token,omitempty"`   // Token of the co-organizer invite link
+	StartsAt     *time.Time    `json:"starts_at,omitempty"`      // Start time of the event (if set)
+	Timezone     string        `json:"timezone,omitempty"`       // IANA time zone name of the event start time
+	AutoClosedAt *time.Time    `json:"auto_closed_at,omitempty"` // Time when the registration was closed automatically before the start
+	Recurrence   Recurrence    `json:"recurrence,omitempty"`     // Recurrence rule of the event
+	NextID       string        `json:"next_id,omitempty"`        // ID of the next event cloned from this one (if any)
+	CreatedAt    time.Time     `json:"created_at"`               // Creation time
 }
 
 // LogValue implements slog.Valuer interface for Event model.
@@ -27,9 +38,76 @@ func (e Event) LogValue() slog.Value {
 
 // EventSettings - is a settings for the event
 type EventSettings struct {
-	Limit       int       `json:"limit,omitempty"`        // Maximum number of couples allowed to sign-in. Zero means no limit
-	ClosedFor   ClosedFor `json:"closed_for,omitempty"`   // Is event closed for new signups or modifications
-	AutoPairing bool      `json:"auto_pairing,omitempty"` // Automatically pair single dancers
+	Limit        int       `json:"limit,omitempty"`         // Maximum number of couples allowed to sign-in. Zero means no limit
+	ClosedFor    ClosedFor `json:"closed_for,omitempty"`    // Is event closed for new signups or modifications
+	AutoPairing  bool      `json:"auto_pairing,omitempty"`  // Automatically pair single dancers
+	Pairing      Pairing   `json:"pairing,omitempty"`       // Strategy to choose a partner on auto pairing
+	MinLevel     Level     `json:"min_level,omitempty"`     // Minimum dance level of the dancers paired automatically
+	MaxLevel     Level     `json:"max_level,omitempty"`     // Maximum dance level of the dancers paired automatically
+	Solo         bool      `json:"solo,omitempty"`          // Dancers sign up individually without partners
+	Consent      bool      `json:"consent,omitempty"`       // Partner with a Telegram profile must confirm the couple registration
+	CoOrganizers []Profile `json:"co_organizers,omitempty"` // Default co-organizers of new events. Used only in user settings
+
+	SignupDeadline time.Duration `json:"signup_deadline,omitempty"` // Registration closes this long before the start. Zero means no deadline
+	CancelDeadline time.Duration `json:"cancel_deadline,omitempty"` // Dancers can not cancel the registration themselves this long before the start
+	SingleTTL      time.Duration `json:"single_ttl,omitempty"`      // Singles are removed this long after the registration unless refreshed. Zero means forever
+}
+
+// SignupDeadlineAt returns the time when the registration for the event closes.
+// Returns nil if the event has no start time or no registration deadline.
+func (e *Event) SignupDeadlineAt() *time.Time {
+	return e.deadlineAt(e.Settings.SignupDeadline)
+}
+
+// CancelDeadlineAt returns the time after which the dancers can not cancel the registration themselves.
+// Returns nil if the event has no start time or no cancellation deadline.
+func (e *Event) CancelDeadlineAt() *time.Time {
+	return e.deadlineAt(e.Settings.CancelDeadline)
+}
+
+func (e *Event) deadlineAt(before time.Duration) *time.Time {
+	if e.StartsAt == nil || before <= 0 {
+		return nil
+	}
+	t := e.StartsAt.Add(-before)
+	return &t
+}
+
+// LevelAllowed checks if the dancer with the given level can be paired automatically.
+// If the level range is set, the dancers without level are not allowed.
+func (s EventSettings) LevelAllowed(level Level) bool {
+	if s.MinLevel == LevelNone && s.MaxLevel == LevelNone {
+		return true
+	}
+	rank := level.Rank()
+	switch {
+	case rank == 0:
+		return false
+	case s.MinLevel != LevelNone && rank < s.MinLevel.Rank():
+		return false
+	case s.MaxLevel != LevelNone && rank > s.MaxLevel.Rank():
+		return false
+	default:
+		return true
+	}
+}
+
+// Pairing - is a strategy to choose a partner for the dancer on auto pairing.
+type Pairing string
+
+const (
+	PairingFIFO   Pairing = ""             // The single who signed up first
+	PairingRandom Pairing = "random"       // A random single
+	PairingRecent Pairing = "avoid_recent" // The first single who was not a recent partner of the dancer
+	PairingLevel  Pairing = "level"        // The single with the closest dance level
+)
+
+// Pairings is the list of all pairing strategies.
+var Pairings = []Pairing{
+	PairingFIFO,
+	PairingRandom,
+	PairingRecent,
+	PairingLevel,
 }
 
 type ClosedFor string
@@ -41,3 +119,42 @@ const (
 	ClosedForSingleLeaders   ClosedFor = "single_leaders"   // Closed for single leaders
 	ClosedForSingleFollowers ClosedFor = "single_followers" // Closed for single followers
 )
+
+// ClosedForModes is the list of all closure modes.
+var ClosedForModes = []ClosedFor{
+	ClosedForNone,
+	ClosedForAll,
+	ClosedForSingles,
+	ClosedForSingleLeaders,
+	ClosedForSingleFollowers,
+}
+
+// Recurrence - is a recurrence rule of the event.
+// The next event is cloned from the current one after its start.
+type Recurrence string
+
+const (
+	RecurrenceNone     Recurrence = ""         // Event is not repeated
+	RecurrenceWeekly   Recurrence = "weekly"   // Event is repeated every week
+	RecurrenceBiweekly Recurrence = "biweekly" // Event is repeated every two weeks
+)
+
+// Recurrences is the list of all recurrence rules.
+var Recurrences = []Recurrence{
+	RecurrenceNone,
+	RecurrenceWeekly,
+	RecurrenceBiweekly,
+}
+
+// Period returns the time between the event occurrences.
+// Returns zero if the event is not repeated.
+func (r Recurrence) Period() time.Duration {
+	switch r {
+	case RecurrenceWeekly:
+		return 7 * 24 * time.Hour
+	case RecurrenceBiweekly:
+		return 14 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
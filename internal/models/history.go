@@ -33,18 +33,62 @@ func (h HistoryItem) LogValue() slog.Value {
 	return slog.GroupValue(attrs...)
 }
 
+// CaptionChange - details of the event caption change
+type CaptionChange struct {
+	Old string `json:"old"` // Previous caption
+	New string `json:"new"` // New caption
+}
+
+// HistoryCount - number of the history items with the action for the event
+type HistoryCount struct {
+	EventID string        `db:"event_id"`
+	Action  HistoryAction `db:"action"`
+	Count   int           `db:"count"`
+}
+
 // HistoryAction - type of HistoryItem
 type HistoryAction string
 
 const (
-	HistoryEventCreated     HistoryAction = "event_created"
-	HistoryEventClosed      HistoryAction = "event_closed"
-	HistoryEventReopened    HistoryAction = "event_reopened"
-	HistoryCoupleAdded      HistoryAction = "couple_added"
-	HistoryCoupleRemoved    HistoryAction = "couple_removed"
-	HistorySingleAdded      HistoryAction = "single_added"
-	HistorySingleRemoved    HistoryAction = "single_removed"
-	HistoryNotificationSent HistoryAction = "notification_sent"
-	HistoryPostAdded        HistoryAction = "post_added"
-	HistoryPostChatAdded    HistoryAction = "post_chat_added"
+	HistoryEventCreated           HistoryAction = "event_created"
+	HistoryEventClosed            HistoryAction = "event_closed"
+	HistoryEventReopened          HistoryAction = "event_reopened"
+	HistoryCoupleAdded            HistoryAction = "couple_added"
+	HistoryCoupleRemoved          HistoryAction = "couple_removed"
+	HistoryWaitlistAdded          HistoryAction = "waitlist_added"
+	HistoryWaitlistRemoved        HistoryAction = "waitlist_removed"
+	HistoryWaitlistPromoted       HistoryAction = "waitlist_promoted"
+	HistoryPendingAdded           HistoryAction = "pending_added"
+	HistoryPendingRemoved         HistoryAction = "pending_removed"
+	HistoryConsentChanged         HistoryAction = "consent_changed"
+	HistoryDeadlinesChanged       HistoryAction = "deadlines_changed"
+	HistoryDancerClaimed          HistoryAction = "dancer_claimed"
+	HistoryPartnerReplaced        HistoryAction = "partner_replaced"
+	HistoryRoleSwitched           HistoryAction = "role_switched"
+	HistoryLimitChanged           HistoryAction = "limit_changed"
+	HistoryCaptionChanged         HistoryAction = "caption_changed"
+	HistoryStartsAtChanged        HistoryAction = "starts_at_changed"
+	HistoryRecurrenceChanged      HistoryAction = "recurrence_changed"
+	HistoryLevelsChanged          HistoryAction = "levels_changed"
+	HistoryEventCloned            HistoryAction = "event_cloned"
+	HistoryCoupleRepeatSet        HistoryAction = "couple_repeat_set"
+	HistorySingleAdded            HistoryAction = "single_added"
+	HistorySingleRemoved          HistoryAction = "single_removed"
+	HistorySingleRefreshed        HistoryAction = "single_refreshed"
+	HistorySingleTTLChanged       HistoryAction = "single_ttl_changed"
+	HistoryParticipantAdded       HistoryAction = "participant_added"
+	HistoryParticipantRemoved     HistoryAction = "participant_removed"
+	HistorySoloChanged            HistoryAction = "solo_changed"
+	HistoryDancerForbidden        HistoryAction = "dancer_forbidden"
+	HistoryDancerAllowed          HistoryAction = "dancer_allowed"
+	HistoryBlocklistAdded         HistoryAction = "blocklist_added"
+	HistoryBlocklistRemoved       HistoryAction = "blocklist_removed"
+	HistoryTemplateAdded          HistoryAction = "template_added"
+	HistoryTemplateRemoved        HistoryAction = "template_removed"
+	HistoryCoOrganizerAdded       HistoryAction = "co_organizer_added"
+	HistoryCoOrganizerRemoved     HistoryAction = "co_organizer_removed"
+	HistoryNotificationSent       HistoryAction = "notification_sent"
+	HistoryNotificationSuppressed HistoryAction = "notification_suppressed"
+	HistoryPostAdded              HistoryAction = "post_added"
+	HistoryPostChatAdded          HistoryAction = "post_chat_added"
 )
@@ -0,0 +1,26 @@
+package models
+
+import "slices"
+
+// Level is a dance level of the dancer
+type Level string
+
+const (
+	LevelNone         Level = "" // Level is not set
+	LevelBeginner     Level = "beginner"
+	LevelIntermediate Level = "intermediate"
+	LevelAdvanced     Level = "advanced"
+)
+
+// Levels is the list of all dance levels in ascending order.
+var Levels = []Level{LevelBeginner, LevelIntermediate, LevelAdvanced}
+
+// Rank returns the position of the level in [Levels] starting from 1.
+// Returns 0 if the level is not set or unknown.
+func (l Level) Rank() int {
+	return slices.Index(Levels, l) + 1
+}
+
+func (l Level) String() string {
+	return string(l)
+}
@@ -3,12 +3,19 @@ package models
 import "log/slog"
 
 type Notification struct {
-	TmplCode  NotificationTmpl    `json:"template"`        // Template of the notification
-	Recipient *Profile            `json:"recipient"`       // Receiver of the notification
-	Payload   NotificationPayload `json:"-"`               // Payload of the notification
-	Error     string              `json:"error,omitempty"` // Error message during notification sending (if any)
+	TmplCode   NotificationTmpl    `json:"template"`             // Template of the notification
+	Recipient  *Profile            `json:"recipient"`            // Receiver of the notification
+	Payload    NotificationPayload `json:"-"`                    // Payload of the notification
+	Error      string              `json:"error,omitempty"`      // Error message during notification sending (if any)
+	Suppressed string              `json:"suppressed,omitempty"` // Reason the notification was not sent due to the recipient preferences (if any)
 }
 
+// Reasons of the suppressed notifications
+const (
+	SuppressedCategory = "category_muted" // The recipient muted the notification category
+	SuppressedEvent    = "event_muted"    // The recipient muted the event
+)
+
 // LogValue implements slog.Valuer interface for Notification model.
 func (n Notification) LogValue() slog.Value {
 	attrs := []slog.Attr{
@@ -25,9 +32,23 @@ func (n Notification) LogValue() slog.Value {
 
 // NotificationPayload contains the context of the notification.
 type NotificationPayload struct {
-	Event      *Event  // Event related to the notification (if any)
-	Partner    *Dancer // Current partner of the recipient (if any)
-	NewPartner *Dancer // New partner of the recipient (if any)
+	Event      *Event         // Event related to the notification (if any)
+	Partner    *Dancer        // Current partner of the recipient (if any)
+	NewPartner *Dancer        // New partner of the recipient (if any)
+	Activity   []ActivityItem // Registration changes in the event of the recipient (if any)
+	Digest     []DigestItem   // Registration changes in the events of the recipient for the digest period (if any)
+}
+
+// ActivityItem - is a registration change in the event
+type ActivityItem struct {
+	Action  HistoryAction // Action of the change
+	Dancers []Dancer      // Dancers affected by the change
+}
+
+// DigestItem - is a summary of the registration changes in the event
+type DigestItem struct {
+	Caption string                // Caption of the event
+	Counts  map[HistoryAction]int // Number of the changes by action
 }
 
 type NotificationTmpl string
@@ -36,6 +57,64 @@ func (t NotificationTmpl) String() string {
 	return string(t)
 }
 
+// Category returns the category of the notification the recipient can mute.
+// Returns [CategoryNone] for the notifications that can not be muted by category.
+func (t NotificationTmpl) Category() NotificationCategory {
+	switch t {
+	case TmplRegisteredWithSingle,
+		TmplCanceledWithSingle,
+		TmplCanceledByPartner,
+		TmplPartnerRemoved,
+		TmplPartnerRemovedWithSingle,
+		TmplPartnerReplaced,
+		TmplRoleSwitched:
+		return CategoryPartner
+	case TmplAutoPairPartnerFound,
+		TmplAutoPairPartnerChanged,
+		TmplPartnerRemovedAutoPair:
+		return CategoryAutoPair
+	case TmplSingleJoined,
+		TmplSingleExpiring:
+		return CategorySingles
+	case TmplOwnerActivity,
+		TmplNextEventCreated:
+		return CategoryOrganizer
+	case TmplReminderCouple,
+		TmplReminderSingle,
+		TmplReminderParticipant:
+		return CategoryReminder
+	default:
+		return CategoryNone
+	}
+}
+
+// EventMutable returns true if the notification is not sent when the recipient muted its event.
+// The consent requests are always sent, as the couple can not be registered without the answer.
+func (t NotificationTmpl) EventMutable() bool {
+	return t != TmplConsentRequest
+}
+
+// NotificationCategory - is a category of the notifications the recipient can mute
+type NotificationCategory string
+
+const (
+	CategoryNone      NotificationCategory = ""          // Notifications that can not be muted by category
+	CategoryPartner   NotificationCategory = "partner"   // Partner changes
+	CategoryAutoPair  NotificationCategory = "auto_pair" // Auto pairing results
+	CategorySingles   NotificationCategory = "singles"   // New compatible singles and stale single warnings
+	CategoryOrganizer NotificationCategory = "organizer" // Registration activity and next recurring events of the organizer
+	CategoryReminder  NotificationCategory = "reminder"  // Reminders before the event start
+)
+
+// NotificationCategories - is a list of the notification categories the recipient can mute
+var NotificationCategories = []NotificationCategory{
+	CategoryPartner,
+	CategoryAutoPair,
+	CategorySingles,
+	CategoryOrganizer,
+	CategoryReminder,
+}
+
 const (
 	// TmplRegisteredWithSingle - someone registered in couple with a single recipient
 	TmplRegisteredWithSingle NotificationTmpl = "registered_with_single"
@@ -54,4 +133,94 @@ const (
 	// TmplAutoPairPartnerChanged - partner has been canceled the registration
 	// and new partner has been chosen.
 	TmplAutoPairPartnerChanged NotificationTmpl = "auto_pair_partner_changed"
+
+	// TmplWaitlistPromoted - a place became available
+	// and the recipient couple was moved from the waitlist to the couples list.
+	TmplWaitlistPromoted NotificationTmpl = "waitlist_promoted"
+
+	// TmplDancerForbidden - the organizer forbade the recipient to sign in
+	// and removed the recipient registration.
+	TmplDancerForbidden NotificationTmpl = "dancer_forbidden"
+
+	// TmplPartnerRemoved - the organizer removed the partner of the recipient.
+	// The registration of the recipient is removed as well.
+	TmplPartnerRemoved NotificationTmpl = "partner_removed"
+
+	// TmplPartnerRemovedWithSingle - the organizer removed the partner
+	// who previously registered in couple with recipient from the singles list.
+	// The recipient will be returned back to the singles list.
+	TmplPartnerRemovedWithSingle NotificationTmpl = "partner_removed_with_single"
+
+	// TmplPartnerRemovedAutoPair - the organizer removed the partner of the recipient
+	// and new partner has been chosen.
+	TmplPartnerRemovedAutoPair NotificationTmpl = "partner_removed_auto_pair"
+
+	// TmplCoupleAddedByOrganizer - the organizer registered the recipient in couple with the partner.
+	TmplCoupleAddedByOrganizer NotificationTmpl = "couple_added_by_organizer"
+
+	// TmplSingleAddedByOrganizer - the organizer registered the recipient as a single.
+	TmplSingleAddedByOrganizer NotificationTmpl = "single_added_by_organizer"
+
+	// TmplParticipantAddedByOrganizer - the organizer registered the recipient as a participant of the solo event.
+	TmplParticipantAddedByOrganizer NotificationTmpl = "participant_added_by_organizer"
+
+	// TmplRemovedByOrganizer - the organizer removed the recipient registration.
+	TmplRemovedByOrganizer NotificationTmpl = "removed_by_organizer"
+
+	// TmplPairedByOrganizer - the organizer paired the recipient with the partner from the singles list.
+	TmplPairedByOrganizer NotificationTmpl = "paired_by_organizer"
+
+	// TmplCoOrganizerAdded - someone accepted the invite
+	// and became a co-organizer of the recipient event.
+	TmplCoOrganizerAdded NotificationTmpl = "co_organizer_added"
+
+	// TmplNextEventCreated - the next draft of the recipient recurring event is created
+	// and ready to be published.
+	TmplNextEventCreated NotificationTmpl = "next_event_created"
+
+	// TmplConsentRequest - the dancer asks the recipient to confirm the couple registration.
+	TmplConsentRequest NotificationTmpl = "consent_request"
+
+	// TmplConsentAccepted - the partner confirmed the couple registration of the recipient.
+	TmplConsentAccepted NotificationTmpl = "consent_accepted"
+
+	// TmplConsentDeclined - the partner declined the couple registration of the recipient.
+	TmplConsentDeclined NotificationTmpl = "consent_declined"
+
+	// TmplConsentFailed - the partner confirmed the couple registration of the recipient,
+	// but the couple could not be registered.
+	TmplConsentFailed NotificationTmpl = "consent_failed"
+
+	// TmplConsentExpired - the partner did not confirm the couple registration of the recipient in time.
+	TmplConsentExpired NotificationTmpl = "consent_expired"
+
+	// TmplPartnerReplaced - the partner of the recipient registered with another dancer instead.
+	TmplPartnerReplaced NotificationTmpl = "partner_replaced"
+
+	// TmplRoleSwitched - the partner of the recipient switched the roles in their couple.
+	TmplRoleSwitched NotificationTmpl = "role_switched"
+
+	// TmplSingleExpiring - the single registration of the recipient will be removed soon unless refreshed.
+	TmplSingleExpiring NotificationTmpl = "single_expiring"
+
+	// TmplSingleExpired - the single registration of the recipient was removed as stale.
+	TmplSingleExpired NotificationTmpl = "single_expired"
+
+	// TmplSingleJoined - a single compatible with the recipient single registered for the event.
+	TmplSingleJoined NotificationTmpl = "single_joined"
+
+	// TmplOwnerActivity - the registrations in the event of the recipient changed.
+	TmplOwnerActivity NotificationTmpl = "owner_activity"
+
+	// TmplOwnerDigest - the daily summary of the registration changes in the events of the recipient.
+	TmplOwnerDigest NotificationTmpl = "owner_digest"
+
+	// TmplReminderCouple - the event where the recipient is registered in couple starts soon.
+	TmplReminderCouple NotificationTmpl = "reminder_couple"
+
+	// TmplReminderSingle - the event where the recipient is registered as a single starts soon.
+	TmplReminderSingle NotificationTmpl = "reminder_single"
+
+	// TmplReminderParticipant - the solo event where the recipient is registered starts soon.
+	TmplReminderParticipant NotificationTmpl = "reminder_participant"
 )
@@ -58,6 +58,9 @@ const (
 	StatusAsSingle                                // Registered as a single
 	StatusInCouple                                // Registered in a couple with a partner
 	StatusForbidden                               // Forbidden to register for the event
+	StatusInWaitlist                              // Registered in a couple with a partner in the waitlist
+	StatusParticipant                             // Registered individually for the solo event
+	StatusPending                                 // Waiting for the partner to confirm the couple registration
 )
 
 // CanRegister returns true if the dancer can register for the event.
@@ -66,9 +69,15 @@ func (s RegistrationStatus) CanRegister() bool {
 	return s == StatusNotRegistered || s == StatusAsSingle
 }
 
-// IsRegistered returns true if the dancer is registered for the event as single or in a couple.
+// IsRegistered returns true if the dancer is registered for the event as single, in a couple,
+// as a participant of the solo event or waits for the partner confirmation.
 func (s RegistrationStatus) IsRegistered() bool {
-	return s == StatusAsSingle || s == StatusInCouple
+	return s == StatusAsSingle || s == StatusParticipant || s == StatusPending || s.HasPartner()
+}
+
+// HasPartner returns true if the dancer is registered in a couple or in the waitlist.
+func (s RegistrationStatus) HasPartner() bool {
+	return s == StatusInCouple || s == StatusInWaitlist
 }
 
 func (s RegistrationStatus) String() string {
@@ -81,6 +90,12 @@ func (s RegistrationStatus) String() string {
 		return "in_couple"
 	case StatusForbidden:
 		return "forbidden"
+	case StatusInWaitlist:
+		return "in_waitlist"
+	case StatusParticipant:
+		return "participant"
+	case StatusPending:
+		return "pending"
 	default:
 		return fmt.Sprintf("unknown_status_%d", s)
 	}
@@ -90,28 +105,46 @@ func (s RegistrationStatus) String() string {
 type RegistrationResult int
 
 const (
-	ResultNoResult            RegistrationResult = iota // No result
-	ResultRegisteredAsSingle                            // Successful registration as single
-	ResultRegisteredInCouple                            // Successful registration in a couple
-	ResultRegistrationRemoved                           // Successful removal of registration
-	ResultAlreadyAsSingle                               // The dancer is already registered as single
-	ResultAlreadyInCouple                               // The dancer is already registered in another couple
-	ResultAlreadyInSameCouple                           // The dancer is already registered in same couple
-	ResultPartnerTaken                                  // Partner is already registered in another couple
-	ResultPartnerSameRole                               // Partner has the same role as dancer
-	ResultSelfNotAllowed                                // Not allowed to register in couple with yourself
-	ResultWasNotRegistered                              // The dancer was not registered for the event
-	ResultEventClosed                                   // The event is closed for new registrations
-	ResultDancerForbidden                               // The event is forbidden for the dancer
-	ResultPartnerForbidden                              // The event is forbidden for given partner
-	ResultClosedForSingles                              // The event is closed for singles
-	ResultClosedForSingleRole                           // The event is closed for singles  with given role
+	ResultNoResult                RegistrationResult = iota // No result
+	ResultRegisteredAsSingle                                // Successful registration as single
+	ResultRegisteredInCouple                                // Successful registration in a couple
+	ResultRegistrationRemoved                               // Successful removal of registration
+	ResultAlreadyAsSingle                                   // The dancer is already registered as single
+	ResultAlreadyInCouple                                   // The dancer is already registered in another couple
+	ResultAlreadyInSameCouple                               // The dancer is already registered in same couple
+	ResultPartnerTaken                                      // Partner is already registered in another couple
+	ResultPartnerSameRole                                   // Partner has the same role as dancer
+	ResultSelfNotAllowed                                    // Not allowed to register in couple with yourself
+	ResultWasNotRegistered                                  // The dancer was not registered for the event
+	ResultEventClosed                                       // The event is closed for new registrations
+	ResultDancerForbidden                                   // The event is forbidden for the dancer
+	ResultPartnerForbidden                                  // The event is forbidden for given partner
+	ResultClosedForSingles                                  // The event is closed for singles
+	ResultClosedForSingleRole                               // The event is closed for singles  with given role
+	ResultRegisteredInWaitlist                              // Successful registration in a couple in the waitlist
+	ResultRegisteredAsParticipant                           // Successful registration as a participant of the solo event
+	ResultAlreadyParticipant                                // The dancer is already registered as a participant
+	ResultPendingConsent                                    // The couple is waiting for the partner confirmation
+	ResultAlreadyPending                                    // The dancer already waits for a partner confirmation
+	ResultPendingNotFound                                   // The couple waiting for confirmation is not found: expired, canceled or declined
+	ResultClaimed                                           // The partner registered by name claimed the registration
+	ResultClaimNotFound                                     // The registration to claim is not found: already claimed or removed
+	ResultPartnerReplaced                                   // The partner in the couple was replaced with another dancer
+	ResultRoleSwitched                                      // The dancers in the couple switched their roles
+	ResultDeadlinePassed                                    // The registration deadline of the event has passed
+	ResultCancelClosed                                      // The dancer can not cancel the registration after the cancellation deadline
 )
 
 // IsSuccess returns true if the registration was successful.
 func (r RegistrationResult) IsSuccess() bool {
 	return r == ResultRegisteredAsSingle ||
 		r == ResultRegisteredInCouple ||
+		r == ResultRegisteredInWaitlist ||
+		r == ResultRegisteredAsParticipant ||
+		r == ResultPendingConsent ||
+		r == ResultClaimed ||
+		r == ResultPartnerReplaced ||
+		r == ResultRoleSwitched ||
 		r == ResultRegistrationRemoved
 }
 
@@ -160,6 +193,30 @@ func (r RegistrationResult) String() string {
 		return "closed_for_singles"
 	case ResultClosedForSingleRole:
 		return "closed_for_single_role"
+	case ResultRegisteredInWaitlist:
+		return "registered_in_waitlist"
+	case ResultRegisteredAsParticipant:
+		return "registered_as_participant"
+	case ResultAlreadyParticipant:
+		return "already_participant"
+	case ResultPendingConsent:
+		return "pending_consent"
+	case ResultAlreadyPending:
+		return "already_pending"
+	case ResultPendingNotFound:
+		return "pending_not_found"
+	case ResultClaimed:
+		return "claimed"
+	case ResultClaimNotFound:
+		return "claim_not_found"
+	case ResultPartnerReplaced:
+		return "partner_replaced"
+	case ResultRoleSwitched:
+		return "role_switched"
+	case ResultDeadlinePassed:
+		return "deadline_passed"
+	case ResultCancelClosed:
+		return "cancel_closed"
 	default:
 		return fmt.Sprintf("unknown_result_%d", r)
 	}
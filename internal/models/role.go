@@ -6,13 +6,40 @@ type Role string
 const (
 	RoleLeader   Role = "leader"
 	RoleFollower Role = "follower"
+	// RoleSwitch is a role of the single dancer who can dance either role.
+	// It is resolved to a concrete role when the couple is formed.
+	RoleSwitch Role = "switch"
 )
 
+// Opposite returns the role of the partner.
+// The partner of the switch dancer can have any role, so the switch role is returned.
 func (r Role) Opposite() Role {
-	if r == RoleLeader {
+	switch r {
+	case RoleLeader:
 		return RoleFollower
+	case RoleSwitch:
+		return RoleSwitch
+	default:
+		return RoleLeader
+	}
+}
+
+// Pairs checks if the dancers with the roles can dance in a couple.
+func (r Role) Pairs(other Role) bool {
+	return r != other || r == RoleSwitch
+}
+
+// Resolve returns the concrete role of the dancer paired with the partner of the given role.
+// The switch dancer takes the role opposite to the partner or leads if the partner is a switch dancer as well.
+func (r Role) Resolve(partner Role) Role {
+	switch {
+	case r != RoleSwitch:
+		return r
+	case partner == RoleSwitch:
+		return RoleLeader
+	default:
+		return partner.Opposite()
 	}
-	return RoleLeader
 }
 
 func (r Role) String() string {
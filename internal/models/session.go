@@ -6,14 +6,27 @@ type Session struct {
 	EventID string          `json:"event_id,omitempty"`      // Current event id related to the session (if any)
 	Role    Role            `json:"event_role,omitempty"`    // Current role related to the session (if any)
 	Singles []SessionSingle `json:"event_singles,omitempty"` // Singles - list of singles available for signup with the current user role
+	Dancer  *Dancer         `json:"event_dancer,omitempty"`  // Dancer chosen by the organizer to be paired (if any)
 }
 
 // SessionAction - is a user action related to the session
 type SessionAction string
 
 const (
-	SessionNoAction SessionAction = ""
-	SessionSignup   SessionAction = "signup"
+	SessionNoAction  SessionAction = ""
+	SessionSignup    SessionAction = "signup"
+	SessionManage    SessionAction = "manage"
+	SessionLimit     SessionAction = "limit"
+	SessionForbid    SessionAction = "forbid"
+	SessionBlocklist SessionAction = "blocklist"
+	SessionInvite    SessionAction = "invite"
+	SessionCaption   SessionAction = "caption"
+	SessionStartsAt  SessionAction = "starts_at"
+	SessionTemplate  SessionAction = "template"
+	SessionDancerAdd SessionAction = "dancer_add"
+	SessionClaim     SessionAction = "claim"
+	SessionReplace   SessionAction = "replace"
+	SessionPair      SessionAction = "pair"
 )
 
 func (a SessionAction) String() string {
@@ -15,5 +15,63 @@ type User struct {
 
 // UserSettings - is a user settings
 type UserSettings struct {
-	Event EventSettings `json:"event"` // Default settings for new events created by user
+	Event         EventSettings        `json:"event"`                  // Default settings for new events created by user
+	Dancer        DancerSettings       `json:"dancer"`                 // Dance profile of the user
+	Blocklist     []Dancer             `json:"blocklist,omitempty"`    // Dancers forbidden to sign in for all events of the user
+	Templates     []EventTemplate      `json:"templates,omitempty"`    // Named templates for new events created by user
+	OwnerAlerts   OwnerAlerts          `json:"owner_alerts,omitempty"` // Notifications about the registration activity in the user events
+	Notifications NotificationSettings `json:"notifications"`          // Notification preferences of the user
+}
+
+// NotificationSettings - is a notification preferences of the user
+type NotificationSettings struct {
+	MutedCategories []NotificationCategory `json:"muted_categories,omitempty"` // Categories of the notifications the user does not receive
+	MutedEvents     []MutedEvent           `json:"muted_events,omitempty"`     // Events the user does not receive the notifications about
+}
+
+// MutedEvent - is an event muted by the user
+type MutedEvent struct {
+	EventID string `json:"event_id"` // ID of the event
+	Title   string `json:"title"`    // Short title of the event for the settings scene
+}
+
+// OwnerAlerts - is a way to notify the event owner about the registration activity
+type OwnerAlerts string
+
+const (
+	OwnerAlertsOff      OwnerAlerts = ""         // No notifications
+	OwnerAlertsRealtime OwnerAlerts = "realtime" // Notification on every registration change
+	OwnerAlertsDigest   OwnerAlerts = "digest"   // Daily digest of the registration changes
+)
+
+// OwnerAlertsAll - is a list of all owner notification modes
+var OwnerAlertsAll = []OwnerAlerts{OwnerAlertsOff, OwnerAlertsRealtime, OwnerAlertsDigest}
+
+// DancerSettings - is a dance profile of the user.
+// The levels are copied to the [Dancer] on every registration.
+type DancerSettings struct {
+	Level        Level `json:"level,omitempty"`         // Dance level of the user
+	PartnerLevel Level `json:"partner_level,omitempty"` // Preferred dance level of the partner
+	SingleAlerts bool  `json:"single_alerts,omitempty"` // Notify the user waiting as single when a compatible single joins. Checked on sending
+}
+
+// EventTemplate - is a named template for new events
+type EventTemplate struct {
+	ID       string        `json:"id"`                // Random string to identify the template
+	Name     string        `json:"name"`              // Name of the template
+	Caption  string        `json:"caption,omitempty"` // Caption boilerplate added to the announcement text
+	Settings EventSettings `json:"settings"`          // Settings for new events
+}
+
+// EventCaption returns the caption of the new event created with the template:
+// the announcement text followed by the caption boilerplate.
+func (t EventTemplate) EventCaption(text string) string {
+	switch {
+	case text == "":
+		return t.Caption
+	case t.Caption == "":
+		return text
+	default:
+		return text + "\n\n" + t.Caption
+	}
 }
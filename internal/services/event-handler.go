@@ -1,7 +1,10 @@
 package services
 
 import (
+	"math/rand/v2"
+	"slices"
 	"sort"
+	"time"
 
 	"github.com/ofstudio/dancegobot/internal/config"
 	"github.com/ofstudio/dancegobot/internal/models"
@@ -9,17 +12,48 @@ import (
 
 // EventHandler implements the event logic and rules.
 type EventHandler struct {
-	event *models.Event
-	hist  []*models.HistoryItem
-	notif []*models.Notification
+	event     *models.Event
+	blocklist []models.Dancer
+	alerts    models.OwnerAlerts
+	recent    map[int64][]int64
+	rnd       *rand.Rand
+	hist      []*models.HistoryItem
+	notif     []*models.Notification
 }
 
 func NewEventHandler(event *models.Event) *EventHandler {
 	return &EventHandler{
 		event: event,
+		rnd:   rand.New(rand.NewPCG(uint64(nowFn().UnixNano()), 0)),
 	}
 }
 
+// WithSeed sets the seed of the random pairing strategy.
+func (h *EventHandler) WithSeed(seed uint64) *EventHandler {
+	h.rnd = rand.New(rand.NewPCG(seed, 0))
+	return h
+}
+
+// WithRecentPartners sets the recent partners of the dancers
+// avoided by the [models.PairingRecent] strategy.
+func (h *EventHandler) WithRecentPartners(recent map[int64][]int64) *EventHandler {
+	h.recent = recent
+	return h
+}
+
+// WithBlocklist sets the blocklist of the event owner.
+// Dancers from the blocklist are forbidden to sign in for the event.
+func (h *EventHandler) WithBlocklist(blocklist []models.Dancer) *EventHandler {
+	h.blocklist = blocklist
+	return h
+}
+
+// WithOwnerAlerts sets the way the event owner is notified about the registration activity.
+func (h *EventHandler) WithOwnerAlerts(alerts models.OwnerAlerts) *EventHandler {
+	h.alerts = alerts
+	return h
+}
+
 // Event returns the event being handled.
 func (h *EventHandler) Event() *models.Event {
 	return h.event
@@ -37,29 +71,259 @@ func (h *EventHandler) Notifications() []*models.Notification {
 	return h.notif
 }
 
+// OwnerActivity returns the notification for the event owner subscribed to the real-time notifications
+// about the registration changes collected during the event handling.
+// The changes made by the owner are skipped. Returns nil if there is nothing to notify about.
+func (h *EventHandler) OwnerActivity() *models.Notification {
+	if h.alerts != models.OwnerAlertsRealtime {
+		return nil
+	}
+	var activity []models.ActivityItem
+	for _, item := range h.hist {
+		if !slices.Contains(activityActions, item.Action) || h.IsOwner(item.Initiator) {
+			continue
+		}
+		switch details := item.Details.(type) {
+		case *models.Couple:
+			activity = append(activity, models.ActivityItem{Action: item.Action, Dancers: details.Dancers})
+		case *models.Dancer:
+			activity = append(activity, models.ActivityItem{Action: item.Action, Dancers: []models.Dancer{*details}})
+		}
+	}
+	if len(activity) == 0 {
+		return nil
+	}
+	return &models.Notification{
+		TmplCode:  models.TmplOwnerActivity,
+		Recipient: &h.event.Owner,
+		Payload: models.NotificationPayload{
+			Event:    h.event,
+			Activity: activity,
+		},
+	}
+}
+
+// activityActions - history actions the event owner is notified about.
+var activityActions = []models.HistoryAction{
+	models.HistoryCoupleAdded,
+	models.HistoryCoupleRemoved,
+	models.HistoryWaitlistAdded,
+	models.HistoryWaitlistRemoved,
+	models.HistorySingleAdded,
+	models.HistorySingleRemoved,
+	models.HistoryParticipantAdded,
+	models.HistoryParticipantRemoved,
+}
+
+// CanManage returns true if the given profile is allowed to manage the event:
+// the profile is the owner or a co-organizer of the event.
+func (h *EventHandler) CanManage(profile *models.Profile) bool {
+	if h.IsOwner(profile) {
+		return true
+	}
+	for _, p := range h.event.CoOrganizers {
+		if profile != nil && profile.ID == p.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOwner returns true if the given profile is the owner of the event.
+func (h *EventHandler) IsOwner(profile *models.Profile) bool {
+	return profile != nil && profile.ID == h.event.Owner.ID
+}
+
+// CoOrganizerAdd adds the profile to the co-organizers of the event
+// and notifies the event owner.
+// Returns false if the profile is already allowed to manage the event.
+func (h *EventHandler) CoOrganizerAdd(profile *models.Profile) bool {
+	if h.CanManage(profile) {
+		return false
+	}
+	h.event.CoOrganizers = append(h.event.CoOrganizers, *profile)
+	h.hist = append(h.hist, &models.HistoryItem{
+		Action:    models.HistoryCoOrganizerAdded,
+		Initiator: profile,
+		EventID:   &h.event.ID,
+		Details:   profile,
+		CreatedAt: nowFn(),
+	})
+	h.notif = append(h.notif, &models.Notification{
+		TmplCode:  models.TmplCoOrganizerAdded,
+		Recipient: &h.event.Owner,
+		Payload: models.NotificationPayload{
+			Event:   h.event,
+			Partner: &models.Dancer{Profile: profile, FullName: profile.FullName()},
+		},
+	})
+	return true
+}
+
+// CoOrganizerRemove removes the co-organizer with the given profile ID from the event.
+// Returns false if there is no such co-organizer.
+func (h *EventHandler) CoOrganizerRemove(profileID int64, initiator *models.Profile) bool {
+	i := -1
+	for j, p := range h.event.CoOrganizers {
+		if p.ID == profileID {
+			i = j
+			break
+		}
+	}
+	if i < 0 {
+		return false
+	}
+	removed := h.event.CoOrganizers[i]
+	h.event.CoOrganizers = append(h.event.CoOrganizers[:i], h.event.CoOrganizers[i+1:]...)
+	h.hist = append(h.hist, &models.HistoryItem{
+		Action:    models.HistoryCoOrganizerRemoved,
+		Initiator: initiator,
+		EventID:   &h.event.ID,
+		Details:   &removed,
+		CreatedAt: nowFn(),
+	})
+	return true
+}
+
+// ClosedForSet changes the closure mode of the event.
+// Returns false if the event already has the given closure mode.
+func (h *EventHandler) ClosedForSet(closedFor models.ClosedFor, initiator *models.Profile) bool {
+	if h.event.Settings.ClosedFor == closedFor {
+		return false
+	}
+	h.event.Settings.ClosedFor = closedFor
+
+	action := models.HistoryEventClosed
+	if closedFor == models.ClosedForNone {
+		action = models.HistoryEventReopened
+	}
+	h.hist = append(h.hist, &models.HistoryItem{
+		Action:    action,
+		Initiator: initiator,
+		EventID:   &h.event.ID,
+		Details:   h.event.Settings,
+		CreatedAt: nowFn(),
+	})
+	return true
+}
+
 // RegistrationGet returns registration for given dancer at the event.
 // If the dancer is not registered, returns a new registration.
 func (h *EventHandler) RegistrationGet(dancer *models.Dancer) *models.Registration {
-	if existingReg := h.findInCouples(dancer); existingReg != nil {
+	if existingReg := h.findInCouples(dancer, h.event.Couples, models.StatusInCouple); existingReg != nil {
+		return existingReg
+	}
+	if existingReg := h.findInCouples(dancer, h.event.Waitlist, models.StatusInWaitlist); existingReg != nil {
 		return existingReg
 	}
 	if existingReg := h.findInSingles(dancer); existingReg != nil {
 		return existingReg
 	}
+	if existingReg := h.findInParticipants(dancer); existingReg != nil {
+		return existingReg
+	}
+	if i := h.findPending(dancer); i >= 0 {
+		requester, invited := pendingDancers(&h.event.Pending[i])
+		return &models.Registration{
+			Dancer:  requester,
+			Status:  models.StatusPending,
+			Event:   h.event,
+			Partner: invited,
+		}
+	}
 	dancer.CreatedAt = nowFn()
+	status := models.StatusNotRegistered
+	if h.isForbidden(dancer) {
+		status = models.StatusForbidden
+	}
 	return &models.Registration{
 		Dancer: dancer,
-		Status: models.StatusNotRegistered,
+		Status: status,
 		Event:  h.event,
 	}
 }
 
 // CoupleAdd registers a couple for the event.
 // If the partner initially was registered as a single, the partner will be notified.
+// If the event requires the partner consent and the partner has a Telegram profile,
+// the couple waits for the partner confirmation.
 func (h *EventHandler) CoupleAdd(d, p *models.Dancer) *models.Registration {
+	return h.coupleRegister(d, p, nil, nil)
+}
+
+// CoupleAddByOrganizer registers a couple for the event on behalf of the organizer.
+// The organizer can register the couple even if the event is closed for new registrations.
+// Both dancers will be notified.
+func (h *EventHandler) CoupleAddByOrganizer(d, p *models.Dancer, organizer *models.Profile) *models.Registration {
+	reg := h.coupleRegister(d, p, organizer, nil)
+	if !reg.Result.IsSuccess() {
+		return reg
+	}
+	for _, r := range []*models.Registration{reg, reg.Related} {
+		if r.Profile == nil {
+			continue
+		}
+		h.notif = append(h.notif, &models.Notification{
+			TmplCode:  models.TmplCoupleAddedByOrganizer,
+			Recipient: r.Profile,
+			Payload: models.NotificationPayload{
+				Event:   h.event,
+				Partner: r.Partner,
+			},
+		})
+	}
+	return reg
+}
+
+// SinglesPair registers the couple of two dancers from the singles list on behalf of the organizer.
+// Both dancers will be notified.
+func (h *EventHandler) SinglesPair(l, f *models.Dancer, organizer *models.Profile) *models.Registration {
+	reg := h.RegistrationGet(l)
+	reg.Related = h.RegistrationGet(f)
+
+	resolveRole(reg, models.RoleLeader)
+	resolveRole(reg.Related, models.RoleFollower)
+
+	// Check if both dancers are in the singles list and have the opposite roles
+	switch {
+	case reg.Status != models.StatusAsSingle || reg.Related.Status != models.StatusAsSingle:
+		reg.Result = models.ResultWasNotRegistered
+		return reg
+	case isSame(reg.Dancer, reg.Related.Dancer):
+		reg.Result = models.ResultSelfNotAllowed
+		return reg
+	case !reg.Role.Pairs(reg.Related.Role):
+		reg.Result = models.ResultPartnerSameRole
+		return reg
+	}
+
+	reg = h.coupleAdd(reg, false, organizer, nil)
+	for _, r := range []*models.Registration{reg, reg.Related} {
+		if r.Profile == nil {
+			continue
+		}
+		h.notif = append(h.notif, &models.Notification{
+			TmplCode:  models.TmplPairedByOrganizer,
+			Recipient: r.Profile,
+			Payload: models.NotificationPayload{
+				Event:   h.event,
+				Partner: r.Partner,
+			},
+		})
+	}
+	return reg
+}
+
+// coupleRegister checks the couple registration rules and registers the couple.
+// If the organizer is not nil, the couple is registered on behalf of the organizer.
+// If the requester is not nil, the partner has already confirmed the couple registration
+// requested by the requester.
+func (h *EventHandler) coupleRegister(d, p *models.Dancer, organizer, requester *models.Profile) *models.Registration {
 	result := models.ResultNoResult
 	reg := h.RegistrationGet(d)
 	reg.Related = h.RegistrationGet(p)
+	resolveRole(reg, d.Role)
+	resolveRole(reg.Related, p.Role)
 
 	// Check if event is not forbidden for the dancer or partner
 	if reg.Status == models.StatusForbidden {
@@ -70,31 +334,46 @@ func (h *EventHandler) CoupleAdd(d, p *models.Dancer) *models.Registration {
 	}
 
 	// Check if the partner is already registered in a couple
-	if reg.Related.Status == models.StatusInCouple {
+	if reg.Related.Status.HasPartner() {
 		result = models.ResultPartnerTaken
 	}
 
 	// Check if the dancer is already registered in a couple
-	if reg.Status == models.StatusInCouple {
-		if h.isSame(reg.Partner, reg.Related.Dancer) {
+	if reg.Status.HasPartner() {
+		if isSame(reg.Partner, reg.Related.Dancer) {
 			result = models.ResultAlreadyInSameCouple
 		} else {
 			result = models.ResultAlreadyInCouple
 		}
 	}
 
+	// Check if the dancer is not waiting for another partner confirmation
+	if h.findPending(reg.Dancer) >= 0 {
+		result = models.ResultAlreadyPending
+	}
+
 	// Check if event is not closed for new registrations
-	if h.event.Settings.ClosedFor == models.ClosedForAll {
+	if h.event.Settings.ClosedFor == models.ClosedForAll && organizer == nil {
+		result = models.ResultEventClosed
+	}
+
+	// Check if the registration deadline has not passed
+	if h.signupClosed() && organizer == nil {
+		result = models.ResultDeadlinePassed
+	}
+
+	// Check if the event accepts couples
+	if h.event.Settings.Solo {
 		result = models.ResultEventClosed
 	}
 
 	// Check if the partner has the same role as the partner
-	if reg.Role == reg.Related.Role {
+	if !reg.Role.Pairs(reg.Related.Role) {
 		result = models.ResultPartnerSameRole
 	}
 
 	// Check if the dancer is trying to register with itself
-	if h.isSame(reg.Dancer, reg.Related.Dancer) {
+	if isSame(reg.Dancer, reg.Related.Dancer) {
 		result = models.ResultSelfNotAllowed
 	}
 
@@ -104,18 +383,387 @@ func (h *EventHandler) CoupleAdd(d, p *models.Dancer) *models.Registration {
 		return reg
 	}
 
+	// Ask the partner with a Telegram profile to confirm the couple
+	if h.event.Settings.Consent && organizer == nil && requester == nil && reg.Related.Profile != nil {
+		return h.pendingAdd(reg, false)
+	}
+
 	// 8. Register as couple
-	return h.coupleAdd(reg, false)
+	return h.coupleAdd(reg, false, organizer, requester)
+}
+
+// pendingAdd adds the couple to the list of couples waiting for the partner confirmation
+// and asks the partner to confirm. If replace is true, the confirmed partner replaces
+// the current partner of the requester.
+func (h *EventHandler) pendingAdd(reg *models.Registration, replace bool) *models.Registration {
+	d, p := *reg.Dancer, *reg.Related.Dancer
+	d.Role = reg.Role.Resolve(reg.Related.Role)
+	p.Role = reg.Related.Role.Resolve(reg.Role)
+	couple := models.Couple{
+		CreatedBy: *reg.Profile,
+		Replace:   replace,
+		CreatedAt: nowFn(),
+	}
+	if d.Role == models.RoleLeader {
+		couple.Dancers = []models.Dancer{d, p}
+	} else {
+		couple.Dancers = []models.Dancer{p, d}
+	}
+	h.event.Pending = append(h.event.Pending, couple)
+	h.hist = append(h.hist, &models.HistoryItem{
+		Action:    models.HistoryPendingAdded,
+		Initiator: reg.Profile,
+		EventID:   &h.event.ID,
+		Details:   &couple,
+		CreatedAt: nowFn(),
+	})
+	h.notif = append(h.notif, &models.Notification{
+		TmplCode:  models.TmplConsentRequest,
+		Recipient: reg.Related.Profile,
+		Payload: models.NotificationPayload{
+			Event:   h.event,
+			Partner: &d,
+		},
+	})
+
+	// The single keeps the place in the singles list until the partner confirms
+	if reg.Status == models.StatusNotRegistered {
+		reg.Status = models.StatusPending
+	}
+	reg.Result = models.ResultPendingConsent
+	reg.Partner = &p
+	return reg
+}
+
+// PendingAccept registers the couple confirmed by the invited partner.
+// The requester is identified by the profile ID and becomes the creator of the couple.
+// If the couple replaces the partner of the requester, the invited partner takes
+// the place of the current partner in the couple of the requester.
+// The requester will be notified unless they are notified as a single found by the partner.
+// If the couple can not be registered, it keeps waiting for the confirmation
+// and the requester is notified of the failure.
+func (h *EventHandler) PendingAccept(partner *models.Dancer, requesterID int64) *models.Registration {
+	i := h.findConsent(partner, requesterID)
+	if i < 0 {
+		reg := h.RegistrationGet(partner)
+		reg.Result = models.ResultPendingNotFound
+		return reg
+	}
+	couple := h.event.Pending[i]
+	requester, invited := pendingDancers(&couple)
+	partner.Role = invited.Role
+
+	// The pending couple is removed before the registration,
+	// otherwise the requester is considered as waiting for the confirmation.
+	r := *requester
+	status := h.RegistrationGet(&r).Status
+	h.event.Pending = slices.Delete(h.event.Pending, i, i+1)
+	var reg *models.Registration
+	if couple.Replace && status.HasPartner() {
+		reg = h.pendingReplace(requester, partner)
+	} else {
+		reg = h.coupleRegister(partner, requester, nil, &couple.CreatedBy)
+	}
+	if !reg.Result.IsSuccess() {
+		h.event.Pending = slices.Insert(h.event.Pending, i, couple)
+		h.pendingNotify(&couple, models.TmplConsentFailed)
+		return reg
+	}
+	h.hist = append(h.hist, &models.HistoryItem{
+		Action:    models.HistoryPendingRemoved,
+		Initiator: partner.Profile,
+		EventID:   &h.event.ID,
+		Details:   &couple,
+		CreatedAt: nowFn(),
+	})
+	if status != models.StatusAsSingle && requester.Profile != nil {
+		h.notif = append(h.notif, &models.Notification{
+			TmplCode:  models.TmplConsentAccepted,
+			Recipient: requester.Profile,
+			Payload: models.NotificationPayload{
+				Event:   h.event,
+				Partner: reg.Dancer,
+			},
+		})
+	}
+	return reg
+}
+
+// pendingReplace replaces the partner of the requester with the invited partner
+// who confirmed the couple. Returns the registration of the invited partner.
+func (h *EventHandler) pendingReplace(requester, partner *models.Dancer) *models.Registration {
+	reg := h.partnerReplace(requester, partner, true)
+	related := reg.Related
+	if related == nil {
+		related = h.RegistrationGet(partner)
+	}
+	switch {
+	case reg.Result == models.ResultPartnerReplaced && related.Status == models.StatusInWaitlist:
+		related.Result = models.ResultRegisteredInWaitlist
+	case reg.Result == models.ResultPartnerReplaced:
+		related.Result = models.ResultRegisteredInCouple
+	case reg.Result == models.ResultPartnerTaken && related.Status == models.StatusPending:
+		related.Result = models.ResultAlreadyPending
+	case reg.Result == models.ResultPartnerTaken:
+		related.Result = models.ResultAlreadyInCouple
+	case reg.Result == models.ResultPartnerForbidden:
+		related.Result = models.ResultDancerForbidden
+	default:
+		related.Result = reg.Result
+	}
+	return related
+}
+
+// PendingDecline removes the couple declined by the invited partner and notifies the requester.
+// Returns false if there is no such couple waiting for the partner confirmation.
+func (h *EventHandler) PendingDecline(partner *models.Dancer, requesterID int64) bool {
+	i := h.findConsent(partner, requesterID)
+	if i < 0 {
+		return false
+	}
+	couple := h.pendingRemove(i, partner.Profile)
+	h.pendingNotify(&couple, models.TmplConsentDeclined)
+	return true
+}
+
+// PendingExpire removes the couples that were not confirmed by the partners
+// since the given time and notifies the requesters on behalf of the bot.
+// Returns the number of removed couples.
+func (h *EventHandler) PendingExpire(before time.Time) int {
+	var n int
+	for i := len(h.event.Pending) - 1; i >= 0; i-- {
+		if h.event.Pending[i].CreatedAt.After(before) {
+			continue
+		}
+		couple := h.pendingRemove(i, config.BotProfile())
+		h.pendingNotify(&couple, models.TmplConsentExpired)
+		n++
+	}
+	return n
+}
+
+// pendingRemove removes the couple with the given index from the list of couples
+// waiting for the partner confirmation.
+func (h *EventHandler) pendingRemove(i int, initiator *models.Profile) models.Couple {
+	couple := h.event.Pending[i]
+	h.event.Pending = append(h.event.Pending[:i], h.event.Pending[i+1:]...)
+	h.hist = append(h.hist, &models.HistoryItem{
+		Action:    models.HistoryPendingRemoved,
+		Initiator: initiator,
+		EventID:   &h.event.ID,
+		Details:   &couple,
+		CreatedAt: nowFn(),
+	})
+	return couple
+}
+
+// pendingNotify notifies the requester of the couple waiting for the partner confirmation.
+func (h *EventHandler) pendingNotify(couple *models.Couple, tmplCode models.NotificationTmpl) {
+	requester, invited := pendingDancers(couple)
+	if requester.Profile == nil {
+		return
+	}
+	h.notif = append(h.notif, &models.Notification{
+		TmplCode:  tmplCode,
+		Recipient: requester.Profile,
+		Payload: models.NotificationPayload{
+			Event:   h.event,
+			Partner: invited,
+		},
+	})
+}
+
+// ConsentSet changes the partner consent requirement of the event.
+// Returns false if the event already has the given requirement.
+func (h *EventHandler) ConsentSet(consent bool, initiator *models.Profile) bool {
+	if h.event.Settings.Consent == consent {
+		return false
+	}
+	h.event.Settings.Consent = consent
+	h.hist = append(h.hist, &models.HistoryItem{
+		Action:    models.HistoryConsentChanged,
+		Initiator: initiator,
+		EventID:   &h.event.ID,
+		Details:   h.event.Settings,
+		CreatedAt: nowFn(),
+	})
+	return true
+}
+
+// DeadlinesSet changes the registration and cancellation deadlines of the event.
+// Returns false if the event already has the given deadlines.
+func (h *EventHandler) DeadlinesSet(signup, cancel time.Duration, initiator *models.Profile) bool {
+	if h.event.Settings.SignupDeadline == signup && h.event.Settings.CancelDeadline == cancel {
+		return false
+	}
+	h.event.Settings.SignupDeadline = signup
+	h.event.Settings.CancelDeadline = cancel
+	h.hist = append(h.hist, &models.HistoryItem{
+		Action:    models.HistoryDeadlinesChanged,
+		Initiator: initiator,
+		EventID:   &h.event.ID,
+		Details:   h.event.Settings,
+		CreatedAt: nowFn(),
+	})
+	return true
+}
+
+// SingleTTLSet changes the time after which the singles are removed from the event.
+// Returns false if the event already has the given time.
+func (h *EventHandler) SingleTTLSet(ttl time.Duration, initiator *models.Profile) bool {
+	if h.event.Settings.SingleTTL == ttl {
+		return false
+	}
+	h.event.Settings.SingleTTL = ttl
+	h.hist = append(h.hist, &models.HistoryItem{
+		Action:    models.HistorySingleTTLChanged,
+		Initiator: initiator,
+		EventID:   &h.event.ID,
+		Details:   h.event.Settings,
+		CreatedAt: nowFn(),
+	})
+	return true
+}
+
+// SinglesExpire warns the singles registered or refreshed longer than the event single TTL ago
+// and removes the ones that did not refresh the registration within the grace period
+// since the warning. The singles of the started or closed events are kept.
+// Returns the number of removed singles.
+func (h *EventHandler) SinglesExpire(grace time.Duration) int {
+	ttl := h.event.Settings.SingleTTL
+	now := nowFn()
+	switch {
+	case ttl <= 0:
+		return 0
+	case h.event.Settings.ClosedFor == models.ClosedForAll:
+		return 0
+	case h.event.StartsAt != nil && !now.Before(*h.event.StartsAt):
+		return 0
+	}
+	var n int
+	for i := len(h.event.Singles) - 1; i >= 0; i-- {
+		single := &h.event.Singles[i]
+		since := single.CreatedAt
+		if single.RefreshedAt != nil {
+			since = *single.RefreshedAt
+		}
+		if now.Before(since.Add(ttl)) {
+			continue
+		}
+
+		// Warn the single first
+		if single.WarnedAt == nil {
+			single.WarnedAt = &now
+			if single.Profile != nil {
+				h.notif = append(h.notif, &models.Notification{
+					TmplCode:  models.TmplSingleExpiring,
+					Recipient: single.Profile,
+					Payload:   models.NotificationPayload{Event: h.event},
+				})
+			}
+			continue
+		}
+
+		// Remove the single if the grace period has passed
+		if now.Before(single.WarnedAt.Add(grace)) {
+			continue
+		}
+		dancer := *single
+		h.event.Singles = append(h.event.Singles[:i], h.event.Singles[i+1:]...)
+		h.hist = append(h.hist, &models.HistoryItem{
+			Action:    models.HistorySingleRemoved,
+			Initiator: config.BotProfile(),
+			EventID:   &h.event.ID,
+			Details:   &dancer,
+			CreatedAt: now,
+		})
+		if dancer.Profile != nil {
+			h.notif = append(h.notif, &models.Notification{
+				TmplCode:  models.TmplSingleExpired,
+				Recipient: dancer.Profile,
+				Payload:   models.NotificationPayload{Event: h.event},
+			})
+		}
+		n++
+	}
+	return n
+}
+
+// SinglesAlert notifies the singles with Telegram profiles compatible with the given new single.
+// The notifications are sent only to the users who opted in to them, see [NotifierService.Notify].
+// Does nothing if the auto pairing is on, as the singles are paired automatically.
+// Returns the number of notifications.
+func (h *EventHandler) SinglesAlert(single *models.Dancer) int {
+	if h.event.Settings.AutoPairing {
+		return 0
+	}
+	var n int
+	for i := range h.event.Singles {
+		other := &h.event.Singles[i]
+		if other.Profile == nil || isSame(single, other) || !single.Role.Pairs(other.Role) {
+			continue
+		}
+		h.notif = append(h.notif, &models.Notification{
+			TmplCode:  models.TmplSingleJoined,
+			Recipient: other.Profile,
+			Payload: models.NotificationPayload{
+				Event:   h.event,
+				Partner: single,
+			},
+		})
+		n++
+	}
+	return n
+}
+
+// SingleRefresh confirms that the single dancer is still looking for a partner.
+// The single TTL is counted from the refresh time, so the single is not removed as stale.
+// The single keeps the registration time and the place in the singles list.
+// Returns false if the dancer is not registered as single.
+func (h *EventHandler) SingleRefresh(dancer *models.Dancer) bool {
+	for i := range h.event.Singles {
+		single := &h.event.Singles[i]
+		if !isSame(dancer, single) {
+			continue
+		}
+		now := nowFn()
+		single.RefreshedAt = &now
+		single.WarnedAt = nil
+		refreshed := *single
+		h.hist = append(h.hist, &models.HistoryItem{
+			Action:    models.HistorySingleRefreshed,
+			Initiator: dancer.Profile,
+			EventID:   &h.event.ID,
+			Details:   &refreshed,
+			CreatedAt: nowFn(),
+		})
+		return true
+	}
+	return false
 }
 
 // coupleAdd processes the couple registration.
-func (h *EventHandler) coupleAdd(reg *models.Registration, isAutoPair bool) *models.Registration {
+// If the organizer is not nil, the couple is registered on behalf of the organizer
+// and the partner from the singles list is not notified here.
+func (h *EventHandler) coupleAdd(reg *models.Registration, isAutoPair bool, organizer, requester *models.Profile) *models.Registration {
+	initiator := reg.Profile
+	switch {
+	case isAutoPair:
+		initiator = config.BotProfile()
+	case organizer != nil:
+		initiator = organizer
+	}
+
 	// Check if dancer is in singles and remove from singles
 	if reg.Status == models.StatusAsSingle {
+		singleInitiator := reg.Dancer.Profile
+		if organizer != nil {
+			singleInitiator = organizer
+		}
 		h.removeFromSingles(reg.Dancer)
 		h.hist = append(h.hist, &models.HistoryItem{
 			Action:    models.HistorySingleRemoved,
-			Initiator: reg.Dancer.Profile,
+			Initiator: singleInitiator,
 			EventID:   &h.event.ID,
 			Details:   reg.Dancer,
 			CreatedAt: nowFn(),
@@ -124,10 +772,6 @@ func (h *EventHandler) coupleAdd(reg *models.Registration, isAutoPair bool) *mod
 
 	// Check if partner is in singles and remove from singles
 	// and create notification for the partner
-	initiator := reg.Profile
-	if isAutoPair {
-		initiator = config.BotProfile()
-	}
 	if reg.Related.Status == models.StatusAsSingle {
 		h.removeFromSingles(reg.Related.Dancer)
 		// Add history item and notification for the partner
@@ -144,21 +788,37 @@ func (h *EventHandler) coupleAdd(reg *models.Registration, isAutoPair bool) *mod
 		} else {
 			tmplCode = models.TmplRegisteredWithSingle
 		}
-		h.notif = append(h.notif, &models.Notification{
-			TmplCode:  tmplCode,
-			Recipient: reg.Related.Profile,
-			Payload: models.NotificationPayload{
-				Event:   h.event,
-				Partner: reg.Dancer,
-			},
-		})
+		if reg.Related.Profile != nil && organizer == nil {
+			h.notif = append(h.notif, &models.Notification{
+				TmplCode:  tmplCode,
+				Recipient: reg.Related.Profile,
+				Payload: models.NotificationPayload{
+					Event:   h.event,
+					Partner: reg.Dancer,
+				},
+			})
+		}
 	}
 
-	// Create a couple
+	// Resolve the roles of the switch dancers
+	reg.Dancer.Role = reg.Role.Resolve(reg.Related.Role)
+	reg.Related.Dancer.Role = reg.Related.Role.Resolve(reg.Role)
+
+	// Create a couple. The dancers added by the organizer may have no profile,
+	// so the initiator becomes the creator of the couple.
+	// The couple confirmed by the partner is created by the requester.
+	createdBy := reg.Profile
+	switch {
+	case requester != nil:
+		createdBy = requester
+	case organizer != nil || createdBy == nil:
+		createdBy = initiator
+	}
 	couple := models.Couple{
-		CreatedBy: *reg.Profile,
-		AutoPair:  isAutoPair,
-		CreatedAt: nowFn(),
+		CreatedBy:   *createdBy,
+		AutoPair:    isAutoPair,
+		ByOrganizer: organizer != nil,
+		CreatedAt:   nowFn(),
 	}
 	if reg.Role == models.RoleLeader {
 		couple.Dancers = []models.Dancer{*reg.Dancer, *reg.Related.Dancer}
@@ -166,29 +826,480 @@ func (h *EventHandler) coupleAdd(reg *models.Registration, isAutoPair bool) *mod
 		couple.Dancers = []models.Dancer{*reg.Related.Dancer, *reg.Dancer}
 	}
 
+	// If the limit is reached, add couple to the waitlist
+	action, status, result := models.HistoryCoupleAdded, models.StatusInCouple, models.ResultRegisteredInCouple
+	if h.isFull() {
+		action, status, result = models.HistoryWaitlistAdded, models.StatusInWaitlist, models.ResultRegisteredInWaitlist
+	}
+
 	// Add couple to the event history
 	h.hist = append(h.hist, &models.HistoryItem{
-		Action:    models.HistoryCoupleAdded,
+		Action:    action,
 		Initiator: initiator,
 		EventID:   &h.event.ID,
 		Details:   &couple,
 		CreatedAt: nowFn(),
 	})
 
-	// Add couple to the event and return the registration
-	h.event.Couples = append(h.event.Couples, couple)
-	reg.Result = models.ResultRegisteredInCouple
-	reg.Status = models.StatusInCouple
-	reg.Partner = reg.Related.Dancer
-	reg.Related.Result = models.ResultRegisteredInCouple
-	reg.Related.Status = models.StatusInCouple
-	reg.Related.Partner = reg.Dancer
-	return reg
+	// Add couple to the event and return the registration
+	if status == models.StatusInWaitlist {
+		h.event.Waitlist = append(h.event.Waitlist, couple)
+	} else {
+		h.event.Couples = append(h.event.Couples, couple)
+	}
+	reg.Result = result
+	reg.Status = status
+	reg.Partner = reg.Related.Dancer
+	reg.Related.Result = result
+	reg.Related.Status = status
+	reg.Related.Partner = reg.Dancer
+	return reg
+}
+
+// DancerClaim attaches the profile of the dancer to the partner registered by name
+// with the given claim token. After that the partner can manage the registration
+// and receives notifications.
+func (h *EventHandler) DancerClaim(d *models.Dancer, token string) *models.Registration {
+	reg := h.RegistrationGet(d)
+	couple, i := h.findClaim(token)
+	switch {
+	case couple == nil:
+		reg.Result = models.ResultClaimNotFound
+	case reg.Status == models.StatusForbidden:
+		reg.Result = models.ResultDancerForbidden
+	case reg.Status.HasPartner():
+		reg.Result = models.ResultAlreadyInCouple
+	case reg.Status == models.StatusAsSingle:
+		reg.Result = models.ResultAlreadyAsSingle
+	case reg.Status == models.StatusPending:
+		reg.Result = models.ResultAlreadyPending
+	}
+	if reg.Result != models.ResultNoResult {
+		return reg
+	}
+
+	couple.Dancers[i].Profile = d.Profile
+	couple.Dancers[i].ClaimToken = ""
+	h.hist = append(h.hist, &models.HistoryItem{
+		Action:    models.HistoryDancerClaimed,
+		Initiator: d.Profile,
+		EventID:   &h.event.ID,
+		Details:   couple,
+		CreatedAt: nowFn(),
+	})
+
+	reg = h.RegistrationGet(d)
+	reg.Result = models.ResultClaimed
+	return reg
+}
+
+// PartnerReplace replaces the partner of the dancer registered in a couple with another dancer.
+// The couple keeps its place in the couples list or the waitlist and its creation time.
+// The new partner takes the role opposite to the dancer. The former partner signed up
+// as a single returns to the singles list, otherwise they are notified of the replacement.
+// If the event requires the partner consent and the new partner has a Telegram profile,
+// the replacement waits for the new partner confirmation.
+func (h *EventHandler) PartnerReplace(d, p *models.Dancer) *models.Registration {
+	return h.partnerReplace(d, p, false)
+}
+
+// partnerReplace checks the replacement rules and replaces the partner of the dancer.
+// If confirmed is true, the new partner has already confirmed the couple.
+func (h *EventHandler) partnerReplace(d, p *models.Dancer, confirmed bool) *models.Registration {
+	result := models.ResultNoResult
+	reg := h.RegistrationGet(d)
+	if !reg.Status.HasPartner() {
+		reg.Result = models.ResultWasNotRegistered
+		return reg
+	}
+	p.Role = reg.Role.Opposite()
+	reg.Related = h.RegistrationGet(p)
+	resolveRole(reg.Related, p.Role)
+
+	// Check if event is not forbidden for the partner
+	if reg.Related.Status == models.StatusForbidden {
+		result = models.ResultPartnerForbidden
+	}
+
+	// Check if the partner is not registered in a couple or waiting for the confirmation
+	if reg.Related.Status.HasPartner() || reg.Related.Status == models.StatusPending {
+		result = models.ResultPartnerTaken
+	}
+	if isSame(reg.Partner, reg.Related.Dancer) {
+		result = models.ResultAlreadyInSameCouple
+	}
+
+	// Check if the dancer is not waiting for another partner confirmation
+	if h.findPending(reg.Dancer) >= 0 {
+		result = models.ResultAlreadyPending
+	}
+
+	// Check if event is not closed for new registrations
+	if h.event.Settings.ClosedFor == models.ClosedForAll {
+		result = models.ResultEventClosed
+	}
+	if h.signupClosed() || h.cancelClosed() {
+		result = models.ResultDeadlinePassed
+	}
+
+	// Check if the partner has the role opposite to the dancer
+	if !reg.Role.Pairs(reg.Related.Role) {
+		result = models.ResultPartnerSameRole
+	}
+
+	// Check if the dancer is trying to register with itself
+	if isSame(reg.Dancer, reg.Related.Dancer) {
+		result = models.ResultSelfNotAllowed
+	}
+
+	if result != models.ResultNoResult {
+		reg.Result = result
+		return reg
+	}
+
+	// Ask the new partner with a Telegram profile to confirm the couple
+	if h.event.Settings.Consent && !confirmed && reg.Related.Profile != nil {
+		return h.pendingAdd(reg, true)
+	}
+
+	// Take the new partner from the singles list
+	if reg.Related.Status == models.StatusAsSingle {
+		h.removeFromSingles(reg.Related.Dancer)
+		h.hist = append(h.hist, &models.HistoryItem{
+			Action:    models.HistorySingleRemoved,
+			Initiator: reg.Profile,
+			EventID:   &h.event.ID,
+			Details:   reg.Related.Dancer,
+			CreatedAt: nowFn(),
+		})
+		if reg.Related.Profile != nil && !confirmed {
+			h.notif = append(h.notif, &models.Notification{
+				TmplCode:  models.TmplRegisteredWithSingle,
+				Recipient: reg.Related.Profile,
+				Payload: models.NotificationPayload{
+					Event:   h.event,
+					Partner: reg.Dancer,
+				},
+			})
+		}
+	}
+
+	// Replace the partner in place
+	partner := *reg.Related.Dancer
+	partner.Role = reg.Related.Role.Resolve(reg.Role)
+	couple, i := h.findCouple(reg.Dancer)
+	ex := couple.Dancers[1-i]
+	couple.Dancers[1-i] = partner
+	couple.CreatedBy = *reg.Profile
+	couple.Repeat = false
+	details := *couple
+	h.hist = append(h.hist, &models.HistoryItem{
+		Action:    models.HistoryPartnerReplaced,
+		Initiator: reg.Profile,
+		EventID:   &h.event.ID,
+		Details:   &details,
+		CreatedAt: nowFn(),
+	})
+	reg.Result = models.ResultPartnerReplaced
+	reg.Partner = &partner
+	reg.Related.Status = reg.Status
+	reg.Related.Partner = reg.Dancer
+
+	// Return the former partner signed up as a single back to singles (or auto pair if available)
+	exReg := &models.Registration{
+		Dancer: &ex,
+		Status: models.StatusNotRegistered,
+		Event:  h.event,
+	}
+	if ex.AsSingle {
+		h.singleRestore(exReg, reg.Dancer, nil)
+		return reg
+	}
+	if ex.Profile != nil {
+		h.notif = append(h.notif, &models.Notification{
+			TmplCode:  models.TmplPartnerReplaced,
+			Recipient: ex.Profile,
+			Payload: models.NotificationPayload{
+				Event:      h.event,
+				Partner:    reg.Dancer,
+				NewPartner: &partner,
+			},
+		})
+	}
+	return reg
+}
+
+// RoleSwitch switches the roles of the dancers in the couple of the dancer.
+// The couple keeps its place in the couples list or the waitlist. The partner is notified.
+// The roles can not be switched if the event is closed or any of the deadlines has passed.
+func (h *EventHandler) RoleSwitch(d *models.Dancer) *models.Registration {
+	reg := h.RegistrationGet(d)
+	switch {
+	case !reg.Status.HasPartner():
+		reg.Result = models.ResultWasNotRegistered
+		return reg
+	case h.event.Settings.ClosedFor == models.ClosedForAll:
+		reg.Result = models.ResultEventClosed
+		return reg
+	case h.signupClosed() || h.cancelClosed():
+		reg.Result = models.ResultDeadlinePassed
+		return reg
+	}
+	couple, _ := h.findCouple(reg.Dancer)
+	couple.Dancers[0].Role, couple.Dancers[1].Role = couple.Dancers[1].Role, couple.Dancers[0].Role
+	couple.Dancers[0], couple.Dancers[1] = couple.Dancers[1], couple.Dancers[0]
+	details := *couple
+	h.hist = append(h.hist, &models.HistoryItem{
+		Action:    models.HistoryRoleSwitched,
+		Initiator: d.Profile,
+		EventID:   &h.event.ID,
+		Details:   &details,
+		CreatedAt: nowFn(),
+	})
+
+	reg = h.RegistrationGet(d)
+	reg.Result = models.ResultRoleSwitched
+	if reg.Partner.Profile != nil {
+		h.notif = append(h.notif, &models.Notification{
+			TmplCode:  models.TmplRoleSwitched,
+			Recipient: reg.Partner.Profile,
+			Payload: models.NotificationPayload{
+				Event:   h.event,
+				Partner: reg.Dancer,
+			},
+		})
+	}
+	return reg
+}
+
+// LimitSet changes the maximum number of couples for the event.
+// If the new limit allows, couples from the waitlist are moved to the couples list.
+// Returns false if the event already has the given limit.
+func (h *EventHandler) LimitSet(limit int, initiator *models.Profile) bool {
+	if h.event.Settings.Limit == limit {
+		return false
+	}
+	h.event.Settings.Limit = limit
+	h.hist = append(h.hist, &models.HistoryItem{
+		Action:    models.HistoryLimitChanged,
+		Initiator: initiator,
+		EventID:   &h.event.ID,
+		Details:   h.event.Settings,
+		CreatedAt: nowFn(),
+	})
+	h.waitlistPromote()
+	return true
+}
+
+// CaptionSet changes the caption of the event.
+// Returns false if the event already has the given caption.
+func (h *EventHandler) CaptionSet(caption string, initiator *models.Profile) bool {
+	if h.event.Caption == caption {
+		return false
+	}
+	h.hist = append(h.hist, &models.HistoryItem{
+		Action:    models.HistoryCaptionChanged,
+		Initiator: initiator,
+		EventID:   &h.event.ID,
+		Details:   models.CaptionChange{Old: h.event.Caption, New: caption},
+		CreatedAt: nowFn(),
+	})
+	h.event.Caption = caption
+	return true
+}
+
+// StartsAtSet changes the start time of the event. Nil start time removes it.
+// The time zone of the event is set to the location of the start time.
+// Returns false if the event already has the given start time.
+func (h *EventHandler) StartsAtSet(startsAt *time.Time, initiator *models.Profile) bool {
+	switch {
+	case startsAt == nil && h.event.StartsAt == nil:
+		return false
+	case startsAt != nil && h.event.StartsAt != nil &&
+		startsAt.Equal(*h.event.StartsAt) && startsAt.Location().String() == h.event.Timezone:
+		return false
+	}
+	h.event.StartsAt = startsAt
+	h.event.Timezone = ""
+	if startsAt != nil {
+		h.event.Timezone = startsAt.Location().String()
+	}
+	// the new start time must be checked by the auto-close scheduler again
+	h.event.AutoClosedAt = nil
+	h.hist = append(h.hist, &models.HistoryItem{
+		Action:    models.HistoryStartsAtChanged,
+		Initiator: initiator,
+		EventID:   &h.event.ID,
+		Details:   startsAt,
+		CreatedAt: nowFn(),
+	})
+	return true
+}
+
+// RecurrenceSet changes the recurrence rule of the event.
+// Returns false if the event already has the given recurrence rule.
+func (h *EventHandler) RecurrenceSet(recurrence models.Recurrence, initiator *models.Profile) bool {
+	if h.event.Recurrence == recurrence {
+		return false
+	}
+	h.event.Recurrence = recurrence
+	h.hist = append(h.hist, &models.HistoryItem{
+		Action:    models.HistoryRecurrenceChanged,
+		Initiator: initiator,
+		EventID:   &h.event.ID,
+		Details:   recurrence,
+		CreatedAt: nowFn(),
+	})
+	return true
+}
+
+// LevelsSet changes the dance level range of the dancers paired automatically.
+// Returns false if the event already has the given level range.
+func (h *EventHandler) LevelsSet(minLevel, maxLevel models.Level, initiator *models.Profile) bool {
+	if h.event.Settings.MinLevel == minLevel && h.event.Settings.MaxLevel == maxLevel {
+		return false
+	}
+	h.event.Settings.MinLevel = minLevel
+	h.event.Settings.MaxLevel = maxLevel
+	h.hist = append(h.hist, &models.HistoryItem{
+		Action:    models.HistoryLevelsChanged,
+		Initiator: initiator,
+		EventID:   &h.event.ID,
+		Details:   []models.Level{minLevel, maxLevel},
+		CreatedAt: nowFn(),
+	})
+	return true
+}
+
+// Clone returns a new draft event with the given ID cloned from the event.
+// The caption, settings, organizers, forbidden dancers and recurrence rule are copied.
+// The start time of the recurring event is moved by the recurrence period.
+// The start time of the non-recurring event is not copied: the owner sets it before publishing.
+// If withCouples is true, the couples opted in to repeat are signed up for the new event
+// within the couples limit, the rest of them are added to the waitlist.
+// Couples with a dancer forbidden to sign in for the event or blocked by the owner are not carried over.
+func (h *EventHandler) Clone(id string, withCouples bool, initiator *models.Profile) *models.Event {
+	now := nowFn()
+	next := &models.Event{
+		ID:           id,
+		Caption:      h.event.Caption,
+		Settings:     h.event.Settings,
+		Forbidden:    slices.Clone(h.event.Forbidden),
+		Owner:        h.event.Owner,
+		CoOrganizers: slices.Clone(h.event.CoOrganizers),
+		Timezone:     h.event.Timezone,
+		Recurrence:   h.event.Recurrence,
+		CreatedAt:    now,
+	}
+	next.Settings.ClosedFor = models.ClosedForNone
+	if h.event.StartsAt != nil && h.event.Recurrence.Period() > 0 {
+		startsAt := h.event.StartsAt.Add(h.event.Recurrence.Period())
+		next.StartsAt = &startsAt
+	}
+
+	if withCouples {
+		for _, couple := range h.event.Couples {
+			if !couple.Repeat || h.isForbidden(&couple.Dancers[0]) || h.isForbidden(&couple.Dancers[1]) {
+				continue
+			}
+			couple.Dancers = slices.Clone(couple.Dancers)
+			couple.CreatedAt = now
+			if next.Settings.Limit > 0 && len(next.Couples) >= next.Settings.Limit {
+				next.Waitlist = append(next.Waitlist, couple)
+			} else {
+				next.Couples = append(next.Couples, couple)
+			}
+		}
+	}
+
+	h.event.NextID = id
+	h.hist = append(h.hist, &models.HistoryItem{
+		Action:    models.HistoryEventCloned,
+		Initiator: initiator,
+		EventID:   &h.event.ID,
+		Details:   next.ID,
+		CreatedAt: now,
+	})
+	return next
+}
+
+// CoupleRepeatSet changes the opt-in of the dancer couple to be signed up for the next recurring event.
+// Returns false if the dancer is not registered in a couple or the opt-in is not changed.
+func (h *EventHandler) CoupleRepeatSet(dancer *models.Dancer, repeat bool) bool {
+	for i := range h.event.Couples {
+		couple := &h.event.Couples[i]
+		if !isSame(dancer, &couple.Dancers[0]) && !isSame(dancer, &couple.Dancers[1]) {
+			continue
+		}
+		if couple.Repeat == repeat {
+			return false
+		}
+		couple.Repeat = repeat
+		h.hist = append(h.hist, &models.HistoryItem{
+			Action:    models.HistoryCoupleRepeatSet,
+			Initiator: dancer.Profile,
+			EventID:   &h.event.ID,
+			Details:   couple,
+			CreatedAt: nowFn(),
+		})
+		return true
+	}
+	return false
+}
+
+// AutoClose closes the registration for the event before its start on behalf of the bot.
+// Returns false if the event has no start time or was already closed automatically.
+func (h *EventHandler) AutoClose() bool {
+	if h.event.StartsAt == nil || h.event.AutoClosedAt != nil {
+		return false
+	}
+	now := nowFn()
+	h.event.AutoClosedAt = &now
+	h.ClosedForSet(models.ClosedForAll, config.BotProfile())
+	return true
 }
 
 // SingleAdd registers a dancer as a single for the event.
 // If auto pairing is enabled, tries to auto pair the dancer.
+// The dancer of the solo event is registered as a participant.
 func (h *EventHandler) SingleAdd(d *models.Dancer) *models.Registration {
+	return h.singleRegister(d, nil)
+}
+
+// SingleAddByOrganizer registers a dancer as a single for the event on behalf of the organizer.
+// The organizer can register the dancer even if the event is closed for new registrations or singles.
+// The dancer of the solo event is registered as a participant.
+// If auto pairing is enabled, tries to auto pair the dancer. The dancer will be notified.
+func (h *EventHandler) SingleAddByOrganizer(d *models.Dancer, organizer *models.Profile) *models.Registration {
+	reg := h.singleRegister(d, organizer)
+	if !reg.Result.IsSuccess() || reg.Profile == nil {
+		return reg
+	}
+	tmplCode := models.TmplSingleAddedByOrganizer
+	switch {
+	case reg.Status.HasPartner():
+		tmplCode = models.TmplAutoPairPartnerFound
+	case reg.Status == models.StatusParticipant:
+		tmplCode = models.TmplParticipantAddedByOrganizer
+	}
+	h.notif = append(h.notif, &models.Notification{
+		TmplCode:  tmplCode,
+		Recipient: reg.Profile,
+		Payload: models.NotificationPayload{
+			Event:   h.event,
+			Partner: reg.Partner,
+		},
+	})
+	return reg
+}
+
+// singleRegister checks the single registration rules and registers the single.
+// If the organizer is not nil, the single is registered on behalf of the organizer.
+func (h *EventHandler) singleRegister(d *models.Dancer, organizer *models.Profile) *models.Registration {
+	// Dancers of the solo event sign up individually
+	if h.event.Settings.Solo {
+		return h.participantRegister(d, organizer)
+	}
 
 	result := models.ResultNoResult
 	reg := h.RegistrationGet(d)
@@ -199,7 +1310,7 @@ func (h *EventHandler) SingleAdd(d *models.Dancer) *models.Registration {
 	}
 
 	// Check if the dancer not already registered in a couple
-	if reg.Status == models.StatusInCouple {
+	if reg.Status.HasPartner() {
 		result = models.ResultAlreadyInCouple
 	}
 
@@ -209,25 +1320,43 @@ func (h *EventHandler) SingleAdd(d *models.Dancer) *models.Registration {
 	}
 
 	// 4. Check if event is not closed for new registrations
-	if h.event.Settings.ClosedFor == models.ClosedForAll {
+	if h.event.Settings.ClosedFor == models.ClosedForAll && organizer == nil {
 		result = models.ResultEventClosed
 	}
+	if h.signupClosed() && organizer == nil {
+		result = models.ResultDeadlinePassed
+	}
 
-	// 5. Try to auto pair the reg
-	if autoPairReg := h.tryAutoPair(reg); autoPairReg != nil {
-		return autoPairReg
+	// The switch dancer is registered for the only role open for singles.
+	// Otherwise, remember that the dancer can dance either role
+	if reg.Status == models.StatusNotRegistered && reg.Role == models.RoleSwitch {
+		switch {
+		case h.event.Settings.ClosedFor == models.ClosedForSingleLeaders && organizer == nil:
+			reg.Dancer.Role = models.RoleFollower
+		case h.event.Settings.ClosedFor == models.ClosedForSingleFollowers && organizer == nil:
+			reg.Dancer.Role = models.RoleLeader
+		default:
+			reg.Dancer.Switch = true
+		}
+	}
+
+	// 5. Try to auto pair the reg if nothing prevents the registration
+	if result == models.ResultNoResult {
+		if autoPairReg := h.tryAutoPair(reg); autoPairReg != nil {
+			return autoPairReg
+		}
 	}
 
 	// 6. Check if singles are allowed for the event
-	if h.event.Settings.ClosedFor == models.ClosedForSingles {
+	if h.event.Settings.ClosedFor == models.ClosedForSingles && organizer == nil {
 		result = models.ResultClosedForSingles
 	}
 
 	// 7. Check if singles are allowed for the role
-	if (h.event.Settings.ClosedFor == models.ClosedForSingleLeaders &&
+	if ((h.event.Settings.ClosedFor == models.ClosedForSingleLeaders &&
 		reg.Dancer.Role == models.RoleLeader) ||
 		(h.event.Settings.ClosedFor == models.ClosedForSingleFollowers &&
-			reg.Dancer.Role == models.RoleFollower) {
+			reg.Dancer.Role == models.RoleFollower)) && organizer == nil {
 		result = models.ResultClosedForSingleRole
 	}
 
@@ -238,11 +1367,15 @@ func (h *EventHandler) SingleAdd(d *models.Dancer) *models.Registration {
 	}
 
 	// 9. Create a single and add to the event
+	initiator := reg.Profile
+	if organizer != nil {
+		initiator = organizer
+	}
 	reg.Dancer.AsSingle = true
 	h.event.Singles = append(h.event.Singles, *reg.Dancer)
 	h.hist = append(h.hist, &models.HistoryItem{
 		Action:    models.HistorySingleAdded,
-		Initiator: reg.Profile,
+		Initiator: initiator,
 		EventID:   &h.event.ID,
 		Details:   reg.Dancer,
 		CreatedAt: nowFn(),
@@ -256,6 +1389,78 @@ func (h *EventHandler) SingleAdd(d *models.Dancer) *models.Registration {
 	return reg
 }
 
+// ParticipantAdd registers a dancer individually for the solo event.
+// The role of the dancer is optional and used only for the headcount.
+func (h *EventHandler) ParticipantAdd(d *models.Dancer) *models.Registration {
+	return h.participantRegister(d, nil)
+}
+
+// participantRegister checks the solo event registration rules and registers the participant.
+// If the organizer is not nil, the participant is registered on behalf of the organizer.
+func (h *EventHandler) participantRegister(d *models.Dancer, organizer *models.Profile) *models.Registration {
+	reg := h.RegistrationGet(d)
+	switch {
+	case !h.event.Settings.Solo:
+		reg.Result = models.ResultEventClosed
+		return reg
+	case reg.Status == models.StatusForbidden:
+		reg.Result = models.ResultDancerForbidden
+		return reg
+	case reg.Status == models.StatusParticipant:
+		reg.Result = models.ResultAlreadyParticipant
+		return reg
+	case h.event.Settings.ClosedFor == models.ClosedForAll && organizer == nil:
+		reg.Result = models.ResultEventClosed
+		return reg
+	case h.signupClosed() && organizer == nil:
+		reg.Result = models.ResultDeadlinePassed
+		return reg
+	}
+
+	initiator := reg.Profile
+	if organizer != nil {
+		initiator = organizer
+	}
+	h.event.Participants = append(h.event.Participants, *reg.Dancer)
+	h.hist = append(h.hist, &models.HistoryItem{
+		Action:    models.HistoryParticipantAdded,
+		Initiator: initiator,
+		EventID:   &h.event.ID,
+		Details:   reg.Dancer,
+		CreatedAt: nowFn(),
+	})
+	reg.Status = models.StatusParticipant
+	reg.Result = models.ResultRegisteredAsParticipant
+	return reg
+}
+
+// SoloSet switches the event between the solo mode, where the dancers sign up individually,
+// and the regular mode with couples and singles.
+// Returns false if the event already has the given mode.
+func (h *EventHandler) SoloSet(solo bool, initiator *models.Profile) bool {
+	if h.event.Settings.Solo == solo {
+		return false
+	}
+	h.event.Settings.Solo = solo
+	h.hist = append(h.hist, &models.HistoryItem{
+		Action:    models.HistorySoloChanged,
+		Initiator: initiator,
+		EventID:   &h.event.ID,
+		Details:   h.event.Settings,
+		CreatedAt: nowFn(),
+	})
+	return true
+}
+
+// IsEmpty returns true if nobody is registered for the event.
+func (h *EventHandler) IsEmpty() bool {
+	return len(h.event.Couples) == 0 &&
+		len(h.event.Waitlist) == 0 &&
+		len(h.event.Pending) == 0 &&
+		len(h.event.Singles) == 0 &&
+		len(h.event.Participants) == 0
+}
+
 // DancerRemove removes the dancer from the event.
 //
 // If the dancer is in a couple, and the partner initially signed up as a single,
@@ -280,12 +1485,139 @@ func (h *EventHandler) DancerRemove(d *models.Dancer) *models.Registration {
 		return reg
 	}
 
+	// Late cancellation is up to the organizer.
+	// The couple waiting for the partner confirmation can always be canceled.
+	if h.cancelClosed() && reg.Status != models.StatusPending {
+		reg.Result = models.ResultCancelClosed
+		return reg
+	}
+
+	return h.registrationRemove(reg, nil)
+}
+
+// DancerRemoveByOrganizer removes the dancer from the event on behalf of the organizer.
+// The organizer can remove the dancer even if the event is closed for new registrations.
+// The dancer and the partner (if any) will be notified.
+func (h *EventHandler) DancerRemoveByOrganizer(d *models.Dancer, organizer *models.Profile) *models.Registration {
+	reg := h.RegistrationGet(d)
+	if !reg.Status.IsRegistered() {
+		reg.Result = models.ResultWasNotRegistered
+		return reg
+	}
+
+	reg = h.registrationRemove(reg, organizer)
+	if reg.Profile != nil {
+		h.notif = append(h.notif, &models.Notification{
+			TmplCode:  models.TmplRemovedByOrganizer,
+			Recipient: reg.Profile,
+			Payload: models.NotificationPayload{
+				Event: h.event,
+			},
+		})
+	}
+	return reg
+}
+
+// DancerForbid forbids the dancer to sign in for the event.
+// If the dancer is registered, the registration is removed
+// and the dancer and the partner are notified.
+// Returns false if the dancer is already forbidden.
+func (h *EventHandler) DancerForbid(d *models.Dancer, initiator *models.Profile) bool {
+	if isListed(d, h.event.Forbidden) {
+		return false
+	}
+
+	reg := h.RegistrationGet(d)
+	if reg.Status.IsRegistered() {
+		h.registrationRemove(reg, initiator)
+		if reg.Profile != nil {
+			h.notif = append(h.notif, &models.Notification{
+				TmplCode:  models.TmplDancerForbidden,
+				Recipient: reg.Profile,
+				Payload: models.NotificationPayload{
+					Event: h.event,
+				},
+			})
+		}
+	}
+
+	forbidden := models.Dancer{
+		Profile:   reg.Dancer.Profile,
+		FullName:  reg.Dancer.FullName,
+		CreatedAt: nowFn(),
+	}
+	h.event.Forbidden = append(h.event.Forbidden, forbidden)
+	h.hist = append(h.hist, &models.HistoryItem{
+		Action:    models.HistoryDancerForbidden,
+		Initiator: initiator,
+		EventID:   &h.event.ID,
+		Details:   &forbidden,
+		CreatedAt: nowFn(),
+	})
+	return true
+}
+
+// DancerAllow removes the dancer with the given key from the forbidden list of the event.
+// Returns false if there is no such dancer in the list.
+func (h *EventHandler) DancerAllow(key string, initiator *models.Profile) bool {
+	for i, allowed := range h.event.Forbidden {
+		if allowed.Key() != key {
+			continue
+		}
+		h.event.Forbidden = append(h.event.Forbidden[:i], h.event.Forbidden[i+1:]...)
+		h.hist = append(h.hist, &models.HistoryItem{
+			Action:    models.HistoryDancerAllowed,
+			Initiator: initiator,
+			EventID:   &h.event.ID,
+			Details:   &allowed,
+			CreatedAt: nowFn(),
+		})
+		return true
+	}
+	return false
+}
+
+// registrationRemove removes the registration of the dancer.
+// If the organizer is nil, the dancer removes the registration by themselves.
+// Otherwise, the partner will be notified that the dancer was removed by the organizer.
+func (h *EventHandler) registrationRemove(reg *models.Registration, organizer *models.Profile) *models.Registration {
+	initiator := reg.Profile
+	if organizer != nil {
+		initiator = organizer
+	}
+
+	// Cancel the couple waiting for the partner confirmation
+	if i := h.findPending(reg.Dancer); i >= 0 {
+		h.pendingRemove(i, initiator)
+		if reg.Status == models.StatusPending {
+			reg.Result = models.ResultRegistrationRemoved
+			reg.Status = models.StatusNotRegistered
+			reg.Partner = nil
+			return reg
+		}
+	}
+
+	// Check if dancer is in a participants list and remove from participants
+	if reg.Status == models.StatusParticipant {
+		h.removeFromParticipants(reg.Dancer)
+		h.hist = append(h.hist, &models.HistoryItem{
+			Action:    models.HistoryParticipantRemoved,
+			Initiator: initiator,
+			EventID:   &h.event.ID,
+			Details:   reg.Dancer,
+			CreatedAt: nowFn(),
+		})
+		reg.Result = models.ResultRegistrationRemoved
+		reg.Status = models.StatusNotRegistered
+		return reg
+	}
+
 	// Check if dancer is in a singles list and remove from singles
 	if reg.Status == models.StatusAsSingle {
 		h.removeFromSingles(reg.Dancer)
 		h.hist = append(h.hist, &models.HistoryItem{
 			Action:    models.HistorySingleRemoved,
-			Initiator: reg.Profile,
+			Initiator: initiator,
 			EventID:   &h.event.ID,
 			Details:   reg.Dancer,
 			CreatedAt: nowFn(),
@@ -295,15 +1627,30 @@ func (h *EventHandler) DancerRemove(d *models.Dancer) *models.Registration {
 		return reg
 	}
 
-	// If dancer is in a couple remove the couple
-	removedCouple := h.removeCouple(reg.Dancer)
-	h.hist = append(h.hist, &models.HistoryItem{
-		Action:    models.HistoryCoupleRemoved,
-		Initiator: reg.Dancer.Profile,
-		EventID:   &h.event.ID,
-		Details:   removedCouple,
-		CreatedAt: nowFn(),
-	})
+	// If dancer is in a couple or in the waitlist remove the couple
+	var removedCouple *models.Couple
+	if reg.Status == models.StatusInWaitlist {
+		removedCouple = h.removeFromWaitlist(reg.Dancer)
+		h.hist = append(h.hist, &models.HistoryItem{
+			Action:    models.HistoryWaitlistRemoved,
+			Initiator: initiator,
+			EventID:   &h.event.ID,
+			Details:   removedCouple,
+			CreatedAt: nowFn(),
+		})
+	} else {
+		removedCouple = h.removeCouple(reg.Dancer)
+		h.hist = append(h.hist, &models.HistoryItem{
+			Action:    models.HistoryCoupleRemoved,
+			Initiator: initiator,
+			EventID:   &h.event.ID,
+			Details:   removedCouple,
+			CreatedAt: nowFn(),
+		})
+		// Move the first couple from the waitlist to the free place
+		// before the partner will be returned to the singles list
+		h.waitlistPromote()
+	}
 
 	// Set dancer and partner status to not registered
 	reg.Status = models.StatusNotRegistered
@@ -318,14 +1665,19 @@ func (h *EventHandler) DancerRemove(d *models.Dancer) *models.Registration {
 
 	// If partner was signed up as a single, move back to singles (or auto pair if available)
 	if reg.Related.AsSingle {
-		reg.Related = h.singleRestore(reg.Related, reg.Dancer)
+		reg.Related = h.singleRestore(reg.Related, reg.Dancer, organizer)
 		return reg
 	}
 
-	// Otherwise, if couple was created by the partner send notification to the partner
-	if reg.Related.Profile != nil && removedCouple.CreatedBy.ID == reg.Related.Profile.ID {
+	// Otherwise, if couple was not created by the dancer or the dancer was removed by the organizer
+	// send notification to the partner
+	if reg.Related.Profile != nil && (organizer != nil || removedCouple.CreatedBy.ID != reg.Profile.ID) {
+		tmplCode := models.TmplCanceledByPartner
+		if organizer != nil {
+			tmplCode = models.TmplPartnerRemoved
+		}
 		h.notif = append(h.notif, &models.Notification{
-			TmplCode:  models.TmplCanceledByPartner,
+			TmplCode:  tmplCode,
 			Recipient: reg.Related.Profile,
 			Payload: models.NotificationPayload{
 				Event:   h.event,
@@ -346,53 +1698,78 @@ func (h *EventHandler) tryAutoPair(reg *models.Registration) *models.Registratio
 	if !h.event.Settings.AutoPairing {
 		return nil
 	}
-	reg.Related = h.firstSingle(reg.Dancer.Role.Opposite())
+	reg.Related = h.chooseSingle(reg.Dancer)
 	if reg.Related == nil {
 		return nil
 	}
 	reg.AsSingle = true
-	return h.coupleAdd(reg, true)
+	return h.coupleAdd(reg, true, nil, nil)
 }
 
 // singleRestore restores the dancer to the singles list.
 // If auto pairing is enabled, tries to auto pair the dancer.
-func (h *EventHandler) singleRestore(reg *models.Registration, ex *models.Dancer) *models.Registration {
+// If the organizer is not nil, the ex-partner was removed by the organizer.
+func (h *EventHandler) singleRestore(reg *models.Registration, ex *models.Dancer, organizer *models.Profile) *models.Registration {
+	initiator := ex.Profile
+	tmplAutoPair, tmplCanceled := models.TmplAutoPairPartnerChanged, models.TmplCanceledWithSingle
+	if organizer != nil {
+		initiator = organizer
+		tmplAutoPair, tmplCanceled = models.TmplPartnerRemovedAutoPair, models.TmplPartnerRemovedWithSingle
+	}
+
+	// The dancer registered for either role gets back the switch role
+	if reg.Dancer.Switch {
+		dancer := *reg.Dancer
+		dancer.Role = models.RoleSwitch
+		reg.Dancer = &dancer
+	}
+
 	// Try to auto pair the dancer
 	if autoPairReg := h.tryAutoPair(reg); autoPairReg != nil {
-		h.notif = append(h.notif, &models.Notification{
-			TmplCode:  models.TmplAutoPairPartnerChanged,
-			Recipient: autoPairReg.Profile,
-			Payload: models.NotificationPayload{
-				Event:      h.event,
-				Partner:    ex,
-				NewPartner: autoPairReg.Partner,
-			},
-		})
+		if autoPairReg.Profile != nil {
+			h.notif = append(h.notif, &models.Notification{
+				TmplCode:  tmplAutoPair,
+				Recipient: autoPairReg.Profile,
+				Payload: models.NotificationPayload{
+					Event:      h.event,
+					Partner:    ex,
+					NewPartner: autoPairReg.Partner,
+				},
+			})
+		}
 		return autoPairReg
 	}
 
-	// Otherwise, move back to singles
+	// Otherwise, move back to singles.
+	// The previous stale warning does not apply to the restored single.
 	reg.Status = models.StatusAsSingle
 	reg.Result = models.ResultRegisteredAsSingle
+	if reg.Dancer.WarnedAt != nil {
+		dancer := *reg.Dancer
+		dancer.WarnedAt = nil
+		reg.Dancer = &dancer
+	}
 	h.event.Singles = append(h.event.Singles, *reg.Dancer)
 
 	// Sort singles by creation time
 	sort.Sort(SinglesSorter(h.event.Singles))
 
 	// Send notification that the partner has canceled the registration
-	h.notif = append(h.notif, &models.Notification{
-		TmplCode:  models.TmplCanceledWithSingle,
-		Recipient: reg.Profile,
-		Payload: models.NotificationPayload{
-			Event:   h.event,
-			Partner: ex,
-		},
-	})
+	if reg.Profile != nil {
+		h.notif = append(h.notif, &models.Notification{
+			TmplCode:  tmplCanceled,
+			Recipient: reg.Profile,
+			Payload: models.NotificationPayload{
+				Event:   h.event,
+				Partner: ex,
+			},
+		})
+	}
 
 	// Add history item
 	h.hist = append(h.hist, &models.HistoryItem{
 		Action:    models.HistorySingleAdded,
-		Initiator: ex.Profile,
+		Initiator: initiator,
 		EventID:   &h.event.ID,
 		Details:   reg.Dancer,
 		CreatedAt: nowFn(),
@@ -401,20 +1778,71 @@ func (h *EventHandler) singleRestore(reg *models.Registration, ex *models.Dancer
 	return reg
 }
 
-// findInCouples finds dancers registration in the couples.
+// waitlistPromote moves couples from the waitlist to the couples list
+// while the limit allows and notifies the dancers of the promoted couples.
+func (h *EventHandler) waitlistPromote() {
+	for len(h.event.Waitlist) > 0 && !h.isFull() {
+		couple := h.event.Waitlist[0]
+		h.event.Waitlist = h.event.Waitlist[1:]
+		h.event.Couples = append(h.event.Couples, couple)
+		h.hist = append(h.hist, &models.HistoryItem{
+			Action:    models.HistoryWaitlistPromoted,
+			Initiator: config.BotProfile(),
+			EventID:   &h.event.ID,
+			Details:   &couple,
+			CreatedAt: nowFn(),
+		})
+		for i, dancer := range couple.Dancers {
+			if dancer.Profile == nil {
+				continue
+			}
+			h.notif = append(h.notif, &models.Notification{
+				TmplCode:  models.TmplWaitlistPromoted,
+				Recipient: dancer.Profile,
+				Payload: models.NotificationPayload{
+					Event:   h.event,
+					Partner: &couple.Dancers[1-i],
+				},
+			})
+		}
+	}
+}
+
+// signupClosed returns true if the registration deadline of the event has passed.
+func (h *EventHandler) signupClosed() bool {
+	deadline := h.event.SignupDeadlineAt()
+	return deadline != nil && !nowFn().Before(*deadline)
+}
+
+// cancelClosed returns true if the cancellation deadline of the event has passed.
+func (h *EventHandler) cancelClosed() bool {
+	deadline := h.event.CancelDeadlineAt()
+	return deadline != nil && !nowFn().Before(*deadline)
+}
+
+// isFull returns true if the couples limit of the event is reached.
+func (h *EventHandler) isFull() bool {
+	return h.event.Settings.Limit > 0 && len(h.event.Couples) >= h.event.Settings.Limit
+}
+
+// findInCouples finds dancers registration in the given list of couples.
 // Returns nil if not found.
-func (h *EventHandler) findInCouples(dancer *models.Dancer) *models.Registration {
+func (h *EventHandler) findInCouples(
+	dancer *models.Dancer,
+	couples []models.Couple,
+	status models.RegistrationStatus,
+) *models.Registration {
 	reg := &models.Registration{
-		Status: models.StatusInCouple,
+		Status: status,
 		Event:  h.event,
 	}
-	for _, couple := range h.event.Couples {
-		if h.isSame(dancer, &couple.Dancers[0]) {
+	for _, couple := range couples {
+		if isSame(dancer, &couple.Dancers[0]) {
 			reg.Dancer = &couple.Dancers[0]
 			reg.Partner = &couple.Dancers[1]
 			return reg
 		}
-		if h.isSame(dancer, &couple.Dancers[1]) {
+		if isSame(dancer, &couple.Dancers[1]) {
 			reg.Dancer = &couple.Dancers[1]
 			reg.Partner = &couple.Dancers[0]
 			return reg
@@ -423,6 +1851,39 @@ func (h *EventHandler) findInCouples(dancer *models.Dancer) *models.Registration
 	return nil
 }
 
+// findClaim finds the couple with the partner registered by name with the given claim token.
+// Returns the couple and the index of the partner in the couple or nil if not found.
+func (h *EventHandler) findClaim(token string) (*models.Couple, int) {
+	if token == "" {
+		return nil, -1
+	}
+	for _, couples := range [][]models.Couple{h.event.Couples, h.event.Waitlist} {
+		for i := range couples {
+			for j := range couples[i].Dancers {
+				if couples[i].Dancers[j].Profile == nil && couples[i].Dancers[j].ClaimToken == token {
+					return &couples[i], j
+				}
+			}
+		}
+	}
+	return nil, -1
+}
+
+// findCouple finds the couple of the dancer in the couples list or the waitlist.
+// Returns the couple and the index of the dancer in the couple or nil if not found.
+func (h *EventHandler) findCouple(dancer *models.Dancer) (*models.Couple, int) {
+	for _, couples := range [][]models.Couple{h.event.Couples, h.event.Waitlist} {
+		for i := range couples {
+			for j := range couples[i].Dancers {
+				if isSame(dancer, &couples[i].Dancers[j]) {
+					return &couples[i], j
+				}
+			}
+		}
+	}
+	return nil, -1
+}
+
 // findInSingles finds dancer in the singles of the event.
 // Returns nil if not found.
 func (h *EventHandler) findInSingles(dancer *models.Dancer) *models.Registration {
@@ -431,7 +1892,7 @@ func (h *EventHandler) findInSingles(dancer *models.Dancer) *models.Registration
 		Event:  h.event,
 	}
 	for _, single := range h.event.Singles {
-		if h.isSame(dancer, &single) {
+		if isSame(dancer, &single) {
 			reg.Dancer = &single
 			return reg
 		}
@@ -439,27 +1900,111 @@ func (h *EventHandler) findInSingles(dancer *models.Dancer) *models.Registration
 	return nil
 }
 
-// firstSingle returns registration of the first dancer with the given role in singles list.
-// Returns nil if no single dancer with this role found.
-func (h *EventHandler) firstSingle(role models.Role) *models.Registration {
-	reg := &models.Registration{
-		Status: models.StatusAsSingle,
-		Event:  h.event,
+// findPending returns the index of the couple waiting for the partner confirmation
+// created by the dancer. Returns -1 if not found.
+func (h *EventHandler) findPending(dancer *models.Dancer) int {
+	if dancer == nil || dancer.Profile == nil {
+		return -1
 	}
-	for _, single := range h.event.Singles {
-		if single.Role == role {
-			reg.Dancer = &single
-			return reg
+	for i, couple := range h.event.Pending {
+		if couple.CreatedBy.ID == dancer.Profile.ID {
+			return i
+		}
+	}
+	return -1
+}
+
+// findConsent returns the index of the couple waiting for the confirmation of the partner
+// created by the requester with the given profile ID. Returns -1 if not found.
+func (h *EventHandler) findConsent(partner *models.Dancer, requesterID int64) int {
+	for i := range h.event.Pending {
+		couple := &h.event.Pending[i]
+		if couple.CreatedBy.ID != requesterID {
+			continue
+		}
+		if _, invited := pendingDancers(couple); isSame(partner, invited) {
+			return i
+		}
+	}
+	return -1
+}
+
+// pendingDancers returns the requester and the invited partner of the couple
+// waiting for the partner confirmation.
+func pendingDancers(couple *models.Couple) (requester, invited *models.Dancer) {
+	d := &couple.Dancers[0]
+	if d.Profile != nil && d.Profile.ID == couple.CreatedBy.ID {
+		return d, &couple.Dancers[1]
+	}
+	return &couple.Dancers[1], d
+}
+
+// findInParticipants finds dancer in the participants of the solo event.
+// Returns nil if not found.
+func (h *EventHandler) findInParticipants(dancer *models.Dancer) *models.Registration {
+	for _, participant := range h.event.Participants {
+		if isSame(dancer, &participant) {
+			return &models.Registration{
+				Dancer: &participant,
+				Status: models.StatusParticipant,
+				Event:  h.event,
+			}
 		}
 	}
 	return nil
 }
 
+// resolveRole sets the requested role to the switch dancer from the singles list.
+func resolveRole(reg *models.Registration, requested models.Role) {
+	if reg.Role == models.RoleSwitch && requested != models.RoleSwitch {
+		reg.Dancer.Role = requested
+	}
+}
+
+// chooseSingle returns registration of the single with the matching role
+// chosen as a partner for the dancer by the pairing strategy of the event.
+// Only the dancers within the level range of the event are paired.
+// Returns nil if no single dancer fits.
+func (h *EventHandler) chooseSingle(dancer *models.Dancer) *models.Registration {
+	if !h.event.Settings.LevelAllowed(dancer.Level) {
+		return nil
+	}
+	var candidates []models.Dancer
+	for _, single := range h.event.Singles {
+		if dancer.Role.Pairs(single.Role) && !isSame(dancer, &single) && h.event.Settings.LevelAllowed(single.Level) {
+			candidates = append(candidates, single)
+		}
+	}
+	i := h.pairingStrategy().Choose(dancer, candidates)
+	if i < 0 || i >= len(candidates) {
+		return nil
+	}
+	return &models.Registration{
+		Dancer: &candidates[i],
+		Status: models.StatusAsSingle,
+		Event:  h.event,
+	}
+}
+
+// pairingStrategy returns the pairing strategy of the event.
+func (h *EventHandler) pairingStrategy() PairingStrategy {
+	switch h.event.Settings.Pairing {
+	case models.PairingRandom:
+		return RandomPairing{Rand: h.rnd}
+	case models.PairingRecent:
+		return RecentPartnersPairing{Recent: h.recent}
+	case models.PairingLevel:
+		return LevelPairing{}
+	default:
+		return FIFOPairing{}
+	}
+}
+
 // removeFromSingles removes the dancer from the singles list of the event.
 // If dancer found returns the dancer and true, otherwise nil and false.
 func (h *EventHandler) removeFromSingles(dancer *models.Dancer) (*models.Dancer, bool) {
 	for i, single := range h.event.Singles {
-		if h.isSame(dancer, &single) {
+		if isSame(dancer, &single) {
 			h.event.Singles = append(h.event.Singles[:i], h.event.Singles[i+1:]...)
 			return &single, true
 		}
@@ -467,11 +2012,21 @@ func (h *EventHandler) removeFromSingles(dancer *models.Dancer) (*models.Dancer,
 	return nil, false
 }
 
+// removeFromParticipants removes the dancer from the participants list of the solo event.
+func (h *EventHandler) removeFromParticipants(dancer *models.Dancer) {
+	for i, participant := range h.event.Participants {
+		if isSame(dancer, &participant) {
+			h.event.Participants = append(h.event.Participants[:i], h.event.Participants[i+1:]...)
+			return
+		}
+	}
+}
+
 // removeCouple removes the couple from the couples list of the event.
 // If dancer found returns removed couple, otherwise nil.
 func (h *EventHandler) removeCouple(dancer *models.Dancer) *models.Couple {
 	for i, couple := range h.event.Couples {
-		if h.isSame(dancer, &couple.Dancers[0]) || h.isSame(dancer, &couple.Dancers[1]) {
+		if isSame(dancer, &couple.Dancers[0]) || isSame(dancer, &couple.Dancers[1]) {
 			h.event.Couples = append(h.event.Couples[:i], h.event.Couples[i+1:]...)
 			return &couple
 		}
@@ -479,28 +2034,22 @@ func (h *EventHandler) removeCouple(dancer *models.Dancer) *models.Couple {
 	return nil
 }
 
-// isSame checks if dancer is the same as the other dancer on the event
-func (h *EventHandler) isSame(dancer, other *models.Dancer) bool {
-	switch {
-	// Compare profile IDs if both profiles are present
-	case dancer.Profile != nil && other.Profile != nil:
-		return dancer.ID == other.Profile.ID
-	// Compare dancer username (if present in profile ) and other username (if present in full name)
-	case dancer.Profile != nil && dancer.Profile.Username != "" && other.Profile == nil:
-		u, ok := getUsername(other.FullName)
-		return ok && (dancer.Profile.Username == u)
-	// Compare dancer username (if present in full name) and other username (if present in profile)
-	case dancer.Profile == nil && other.Profile != nil && other.Profile.Username != "":
-		u, ok := getUsername(dancer.FullName)
-		return ok && (u == other.Profile.Username)
-	// Compare usernames (if present in full names) if both profiles are missing
-	case dancer.Profile == nil && other.Profile == nil:
-		u1, ok1 := getUsername(dancer.FullName)
-		u2, ok2 := getUsername(other.FullName)
-		return (ok1 && ok2) && (u1 == u2)
-	default:
-		return false
+// removeFromWaitlist removes the couple from the waitlist of the event.
+// If dancer found returns removed couple, otherwise nil.
+func (h *EventHandler) removeFromWaitlist(dancer *models.Dancer) *models.Couple {
+	for i, couple := range h.event.Waitlist {
+		if isSame(dancer, &couple.Dancers[0]) || isSame(dancer, &couple.Dancers[1]) {
+			h.event.Waitlist = append(h.event.Waitlist[:i], h.event.Waitlist[i+1:]...)
+			return &couple
+		}
 	}
+	return nil
+}
+
+// isForbidden checks if the dancer is in the forbidden list of the event
+// or in the blocklist of the event owner.
+func (h *EventHandler) isForbidden(dancer *models.Dancer) bool {
+	return isListed(dancer, h.event.Forbidden) || isListed(dancer, h.blocklist)
 }
 
 // SinglesSorter is a sorter for singles by creation time.
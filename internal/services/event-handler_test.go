@@ -1,6 +1,7 @@
 package services
 
 import (
+	"slices"
 	"testing"
 	"time"
 
@@ -19,6 +20,339 @@ type TestEventHandlerSuite struct {
 	suite.Suite
 }
 
+func (suite *TestEventHandlerSuite) TestCanManage() {
+	suite.Run("owner", func() {
+		event := sampleEvent()
+		suite.True(NewEventHandler(&event).CanManage(&models.Profile{ID: 1000}))
+	})
+
+	suite.Run("not owner", func() {
+		event := sampleEvent()
+		suite.False(NewEventHandler(&event).CanManage(&models.Profile{ID: 1}))
+	})
+
+	suite.Run("co-organizer", func() {
+		event := sampleEvent()
+		event.CoOrganizers = []models.Profile{{ID: 2000}}
+		handler := NewEventHandler(&event)
+		suite.True(handler.CanManage(&models.Profile{ID: 2000}))
+		suite.False(handler.IsOwner(&models.Profile{ID: 2000}))
+	})
+
+	suite.Run("nil profile", func() {
+		event := sampleEvent()
+		suite.False(NewEventHandler(&event).CanManage(nil))
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestCoOrganizerAdd() {
+	suite.Run("add co-organizer", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+		profile := &models.Profile{ID: 2000, FirstName: "Mary"}
+
+		suite.True(handler.CoOrganizerAdd(profile))
+		suite.Equal([]models.Profile{*profile}, event.CoOrganizers)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistoryCoOrganizerAdded, handler.hist[0].Action)
+		suite.Require().Len(handler.notif, 1)
+		suite.Equal(models.TmplCoOrganizerAdded, handler.notif[0].TmplCode)
+		suite.Equal(event.Owner.ID, handler.notif[0].Recipient.ID)
+	})
+
+	suite.Run("already manages the event", func() {
+		event := sampleEvent()
+		event.CoOrganizers = []models.Profile{{ID: 2000}}
+		handler := NewEventHandler(&event)
+
+		suite.False(handler.CoOrganizerAdd(&models.Profile{ID: 2000}))
+		suite.False(handler.CoOrganizerAdd(&event.Owner))
+		suite.Len(event.CoOrganizers, 1)
+		suite.Empty(handler.hist)
+		suite.Empty(handler.notif)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestCoOrganizerRemove() {
+	suite.Run("remove co-organizer", func() {
+		event := sampleEvent()
+		event.CoOrganizers = []models.Profile{{ID: 2000}, {ID: 3000}}
+		handler := NewEventHandler(&event)
+
+		suite.True(handler.CoOrganizerRemove(2000, &event.Owner))
+		suite.Equal([]models.Profile{{ID: 3000}}, event.CoOrganizers)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistoryCoOrganizerRemoved, handler.hist[0].Action)
+		suite.False(handler.CanManage(&models.Profile{ID: 2000}))
+	})
+
+	suite.Run("not a co-organizer", func() {
+		event := sampleEvent()
+		event.CoOrganizers = []models.Profile{{ID: 2000}}
+		handler := NewEventHandler(&event)
+
+		suite.False(handler.CoOrganizerRemove(3000, &event.Owner))
+		suite.False(handler.CoOrganizerRemove(0, &event.Owner))
+		suite.Len(event.CoOrganizers, 1)
+		suite.Empty(handler.hist)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestCaptionSet() {
+	suite.Run("change caption", func() {
+		event := sampleEvent()
+		old := event.Caption
+		handler := NewEventHandler(&event)
+
+		suite.True(handler.CaptionSet("New caption", &event.Owner))
+		suite.Equal("New caption", event.Caption)
+		suite.Len(event.Couples, 2)
+		suite.Len(event.Singles, 2)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistoryCaptionChanged, handler.hist[0].Action)
+		suite.Equal(models.CaptionChange{Old: old, New: "New caption"}, handler.hist[0].Details)
+	})
+
+	suite.Run("same caption", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+
+		suite.False(handler.CaptionSet(event.Caption, &event.Owner))
+		suite.Empty(handler.hist)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestStartsAtSet() {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	suite.Require().NoError(err)
+	startsAt := time.Date(2025, 12, 31, 19, 30, 0, 0, berlin)
+
+	suite.Run("set start time", func() {
+		event := sampleEvent()
+		autoClosedAt := nowFn()
+		event.AutoClosedAt = &autoClosedAt
+		handler := NewEventHandler(&event)
+
+		suite.True(handler.StartsAtSet(&startsAt, &event.Owner))
+		suite.Equal(&startsAt, event.StartsAt)
+		suite.Equal("Europe/Berlin", event.Timezone)
+		suite.Nil(event.AutoClosedAt)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistoryStartsAtChanged, handler.hist[0].Action)
+		suite.Equal(&startsAt, handler.hist[0].Details)
+	})
+
+	suite.Run("same start time", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+		suite.True(handler.StartsAtSet(&startsAt, &event.Owner))
+		suite.False(handler.StartsAtSet(&startsAt, &event.Owner))
+		suite.Len(handler.hist, 1)
+	})
+
+	suite.Run("remove start time", func() {
+		event := sampleEvent()
+		event.StartsAt = &startsAt
+		event.Timezone = "Europe/Berlin"
+		handler := NewEventHandler(&event)
+
+		suite.True(handler.StartsAtSet(nil, &event.Owner))
+		suite.Nil(event.StartsAt)
+		suite.Empty(event.Timezone)
+		suite.False(handler.StartsAtSet(nil, &event.Owner))
+		suite.Len(handler.hist, 1)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestRecurrenceSet() {
+	suite.Run("set recurrence", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+
+		suite.True(handler.RecurrenceSet(models.RecurrenceWeekly, &event.Owner))
+		suite.Equal(models.RecurrenceWeekly, event.Recurrence)
+		suite.False(handler.RecurrenceSet(models.RecurrenceWeekly, &event.Owner))
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistoryRecurrenceChanged, handler.hist[0].Action)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestClone() {
+	suite.Run("clone without couples", func() {
+		event := sampleEvent()
+		startsAt := time.Date(2025, 12, 31, 19, 30, 0, 0, time.UTC)
+		event.StartsAt = &startsAt
+		event.Timezone = "UTC"
+		event.Settings = models.EventSettings{Limit: 10, ClosedFor: models.ClosedForAll, AutoPairing: true}
+		event.Forbidden = []models.Dancer{{FullName: "@baddancer"}}
+		event.CoOrganizers = []models.Profile{{ID: 2000}}
+		event.Couples[0].Repeat = true
+		handler := NewEventHandler(&event)
+
+		next := handler.Clone("next12345678", false, &event.Owner)
+		suite.Equal("next12345678", next.ID)
+		suite.Equal(event.Caption, next.Caption)
+		suite.Equal(models.EventSettings{Limit: 10, AutoPairing: true}, next.Settings)
+		suite.Equal(event.Owner, next.Owner)
+		suite.Equal(event.CoOrganizers, next.CoOrganizers)
+		suite.Equal(event.Forbidden, next.Forbidden)
+		suite.Nil(next.StartsAt)
+		suite.Nil(next.Post)
+		suite.Empty(next.Couples)
+		suite.Empty(next.Singles)
+		suite.Equal("next12345678", event.NextID)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistoryEventCloned, handler.hist[0].Action)
+	})
+
+	suite.Run("clone event with past start", func() {
+		event := sampleEvent()
+		startsAt := nowFn().Add(-24 * time.Hour)
+		event.StartsAt = &startsAt
+		handler := NewEventHandler(&event)
+
+		next := handler.Clone("next12345678", false, &event.Owner)
+		suite.Nil(next.StartsAt)
+		suite.Equal(&startsAt, event.StartsAt)
+	})
+
+	suite.Run("clone recurring event with couples", func() {
+		event := sampleEvent()
+		startsAt := time.Date(2025, 12, 31, 19, 30, 0, 0, time.UTC)
+		event.StartsAt = &startsAt
+		event.Recurrence = models.RecurrenceWeekly
+		event.Settings.Limit = 1
+		event.Couples[0].Repeat = true
+		event.Couples[1].Repeat = true
+		handler := NewEventHandler(&event)
+
+		next := handler.Clone("next12345678", true, config.BotProfile())
+		suite.Equal(startsAt.Add(7*24*time.Hour), *next.StartsAt)
+		suite.Equal(models.RecurrenceWeekly, next.Recurrence)
+		suite.Require().Len(next.Couples, 1)
+		suite.Equal(event.Couples[0].Dancers, next.Couples[0].Dancers)
+		suite.Require().Len(next.Waitlist, 1)
+		suite.Equal(event.Couples[1].Dancers, next.Waitlist[0].Dancers)
+		suite.Empty(next.Singles)
+
+		// the source event is not affected by the changes of the clone
+		next.Couples[0].Dancers[0].FullName = "Changed"
+		suite.Equal("John Doe", event.Couples[0].Dancers[0].FullName)
+	})
+
+	suite.Run("forbidden and blocked couples are not carried over", func() {
+		event := sampleEvent()
+		event.Couples[0].Repeat = true
+		event.Couples[1].Repeat = true
+		event.Forbidden = []models.Dancer{{Profile: &models.Profile{ID: 2}, FullName: "Jane Doe"}}
+		handler := NewEventHandler(&event).WithBlocklist([]models.Dancer{{FullName: "@jillsmith"}})
+
+		next := handler.Clone("next12345678", true, &event.Owner)
+		suite.Empty(next.Couples)
+		suite.Empty(next.Waitlist)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestCoupleRepeatSet() {
+	suite.Run("opt in and out", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+		jane := &models.Dancer{Profile: &models.Profile{ID: 2}}
+
+		suite.True(handler.CoupleRepeatSet(jane, true))
+		suite.True(event.Couples[0].Repeat)
+		suite.False(handler.CoupleRepeatSet(jane, true))
+		suite.True(handler.CoupleRepeatSet(&models.Dancer{Profile: &models.Profile{ID: 1}}, false))
+		suite.False(event.Couples[0].Repeat)
+		suite.Require().Len(handler.hist, 2)
+		suite.Equal(models.HistoryCoupleRepeatSet, handler.hist[0].Action)
+	})
+
+	suite.Run("not in couple", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+
+		suite.False(handler.CoupleRepeatSet(&models.Dancer{Profile: &models.Profile{ID: 4}}, true))
+		suite.False(handler.CoupleRepeatSet(&models.Dancer{Profile: &models.Profile{ID: 100}}, true))
+		suite.Empty(handler.hist)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestAutoClose() {
+	suite.Run("close event", func() {
+		config.SetBotProfile(botUser)
+		event := sampleEvent()
+		startsAt := nowFn().Add(time.Hour)
+		event.StartsAt = &startsAt
+		handler := NewEventHandler(&event)
+
+		suite.True(handler.AutoClose())
+		suite.Equal(models.ClosedForAll, event.Settings.ClosedFor)
+		suite.NotNil(event.AutoClosedAt)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistoryEventClosed, handler.hist[0].Action)
+		suite.Equal(config.BotProfile(), handler.hist[0].Initiator)
+
+		suite.False(handler.AutoClose())
+		suite.Len(handler.hist, 1)
+	})
+
+	suite.Run("already closed by the owner", func() {
+		event := sampleEvent()
+		startsAt := nowFn().Add(time.Hour)
+		event.StartsAt = &startsAt
+		event.Settings.ClosedFor = models.ClosedForAll
+		handler := NewEventHandler(&event)
+
+		suite.True(handler.AutoClose())
+		suite.NotNil(event.AutoClosedAt)
+		suite.Empty(handler.hist)
+	})
+
+	suite.Run("no start time", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+
+		suite.False(handler.AutoClose())
+		suite.Equal(models.ClosedForNone, event.Settings.ClosedFor)
+		suite.Nil(event.AutoClosedAt)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestClosedForSet() {
+	suite.Run("close event", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+
+		suite.True(handler.ClosedForSet(models.ClosedForAll, &event.Owner))
+		suite.Equal(models.ClosedForAll, event.Settings.ClosedFor)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistoryEventClosed, handler.hist[0].Action)
+		suite.Equal(&event.Owner, handler.hist[0].Initiator)
+		suite.Equal(event.Settings, handler.hist[0].Details)
+	})
+
+	suite.Run("reopen event", func() {
+		event := sampleEvent()
+		event.Settings.ClosedFor = models.ClosedForSingles
+		handler := NewEventHandler(&event)
+
+		suite.True(handler.ClosedForSet(models.ClosedForNone, &event.Owner))
+		suite.Equal(models.ClosedForNone, event.Settings.ClosedFor)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistoryEventReopened, handler.hist[0].Action)
+	})
+
+	suite.Run("same mode", func() {
+		event := sampleEvent()
+		event.Settings.ClosedFor = models.ClosedForSingleLeaders
+		handler := NewEventHandler(&event)
+
+		suite.False(handler.ClosedForSet(models.ClosedForSingleLeaders, &event.Owner))
+		suite.Len(handler.hist, 0)
+	})
+}
+
 func (suite *TestEventHandlerSuite) TestDancerRegistrationGet() {
 	suite.Run("dancer is not registered", func() {
 		event := sampleEvent()
@@ -728,100 +1062,336 @@ func (suite *TestEventHandlerSuite) TestSingleAdd_autoPair() {
 
 }
 
-func (suite *TestEventHandlerSuite) TestDancerRemove() {
-	suite.Run("dancer not registered", func() {
+func (suite *TestEventHandlerSuite) TestSingleAdd_pairingStrategies() {
+	newDancer := func() *models.Dancer {
+		return &models.Dancer{
+			Profile: &models.Profile{ID: 700, FirstName: "Bobby", LastName: "Fisher"},
+			Role:    models.RoleLeader,
+		}
+	}
+
+	suite.Run("first come first served", func() {
+		config.SetBotProfile(botUser)
 		event := sampleEvent()
+		event.Settings.AutoPairing = true
+		event.Settings.Pairing = models.PairingFIFO
 		handler := NewEventHandler(&event)
-		d := &models.Dancer{
-			Profile: &models.Profile{ID: 100, FirstName: "Test", LastName: "User"},
-		}
+		wantPartner := event.Singles[0]
 
-		got := handler.DancerRemove(d)
+		got := handler.SingleAdd(newDancer())
 
 		suite.Require().NotNil(got)
-		suite.Equal(models.ResultWasNotRegistered, got.Result)
-		suite.Equal(models.StatusNotRegistered, got.Status)
-		suite.Nil(got.Partner)
-		suite.Require().Len(handler.hist, 0)
-		suite.Require().Len(handler.notif, 0)
+		suite.Equal(models.ResultRegisteredInCouple, got.Result)
+		suite.Equal(&wantPartner, got.Partner)
 	})
 
-	suite.Run("dancer registered as single", func() {
+	suite.Run("random with seed", func() {
+		config.SetBotProfile(botUser)
 		event := sampleEvent()
-		handler := NewEventHandler(&event)
-		d := &models.Dancer{
-			Profile: &models.Profile{ID: 5, FirstName: "Amalia", LastName: "Green"},
-		}
+		event.Settings.AutoPairing = true
+		event.Settings.Pairing = models.PairingRandom
+		handler := NewEventHandler(&event).WithSeed(1)
+		wantPartner := event.Singles[1]
 
-		got := handler.DancerRemove(d)
+		got := handler.SingleAdd(newDancer())
 
 		suite.Require().NotNil(got)
-		suite.Equal(models.ResultRegistrationRemoved, got.Result)
-		suite.Equal(models.StatusNotRegistered, got.Status)
-		suite.Nil(got.Partner)
+		suite.Equal(models.ResultRegisteredInCouple, got.Result)
+		suite.Equal(&wantPartner, got.Partner)
 		suite.Require().Len(event.Singles, 1)
-		suite.Require().Len(handler.hist, 1)
-		suite.Equal(models.HistorySingleRemoved, handler.hist[0].Action)
-		suite.Equal(got.Profile, handler.hist[0].Initiator)
-		suite.Equal(got.Dancer, handler.hist[0].Details)
-		suite.Require().Len(handler.notif, 0)
+		suite.Equal(int64(4), event.Singles[0].Profile.ID)
 	})
 
-	suite.Run("dancer registered in couple, partner is from singles list", func() {
-		event := sampleEvent()
-		couple := event.Couples[0]
-		handler := NewEventHandler(&event)
-		d := &models.Dancer{
-			Profile: &models.Profile{ID: 1, FirstName: "John", LastName: "Doe"},
-			Role:    models.RoleLeader,
+	suite.Run("random with the same seed chooses the same partner", func() {
+		config.SetBotProfile(botUser)
+		var partners []int64
+		for range 3 {
+			event := sampleEvent()
+			event.Settings.AutoPairing = true
+			event.Settings.Pairing = models.PairingRandom
+			got := NewEventHandler(&event).WithSeed(42).SingleAdd(newDancer())
+			suite.Require().NotNil(got)
+			suite.Require().NotNil(got.Partner)
+			partners = append(partners, got.Partner.Profile.ID)
 		}
-		partner := handler.RegistrationGet(d).Partner
-		suite.Require().NotNil(partner)
+		suite.Equal(partners[0], partners[1])
+		suite.Equal(partners[0], partners[2])
+	})
 
-		got := handler.DancerRemove(d)
+	suite.Run("avoid recent partners", func() {
+		config.SetBotProfile(botUser)
+		event := sampleEvent()
+		event.Settings.AutoPairing = true
+		event.Settings.Pairing = models.PairingRecent
+		handler := NewEventHandler(&event).WithRecentPartners(map[int64][]int64{700: {4}})
+		wantPartner := event.Singles[1]
+
+		got := handler.SingleAdd(newDancer())
 
 		suite.Require().NotNil(got)
-		suite.Equal(models.ResultRegistrationRemoved, got.Result)
-		suite.Equal(models.StatusNotRegistered, got.Status)
-		suite.Nil(got.Partner)
-		suite.Require().NotNil(got.Related)
-		suite.Equal(partner, got.Related.Dancer)
-		suite.Equal(models.StatusAsSingle, got.Related.Status)
-		suite.Equal(models.ResultRegisteredAsSingle, got.Related.Result)
-		suite.Require().Len(event.Couples, 1)
-		suite.Require().Len(event.Singles, 3)
-		suite.Equal(partner, &event.Singles[1])
-		suite.Require().Len(handler.hist, 2)
-		suite.Equal(models.HistoryCoupleRemoved, handler.hist[0].Action)
-		suite.Equal(got.Profile, handler.hist[0].Initiator)
-		suite.Equal(&couple, handler.hist[0].Details)
-		suite.Equal(models.HistorySingleAdded, handler.hist[1].Action)
-		suite.Equal(got.Profile, handler.hist[1].Initiator)
-		suite.Equal(partner, handler.hist[1].Details)
-		suite.Require().Len(handler.notif, 1)
-		suite.Equal(got.Dancer, handler.notif[0].Payload.Partner)
-		suite.Equal(partner.Profile, handler.notif[0].Recipient)
-		suite.Equal(models.TmplCanceledWithSingle, handler.notif[0].TmplCode)
+		suite.Equal(models.ResultRegisteredInCouple, got.Result)
+		suite.Equal(&wantPartner, got.Partner)
 	})
 
-	suite.Run("dancer registered in couple, partner is the couple creator", func() {
+	suite.Run("avoid recent partners when all the singles are recent partners", func() {
+		config.SetBotProfile(botUser)
 		event := sampleEvent()
-		couple := event.Couples[0]
+		event.Settings.AutoPairing = true
+		event.Settings.Pairing = models.PairingRecent
+		handler := NewEventHandler(&event).WithRecentPartners(map[int64][]int64{700: {4, 5}})
+		wantPartner := event.Singles[0]
+
+		got := handler.SingleAdd(newDancer())
+
+		suite.Require().NotNil(got)
+		suite.Equal(models.ResultRegisteredInCouple, got.Result)
+		suite.Equal(&wantPartner, got.Partner)
+	})
+
+	suite.Run("avoid recent partners without recent partners", func() {
+		config.SetBotProfile(botUser)
+		event := sampleEvent()
+		event.Settings.AutoPairing = true
+		event.Settings.Pairing = models.PairingRecent
 		handler := NewEventHandler(&event)
-		d := &models.Dancer{
-			Profile: &models.Profile{ID: 2, FirstName: "Jane", LastName: "Doe"},
-			Role:    models.RoleFollower,
-		}
-		partner := handler.RegistrationGet(d).Partner
-		suite.Require().NotNil(partner)
+		wantPartner := event.Singles[0]
 
-		got := handler.DancerRemove(d)
+		got := handler.SingleAdd(newDancer())
 
 		suite.Require().NotNil(got)
-		suite.Equal(models.ResultRegistrationRemoved, got.Result)
-		suite.Equal(models.StatusNotRegistered, got.Status)
-		suite.Nil(got.Partner)
-		suite.Require().NotNil(got.Related)
+		suite.Equal(models.ResultRegisteredInCouple, got.Result)
+		suite.Equal(&wantPartner, got.Partner)
+	})
+
+	suite.Run("closest level", func() {
+		config.SetBotProfile(botUser)
+		event := sampleEvent()
+		event.Settings.AutoPairing = true
+		event.Settings.Pairing = models.PairingLevel
+		event.Singles[0].Level = models.LevelBeginner
+		event.Singles[1].Level = models.LevelAdvanced
+		handler := NewEventHandler(&event)
+		// both singles are equally close, the first one wins
+		wantPartner := event.Singles[0]
+		d := newDancer()
+		d.Level = models.LevelIntermediate
+
+		got := handler.SingleAdd(d)
+
+		suite.Require().NotNil(got)
+		suite.Equal(models.ResultRegisteredInCouple, got.Result)
+		suite.Equal(&wantPartner, got.Partner)
+	})
+
+	suite.Run("closest level with partner level preference", func() {
+		config.SetBotProfile(botUser)
+		event := sampleEvent()
+		event.Settings.AutoPairing = true
+		event.Settings.Pairing = models.PairingLevel
+		event.Singles[0].Level = models.LevelAdvanced
+		event.Singles[0].PartnerLevel = models.LevelAdvanced
+		event.Singles[1].Level = models.LevelBeginner
+		handler := NewEventHandler(&event)
+		wantPartner := event.Singles[1]
+		d := newDancer()
+		d.Level = models.LevelIntermediate
+
+		got := handler.SingleAdd(d)
+
+		suite.Require().NotNil(got)
+		suite.Equal(models.ResultRegisteredInCouple, got.Result)
+		suite.Equal(&wantPartner, got.Partner)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestLevelScore() {
+	beginner := &models.Dancer{Level: models.LevelBeginner}
+	advanced := &models.Dancer{Level: models.LevelAdvanced}
+	unknown := &models.Dancer{}
+	picky := &models.Dancer{Level: models.LevelBeginner, PartnerLevel: models.LevelBeginner}
+
+	suite.Equal(0, levelScore(beginner, beginner))
+	suite.Equal(2, levelScore(beginner, advanced))
+	suite.Equal(3, levelScore(beginner, unknown))
+	suite.Equal(0, levelScore(picky, beginner))
+	suite.Equal(6, levelScore(picky, advanced))
+}
+
+func (suite *TestEventHandlerSuite) TestSingleAdd_levels() {
+	suite.Run("dancer out of level range is not paired", func() {
+		event := sampleEvent()
+		event.Settings.AutoPairing = true
+		event.Settings.MinLevel = models.LevelIntermediate
+		handler := NewEventHandler(&event)
+		d := &models.Dancer{
+			Profile: &models.Profile{ID: 700, FirstName: "Bobby", LastName: "Fisher"},
+			Role:    models.RoleLeader,
+			Level:   models.LevelBeginner,
+		}
+
+		got := handler.SingleAdd(d)
+
+		suite.Require().NotNil(got)
+		suite.Equal(models.ResultRegisteredAsSingle, got.Result)
+		suite.Len(event.Singles, 3)
+		suite.Equal(models.LevelBeginner, event.Singles[2].Level)
+	})
+
+	suite.Run("singles out of level range are skipped", func() {
+		config.SetBotProfile(botUser)
+		event := sampleEvent()
+		event.Settings.AutoPairing = true
+		event.Settings.MinLevel = models.LevelIntermediate
+		event.Settings.MaxLevel = models.LevelAdvanced
+		event.Singles[1].Level = models.LevelAdvanced
+		handler := NewEventHandler(&event)
+		wantPartner := event.Singles[1]
+		d := &models.Dancer{
+			Profile: &models.Profile{ID: 700, FirstName: "Bobby", LastName: "Fisher"},
+			Role:    models.RoleLeader,
+			Level:   models.LevelIntermediate,
+		}
+
+		got := handler.SingleAdd(d)
+
+		suite.Require().NotNil(got)
+		suite.Equal(models.ResultRegisteredInCouple, got.Result)
+		suite.Equal(&wantPartner, got.Partner)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestLevelsSet() {
+	event := sampleEvent()
+	handler := NewEventHandler(&event)
+
+	suite.True(handler.LevelsSet(models.LevelBeginner, models.LevelIntermediate, &event.Owner))
+	suite.Equal(models.LevelBeginner, event.Settings.MinLevel)
+	suite.Equal(models.LevelIntermediate, event.Settings.MaxLevel)
+	suite.Require().Len(handler.hist, 1)
+	suite.Equal(models.HistoryLevelsChanged, handler.hist[0].Action)
+
+	suite.False(handler.LevelsSet(models.LevelBeginner, models.LevelIntermediate, &event.Owner))
+	suite.Len(handler.hist, 1)
+}
+
+func (suite *TestEventHandlerSuite) TestRecentPartners() {
+	couple := func(l, f *models.Profile) models.Couple {
+		return models.Couple{Dancers: []models.Dancer{
+			{Profile: l, Role: models.RoleLeader},
+			{Profile: f, Role: models.RoleFollower},
+		}}
+	}
+	john := &models.Profile{ID: 1, FirstName: "John"}
+	jane := &models.Profile{ID: 2, FirstName: "Jane"}
+	kate := &models.Profile{ID: 3, FirstName: "Kate"}
+	events := []*models.Event{
+		{Couples: []models.Couple{couple(john, jane), couple(nil, kate)}},
+		{Couples: []models.Couple{couple(john, kate)}},
+	}
+
+	got := recentPartners(events)
+
+	suite.Equal(map[int64][]int64{
+		1: {2, 3},
+		2: {1},
+		3: {1},
+	}, got)
+}
+
+func (suite *TestEventHandlerSuite) TestDancerRemove() {
+	suite.Run("dancer not registered", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+		d := &models.Dancer{
+			Profile: &models.Profile{ID: 100, FirstName: "Test", LastName: "User"},
+		}
+
+		got := handler.DancerRemove(d)
+
+		suite.Require().NotNil(got)
+		suite.Equal(models.ResultWasNotRegistered, got.Result)
+		suite.Equal(models.StatusNotRegistered, got.Status)
+		suite.Nil(got.Partner)
+		suite.Require().Len(handler.hist, 0)
+		suite.Require().Len(handler.notif, 0)
+	})
+
+	suite.Run("dancer registered as single", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+		d := &models.Dancer{
+			Profile: &models.Profile{ID: 5, FirstName: "Amalia", LastName: "Green"},
+		}
+
+		got := handler.DancerRemove(d)
+
+		suite.Require().NotNil(got)
+		suite.Equal(models.ResultRegistrationRemoved, got.Result)
+		suite.Equal(models.StatusNotRegistered, got.Status)
+		suite.Nil(got.Partner)
+		suite.Require().Len(event.Singles, 1)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistorySingleRemoved, handler.hist[0].Action)
+		suite.Equal(got.Profile, handler.hist[0].Initiator)
+		suite.Equal(got.Dancer, handler.hist[0].Details)
+		suite.Require().Len(handler.notif, 0)
+	})
+
+	suite.Run("dancer registered in couple, partner is from singles list", func() {
+		event := sampleEvent()
+		couple := event.Couples[0]
+		handler := NewEventHandler(&event)
+		d := &models.Dancer{
+			Profile: &models.Profile{ID: 1, FirstName: "John", LastName: "Doe"},
+			Role:    models.RoleLeader,
+		}
+		partner := handler.RegistrationGet(d).Partner
+		suite.Require().NotNil(partner)
+
+		got := handler.DancerRemove(d)
+
+		suite.Require().NotNil(got)
+		suite.Equal(models.ResultRegistrationRemoved, got.Result)
+		suite.Equal(models.StatusNotRegistered, got.Status)
+		suite.Nil(got.Partner)
+		suite.Require().NotNil(got.Related)
+		suite.Equal(partner, got.Related.Dancer)
+		suite.Equal(models.StatusAsSingle, got.Related.Status)
+		suite.Equal(models.ResultRegisteredAsSingle, got.Related.Result)
+		suite.Require().Len(event.Couples, 1)
+		suite.Require().Len(event.Singles, 3)
+		suite.Equal(partner, &event.Singles[1])
+		suite.Require().Len(handler.hist, 2)
+		suite.Equal(models.HistoryCoupleRemoved, handler.hist[0].Action)
+		suite.Equal(got.Profile, handler.hist[0].Initiator)
+		suite.Equal(&couple, handler.hist[0].Details)
+		suite.Equal(models.HistorySingleAdded, handler.hist[1].Action)
+		suite.Equal(got.Profile, handler.hist[1].Initiator)
+		suite.Equal(partner, handler.hist[1].Details)
+		suite.Require().Len(handler.notif, 1)
+		suite.Equal(got.Dancer, handler.notif[0].Payload.Partner)
+		suite.Equal(partner.Profile, handler.notif[0].Recipient)
+		suite.Equal(models.TmplCanceledWithSingle, handler.notif[0].TmplCode)
+	})
+
+	suite.Run("dancer registered in couple, partner is the couple creator", func() {
+		event := sampleEvent()
+		couple := event.Couples[0]
+		handler := NewEventHandler(&event)
+		d := &models.Dancer{
+			Profile: &models.Profile{ID: 2, FirstName: "Jane", LastName: "Doe"},
+			Role:    models.RoleFollower,
+		}
+		partner := handler.RegistrationGet(d).Partner
+		suite.Require().NotNil(partner)
+
+		got := handler.DancerRemove(d)
+
+		suite.Require().NotNil(got)
+		suite.Equal(models.ResultRegistrationRemoved, got.Result)
+		suite.Equal(models.StatusNotRegistered, got.Status)
+		suite.Nil(got.Partner)
+		suite.Require().NotNil(got.Related)
 		suite.Equal(partner, got.Related.Dancer)
 		suite.Equal(models.StatusNotRegistered, got.Related.Status)
 		suite.Equal(models.ResultRegistrationRemoved, got.Related.Result)
@@ -837,6 +1407,45 @@ func (suite *TestEventHandlerSuite) TestDancerRemove() {
 		suite.Equal(models.TmplCanceledByPartner, handler.notif[0].TmplCode)
 	})
 
+	suite.Run("dancer registered in couple, couple created by the organizer", func() {
+		event := sampleEvent()
+		event.Couples[0].Dancers[1].AsSingle = false
+		event.Couples[0].CreatedBy = event.Owner
+		dancer := event.Couples[0].Dancers[0]
+		partner := event.Couples[0].Dancers[1]
+		handler := NewEventHandler(&event)
+
+		got := handler.DancerRemove(&dancer)
+
+		suite.Require().NotNil(got)
+		suite.Equal(models.ResultRegistrationRemoved, got.Result)
+		suite.Require().NotNil(got.Related)
+		suite.Equal(models.StatusNotRegistered, got.Related.Status)
+		suite.Require().Len(event.Couples, 1)
+		suite.Require().Len(event.Singles, 2)
+		suite.Require().Len(handler.notif, 1)
+		suite.Equal(models.TmplCanceledByPartner, handler.notif[0].TmplCode)
+		suite.Equal(partner.Profile, handler.notif[0].Recipient)
+		suite.Equal(&dancer, handler.notif[0].Payload.Partner)
+	})
+
+	suite.Run("dancer registered in couple, couple created by the dancer", func() {
+		event := sampleEvent()
+		event.Couples[0].Dancers[1].AsSingle = false
+		dancer := event.Couples[0].Dancers[0]
+		handler := NewEventHandler(&event)
+
+		got := handler.DancerRemove(&dancer)
+
+		suite.Require().NotNil(got)
+		suite.Equal(models.ResultRegistrationRemoved, got.Result)
+		suite.Require().NotNil(got.Related)
+		suite.Equal(models.StatusNotRegistered, got.Related.Status)
+		suite.Require().Len(event.Couples, 1)
+		suite.Require().Len(event.Singles, 2)
+		suite.Require().Len(handler.notif, 0)
+	})
+
 	suite.Run("event is closed for all", func() {
 		event := sampleEvent()
 		event.Settings.ClosedFor = models.ClosedForAll
@@ -1025,6 +1634,1518 @@ func (suite *TestEventHandlerSuite) TestDancerRemove_autoPair() {
 	})
 }
 
+func (suite *TestEventHandlerSuite) TestWaitlist() {
+	suite.Run("couple added to waitlist when limit reached", func() {
+		event := sampleEvent()
+		event.Settings.Limit = 2
+		handler := NewEventHandler(&event)
+		d1 := &models.Dancer{
+			Profile: &models.Profile{ID: 600, FirstName: "Alice", LastName: "Wonder"},
+			Role:    models.RoleLeader,
+		}
+		d2 := &models.Dancer{
+			Profile: &models.Profile{ID: 700, FirstName: "Bob", LastName: "Builder"},
+			Role:    models.RoleFollower,
+		}
+
+		got := handler.CoupleAdd(d1, d2)
+
+		suite.Require().NotNil(got)
+		suite.Equal(models.ResultRegisteredInWaitlist, got.Result)
+		suite.Equal(models.StatusInWaitlist, got.Status)
+		suite.Equal(models.StatusInWaitlist, got.Related.Status)
+		suite.Len(event.Couples, 2)
+		suite.Require().Len(event.Waitlist, 1)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistoryWaitlistAdded, handler.hist[0].Action)
+		suite.Equal(&event.Waitlist[0], handler.hist[0].Details)
+
+		reg := handler.RegistrationGet(d2)
+		suite.Equal(models.StatusInWaitlist, reg.Status)
+		suite.Require().NotNil(reg.Partner)
+		suite.Equal(d1.Profile, reg.Partner.Profile)
+	})
+
+	suite.Run("dancer removed from waitlist", func() {
+		event := sampleEvent()
+		event.Settings.Limit = 2
+		handler := NewEventHandler(&event)
+		d1 := &models.Dancer{
+			Profile: &models.Profile{ID: 600, FirstName: "Alice", LastName: "Wonder"},
+			Role:    models.RoleLeader,
+		}
+		d2 := &models.Dancer{
+			Profile: &models.Profile{ID: 700, FirstName: "Bob", LastName: "Builder"},
+			Role:    models.RoleFollower,
+		}
+		handler.CoupleAdd(d1, d2)
+
+		got := handler.DancerRemove(d1)
+
+		suite.Require().NotNil(got)
+		suite.Equal(models.ResultRegistrationRemoved, got.Result)
+		suite.Len(event.Couples, 2)
+		suite.Len(event.Waitlist, 0)
+		suite.Require().Len(handler.hist, 2)
+		suite.Equal(models.HistoryWaitlistRemoved, handler.hist[1].Action)
+		suite.Equal(got.Profile, handler.hist[1].Initiator)
+	})
+
+	suite.Run("couple promoted from waitlist after removal", func() {
+		config.SetBotProfile(botUser)
+		event := sampleEvent()
+		event.Settings.Limit = 2
+		handler := NewEventHandler(&event)
+		d1 := &models.Dancer{
+			Profile: &models.Profile{ID: 600, FirstName: "Alice", LastName: "Wonder"},
+			Role:    models.RoleLeader,
+		}
+		d2 := &models.Dancer{
+			Profile: &models.Profile{ID: 700, FirstName: "Bob", LastName: "Builder"},
+			Role:    models.RoleFollower,
+		}
+		handler.CoupleAdd(d1, d2)
+
+		got := handler.DancerRemove(&models.Dancer{
+			Profile: &models.Profile{ID: 1, FirstName: "John", LastName: "Doe"},
+			Role:    models.RoleLeader,
+		})
+
+		suite.Require().NotNil(got)
+		suite.Equal(models.ResultRegistrationRemoved, got.Result)
+		suite.Len(event.Waitlist, 0)
+		suite.Require().Len(event.Couples, 2)
+		suite.Equal(int64(600), event.Couples[1].Dancers[0].Profile.ID)
+		suite.Require().Len(handler.hist, 4)
+		suite.Equal(models.HistoryCoupleRemoved, handler.hist[1].Action)
+		suite.Equal(models.HistoryWaitlistPromoted, handler.hist[2].Action)
+		suite.Equal(&botProfile, handler.hist[2].Initiator)
+		suite.Equal(models.HistorySingleAdded, handler.hist[3].Action)
+		suite.Require().Len(handler.notif, 3)
+		suite.Equal(models.TmplWaitlistPromoted, handler.notif[0].TmplCode)
+		suite.Equal(d1.Profile, handler.notif[0].Recipient)
+		suite.Equal(d2.Profile, handler.notif[0].Payload.Partner.Profile)
+		suite.Equal(models.TmplWaitlistPromoted, handler.notif[1].TmplCode)
+		suite.Equal(d2.Profile, handler.notif[1].Recipient)
+		suite.Equal(models.TmplCanceledWithSingle, handler.notif[2].TmplCode)
+	})
+
+	suite.Run("couples promoted after limit increased", func() {
+		config.SetBotProfile(botUser)
+		event := sampleEvent()
+		event.Settings.Limit = 1
+		event.Waitlist = event.Couples[1:]
+		event.Couples = event.Couples[:1]
+		handler := NewEventHandler(&event)
+
+		suite.True(handler.LimitSet(0, &event.Owner))
+
+		suite.Equal(0, event.Settings.Limit)
+		suite.Len(event.Couples, 2)
+		suite.Len(event.Waitlist, 0)
+		suite.Require().Len(handler.hist, 2)
+		suite.Equal(models.HistoryLimitChanged, handler.hist[0].Action)
+		suite.Equal(&event.Owner, handler.hist[0].Initiator)
+		suite.Equal(models.HistoryWaitlistPromoted, handler.hist[1].Action)
+		// only the dancer with a profile is notified
+		suite.Require().Len(handler.notif, 1)
+		suite.Equal(int64(3), handler.notif[0].Recipient.ID)
+		suite.Equal("@jillsmith", handler.notif[0].Payload.Partner.FullName)
+	})
+
+	suite.Run("same limit", func() {
+		event := sampleEvent()
+		event.Settings.Limit = 5
+		handler := NewEventHandler(&event)
+
+		suite.False(handler.LimitSet(5, &event.Owner))
+		suite.Len(handler.hist, 0)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestCoupleAddByOrganizer() {
+	suite.Run("dancers without profiles, event is closed", func() {
+		event := sampleEvent()
+		event.Settings.ClosedFor = models.ClosedForAll
+		handler := NewEventHandler(&event)
+
+		reg := handler.CoupleAddByOrganizer(
+			&models.Dancer{FullName: "Ivan Petrov", Role: models.RoleLeader},
+			&models.Dancer{FullName: "Maria Ivanova", Role: models.RoleFollower},
+			&event.Owner,
+		)
+
+		suite.Equal(models.ResultRegisteredInCouple, reg.Result)
+		suite.Require().Len(event.Couples, 3)
+		suite.Equal("Ivan Petrov", event.Couples[2].Dancers[0].FullName)
+		suite.Equal("Maria Ivanova", event.Couples[2].Dancers[1].FullName)
+		suite.Equal(event.Owner, event.Couples[2].CreatedBy)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistoryCoupleAdded, handler.hist[0].Action)
+		suite.Equal(&event.Owner, handler.hist[0].Initiator)
+		suite.Len(handler.notif, 0)
+	})
+
+	suite.Run("single moved into couple", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+		single := event.Singles[0]
+
+		reg := handler.CoupleAddByOrganizer(
+			&models.Dancer{FullName: "Ivan Petrov", Role: models.RoleLeader},
+			&single,
+			&event.Owner,
+		)
+
+		suite.Equal(models.ResultRegisteredInCouple, reg.Result)
+		suite.Require().Len(event.Singles, 1)
+		suite.Equal(int64(5), event.Singles[0].Profile.ID)
+		suite.Require().Len(event.Couples, 3)
+		suite.Equal(single.Profile, event.Couples[2].Dancers[1].Profile)
+		suite.Require().Len(handler.hist, 2)
+		suite.Equal(models.HistorySingleRemoved, handler.hist[0].Action)
+		suite.Equal(&event.Owner, handler.hist[0].Initiator)
+		suite.Equal(models.HistoryCoupleAdded, handler.hist[1].Action)
+		suite.Require().Len(handler.notif, 1)
+		suite.Equal(models.TmplCoupleAddedByOrganizer, handler.notif[0].TmplCode)
+		suite.Equal(single.Profile, handler.notif[0].Recipient)
+		suite.Equal("Ivan Petrov", handler.notif[0].Payload.Partner.FullName)
+	})
+
+	suite.Run("partner registered in another couple", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+
+		reg := handler.CoupleAddByOrganizer(
+			&models.Dancer{FullName: "Ivan Petrov", Role: models.RoleLeader},
+			&models.Dancer{FullName: "@jillsmith", Role: models.RoleFollower},
+			&event.Owner,
+		)
+
+		suite.Equal(models.ResultPartnerTaken, reg.Result)
+		suite.Len(event.Couples, 2)
+		suite.Len(handler.hist, 0)
+		suite.Len(handler.notif, 0)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestSingleAddByOrganizer() {
+	suite.Run("dancer without profile, event is closed for singles", func() {
+		event := sampleEvent()
+		event.Settings.ClosedFor = models.ClosedForSingles
+		handler := NewEventHandler(&event)
+
+		reg := handler.SingleAddByOrganizer(
+			&models.Dancer{FullName: "Ivan Petrov", Role: models.RoleLeader},
+			&event.Owner,
+		)
+
+		suite.Equal(models.ResultRegisteredAsSingle, reg.Result)
+		suite.Require().Len(event.Singles, 3)
+		suite.Equal("Ivan Petrov", event.Singles[2].FullName)
+		suite.True(event.Singles[2].AsSingle)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistorySingleAdded, handler.hist[0].Action)
+		suite.Equal(&event.Owner, handler.hist[0].Initiator)
+		suite.Len(handler.notif, 0)
+	})
+
+	suite.Run("dancer with profile", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+		profile := &models.Profile{ID: 30, FirstName: "Bob"}
+
+		reg := handler.SingleAddByOrganizer(
+			&models.Dancer{Profile: profile, FullName: "Bob", Role: models.RoleLeader},
+			&event.Owner,
+		)
+
+		suite.Equal(models.ResultRegisteredAsSingle, reg.Result)
+		suite.Require().Len(handler.notif, 1)
+		suite.Equal(models.TmplSingleAddedByOrganizer, handler.notif[0].TmplCode)
+		suite.Equal(profile, handler.notif[0].Recipient)
+	})
+
+	suite.Run("dancer already registered in couple", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+
+		reg := handler.SingleAddByOrganizer(
+			&models.Dancer{FullName: "@johndoe", Role: models.RoleLeader},
+			&event.Owner,
+		)
+
+		suite.Equal(models.ResultAlreadyInCouple, reg.Result)
+		suite.Len(event.Singles, 2)
+		suite.Len(handler.notif, 0)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestSolo() {
+	bob := &models.Profile{ID: 30, FirstName: "Bob"}
+
+	suite.Run("register, register again and remove", func() {
+		event := sampleEvent()
+		event.Couples, event.Singles = nil, nil
+		event.Settings.Solo = true
+		handler := NewEventHandler(&event)
+
+		reg := handler.ParticipantAdd(&models.Dancer{Profile: bob, FullName: "Bob", Role: models.RoleLeader})
+		suite.Equal(models.ResultRegisteredAsParticipant, reg.Result)
+		suite.Equal(models.StatusParticipant, reg.Status)
+		suite.Require().Len(event.Participants, 1)
+		suite.Equal(models.RoleLeader, event.Participants[0].Role)
+
+		reg = handler.ParticipantAdd(&models.Dancer{Profile: bob, FullName: "Bob"})
+		suite.Equal(models.ResultAlreadyParticipant, reg.Result)
+		suite.Len(event.Participants, 1)
+
+		reg = handler.DancerRemove(&models.Dancer{Profile: bob, FullName: "Bob"})
+		suite.Equal(models.ResultRegistrationRemoved, reg.Result)
+		suite.Len(event.Participants, 0)
+
+		suite.Require().Len(handler.hist, 2)
+		suite.Equal(models.HistoryParticipantAdded, handler.hist[0].Action)
+		suite.Equal(models.HistoryParticipantRemoved, handler.hist[1].Action)
+	})
+
+	suite.Run("single and couple registrations", func() {
+		event := sampleEvent()
+		event.Couples, event.Singles = nil, nil
+		event.Settings.Solo = true
+		handler := NewEventHandler(&event)
+
+		reg := handler.SingleAdd(&models.Dancer{Profile: bob, FullName: "Bob", Role: models.RoleFollower})
+		suite.Equal(models.ResultRegisteredAsParticipant, reg.Result)
+		suite.Len(event.Singles, 0)
+		suite.Len(event.Participants, 1)
+
+		reg = handler.CoupleAdd(
+			&models.Dancer{Profile: &models.Profile{ID: 31, FirstName: "Jack"}, FullName: "Jack", Role: models.RoleLeader},
+			&models.Dancer{FullName: "Jill", Role: models.RoleFollower},
+		)
+		suite.Equal(models.ResultEventClosed, reg.Result)
+		suite.Len(event.Couples, 0)
+	})
+
+	suite.Run("added by organizer to the closed event", func() {
+		event := sampleEvent()
+		event.Couples, event.Singles = nil, nil
+		event.Settings.Solo = true
+		event.Settings.ClosedFor = models.ClosedForAll
+		handler := NewEventHandler(&event)
+
+		reg := handler.ParticipantAdd(&models.Dancer{Profile: bob, FullName: "Bob"})
+		suite.Equal(models.ResultEventClosed, reg.Result)
+
+		reg = handler.SingleAddByOrganizer(&models.Dancer{Profile: bob, FullName: "Bob"}, &event.Owner)
+		suite.Equal(models.ResultRegisteredAsParticipant, reg.Result)
+		suite.Require().Len(handler.notif, 1)
+		suite.Equal(models.TmplParticipantAddedByOrganizer, handler.notif[0].TmplCode)
+	})
+
+	suite.Run("regular event", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+		suite.False(handler.IsEmpty())
+
+		reg := handler.ParticipantAdd(&models.Dancer{Profile: bob, FullName: "Bob"})
+		suite.Equal(models.ResultEventClosed, reg.Result)
+		suite.Len(event.Participants, 0)
+	})
+
+	suite.Run("solo set", func() {
+		event := sampleEvent()
+		event.Couples, event.Singles = nil, nil
+		event.Settings.Solo = true
+		handler := NewEventHandler(&event)
+		suite.True(handler.IsEmpty())
+
+		suite.False(handler.SoloSet(true, &event.Owner))
+		suite.True(handler.SoloSet(false, &event.Owner))
+		suite.False(event.Settings.Solo)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistorySoloChanged, handler.hist[0].Action)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestConsent() {
+	bob := &models.Profile{ID: 30, FirstName: "Bob"}
+	alice := &models.Profile{ID: 31, FirstName: "Alice"}
+
+	suite.Run("pending and accepted", func() {
+		event := sampleEvent()
+		event.Couples, event.Singles = nil, nil
+		event.Settings.Consent = true
+		handler := NewEventHandler(&event)
+
+		reg := handler.CoupleAdd(&models.Dancer{Profile: bob, FullName: "Bob", Role: models.RoleLeader}, &models.Dancer{Profile: alice, FullName: "Alice", Role: models.RoleFollower})
+		suite.Equal(models.ResultPendingConsent, reg.Result)
+		suite.Equal(models.StatusPending, reg.Status)
+		suite.Equal("Alice", reg.Partner.FullName)
+		suite.Len(event.Couples, 0)
+		suite.Require().Len(event.Pending, 1)
+		suite.Equal(bob.ID, event.Pending[0].CreatedBy.ID)
+		suite.Require().Len(handler.notif, 1)
+		suite.Equal(models.TmplConsentRequest, handler.notif[0].TmplCode)
+		suite.Equal(alice.ID, handler.notif[0].Recipient.ID)
+
+		reg = handler.RegistrationGet(&models.Dancer{Profile: bob, FullName: "Bob", Role: models.RoleLeader})
+		suite.Equal(models.StatusPending, reg.Status)
+
+		reg = handler.CoupleAdd(&models.Dancer{Profile: bob, FullName: "Bob", Role: models.RoleLeader}, &models.Dancer{Profile: &models.Profile{ID: 32}, FullName: "Jane"})
+		suite.Equal(models.ResultAlreadyPending, reg.Result)
+		suite.Len(event.Pending, 1)
+
+		reg = handler.PendingAccept(&models.Dancer{Profile: alice, FullName: "Alice"}, bob.ID)
+		suite.Equal(models.ResultRegisteredInCouple, reg.Result)
+		suite.Len(event.Pending, 0)
+		suite.Require().Len(event.Couples, 1)
+		suite.Equal("Bob", event.Couples[0].Dancers[0].FullName)
+		suite.Equal("Alice", event.Couples[0].Dancers[1].FullName)
+		suite.Equal(bob.ID, event.Couples[0].CreatedBy.ID)
+		suite.Equal(models.TmplConsentAccepted, handler.notif[len(handler.notif)-1].TmplCode)
+		suite.Equal(bob.ID, handler.notif[len(handler.notif)-1].Recipient.ID)
+
+		reg = handler.PendingAccept(&models.Dancer{Profile: alice, FullName: "Alice"}, bob.ID)
+		suite.Equal(models.ResultPendingNotFound, reg.Result)
+	})
+
+	suite.Run("accepted after the event is closed", func() {
+		event := sampleEvent()
+		event.Couples, event.Singles = nil, nil
+		event.Settings.Consent = true
+		handler := NewEventHandler(&event)
+
+		handler.CoupleAdd(&models.Dancer{Profile: bob, FullName: "Bob", Role: models.RoleLeader}, &models.Dancer{Profile: alice, FullName: "Alice", Role: models.RoleFollower})
+		event.Settings.ClosedFor = models.ClosedForAll
+		reg := handler.PendingAccept(&models.Dancer{Profile: alice, FullName: "Alice"}, bob.ID)
+		suite.Equal(models.ResultEventClosed, reg.Result)
+		suite.Len(event.Couples, 0)
+		suite.Require().Len(event.Pending, 1)
+		suite.Equal(bob.ID, event.Pending[0].CreatedBy.ID)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistoryPendingAdded, handler.hist[0].Action)
+		suite.Equal(models.TmplConsentFailed, handler.notif[len(handler.notif)-1].TmplCode)
+		suite.Equal(bob.ID, handler.notif[len(handler.notif)-1].Recipient.ID)
+
+		// the partner can accept again after the event is reopened
+		event.Settings.ClosedFor = models.ClosedForNone
+		reg = handler.PendingAccept(&models.Dancer{Profile: alice, FullName: "Alice"}, bob.ID)
+		suite.Equal(models.ResultRegisteredInCouple, reg.Result)
+		suite.Len(event.Pending, 0)
+	})
+
+	suite.Run("declined", func() {
+		event := sampleEvent()
+		event.Couples, event.Singles = nil, nil
+		event.Settings.Consent = true
+		handler := NewEventHandler(&event)
+
+		handler.CoupleAdd(&models.Dancer{Profile: bob, FullName: "Bob", Role: models.RoleLeader}, &models.Dancer{Profile: alice, FullName: "Alice", Role: models.RoleFollower})
+		suite.False(handler.PendingDecline(&models.Dancer{Profile: bob, FullName: "Bob"}, alice.ID))
+		suite.True(handler.PendingDecline(&models.Dancer{Profile: alice, FullName: "Alice"}, bob.ID))
+		suite.Len(event.Pending, 0)
+		suite.Len(event.Couples, 0)
+		suite.Equal(models.TmplConsentDeclined, handler.notif[len(handler.notif)-1].TmplCode)
+		suite.Equal(bob.ID, handler.notif[len(handler.notif)-1].Recipient.ID)
+	})
+
+	suite.Run("expired", func() {
+		event := sampleEvent()
+		event.Couples, event.Singles = nil, nil
+		event.Settings.Consent = true
+		handler := NewEventHandler(&event)
+
+		handler.CoupleAdd(&models.Dancer{Profile: bob, FullName: "Bob", Role: models.RoleLeader}, &models.Dancer{Profile: alice, FullName: "Alice", Role: models.RoleFollower})
+		suite.Equal(0, handler.PendingExpire(nowFn().Add(-time.Hour)))
+		suite.Equal(1, handler.PendingExpire(nowFn().Add(time.Hour)))
+		suite.Len(event.Pending, 0)
+		suite.Equal(models.TmplConsentExpired, handler.notif[len(handler.notif)-1].TmplCode)
+	})
+
+	suite.Run("cancelled by requester", func() {
+		event := sampleEvent()
+		event.Couples, event.Singles = nil, nil
+		event.Settings.Consent = true
+		handler := NewEventHandler(&event)
+
+		handler.CoupleAdd(&models.Dancer{Profile: bob, FullName: "Bob", Role: models.RoleLeader}, &models.Dancer{Profile: alice, FullName: "Alice", Role: models.RoleFollower})
+		reg := handler.DancerRemove(&models.Dancer{Profile: bob, FullName: "Bob", Role: models.RoleLeader})
+		suite.Equal(models.ResultRegistrationRemoved, reg.Result)
+		suite.Len(event.Pending, 0)
+	})
+
+	suite.Run("partner without profile and organizer", func() {
+		event := sampleEvent()
+		event.Couples, event.Singles = nil, nil
+		event.Settings.Consent = true
+		handler := NewEventHandler(&event)
+
+		reg := handler.CoupleAdd(&models.Dancer{Profile: bob, FullName: "Bob", Role: models.RoleLeader}, &models.Dancer{FullName: "Jane"})
+		suite.Equal(models.ResultRegisteredInCouple, reg.Result)
+
+		reg = handler.CoupleAddByOrganizer(
+			&models.Dancer{Profile: &models.Profile{ID: 40}, FullName: "Jack", Role: models.RoleLeader},
+			&models.Dancer{Profile: alice, FullName: "Alice", Role: models.RoleFollower}, &event.Owner)
+		suite.Equal(models.ResultRegisteredInCouple, reg.Result)
+		suite.Len(event.Pending, 0)
+		suite.Len(event.Couples, 2)
+	})
+
+	suite.Run("consent set", func() {
+		event := sampleEvent()
+		event.Couples, event.Singles = nil, nil
+		event.Settings.Consent = true
+		handler := NewEventHandler(&event)
+		suite.False(handler.ConsentSet(true, &event.Owner))
+		suite.True(handler.ConsentSet(false, &event.Owner))
+		suite.False(event.Settings.Consent)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistoryConsentChanged, handler.hist[0].Action)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestDancerClaim() {
+	alice := &models.Profile{ID: 31, FirstName: "Alice"}
+
+	suite.Run("claimed", func() {
+		event := sampleEvent()
+		event.Couples[1].Dancers[1].ClaimToken = "token"
+		handler := NewEventHandler(&event)
+
+		reg := handler.DancerClaim(&models.Dancer{Profile: alice, FullName: "Alice W."}, "token")
+		suite.Equal(models.ResultClaimed, reg.Result)
+		suite.Equal(models.StatusInCouple, reg.Status)
+		suite.Equal("Jack Smith", reg.Partner.FullName)
+		suite.Require().NotNil(event.Couples[1].Dancers[1].Profile)
+		suite.Equal(alice.ID, event.Couples[1].Dancers[1].Profile.ID)
+		suite.Equal("@jillsmith", event.Couples[1].Dancers[1].FullName)
+		suite.Empty(event.Couples[1].Dancers[1].ClaimToken)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistoryDancerClaimed, handler.hist[0].Action)
+
+		// the claimed partner can remove the registration
+		reg = handler.DancerRemove(&models.Dancer{Profile: alice, FullName: "Alice W."})
+		suite.Equal(models.ResultRegistrationRemoved, reg.Result)
+		suite.Len(event.Couples, 1)
+	})
+
+	suite.Run("invalid token", func() {
+		event := sampleEvent()
+		event.Couples[1].Dancers[1].ClaimToken = "token"
+		handler := NewEventHandler(&event)
+
+		reg := handler.DancerClaim(&models.Dancer{Profile: alice, FullName: "Alice"}, "other")
+		suite.Equal(models.ResultClaimNotFound, reg.Result)
+		reg = handler.DancerClaim(&models.Dancer{Profile: alice, FullName: "Alice"}, "")
+		suite.Equal(models.ResultClaimNotFound, reg.Result)
+		suite.Nil(event.Couples[1].Dancers[1].Profile)
+		suite.Len(handler.hist, 0)
+	})
+
+	suite.Run("already registered", func() {
+		event := sampleEvent()
+		event.Couples[1].Dancers[1].ClaimToken = "token"
+		handler := NewEventHandler(&event)
+
+		reg := handler.DancerClaim(&event.Couples[0].Dancers[0], "token")
+		suite.Equal(models.ResultAlreadyInCouple, reg.Result)
+		suite.Nil(event.Couples[1].Dancers[1].Profile)
+		suite.Equal("token", event.Couples[1].Dancers[1].ClaimToken)
+	})
+
+	suite.Run("forbidden", func() {
+		event := sampleEvent()
+		event.Couples[1].Dancers[1].ClaimToken = "token"
+		event.Forbidden = []models.Dancer{{Profile: alice, FullName: "Alice"}}
+		handler := NewEventHandler(&event)
+
+		reg := handler.DancerClaim(&models.Dancer{Profile: alice, FullName: "Alice"}, "token")
+		suite.Equal(models.ResultDancerForbidden, reg.Result)
+		suite.Nil(event.Couples[1].Dancers[1].Profile)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestPartnerReplace() {
+	john := &models.Profile{ID: 1, FirstName: "John", LastName: "Doe", Username: "johndoe"}
+	jane := &models.Profile{ID: 2, FirstName: "Jane", LastName: "Doe", Username: "janedoe"}
+	kate := &models.Profile{ID: 4, FirstName: "Kate", LastName: "Brown", Username: "katbrown"}
+
+	suite.Run("with single", func() {
+		event := sampleEvent()
+		createdAt := event.Couples[0].CreatedAt
+		handler := NewEventHandler(&event)
+
+		reg := handler.PartnerReplace(
+			&models.Dancer{Profile: john, FullName: "John Doe"},
+			&models.Dancer{Profile: kate, FullName: "Kate Brown"},
+		)
+		suite.Equal(models.ResultPartnerReplaced, reg.Result)
+		suite.Equal(models.StatusInCouple, reg.Status)
+		suite.Equal("Kate Brown", reg.Partner.FullName)
+		suite.Require().Len(event.Couples, 2)
+		suite.Equal("John Doe", event.Couples[0].Dancers[0].FullName)
+		suite.Equal("Kate Brown", event.Couples[0].Dancers[1].FullName)
+		suite.Equal(models.RoleFollower, event.Couples[0].Dancers[1].Role)
+		suite.Equal(createdAt, event.Couples[0].CreatedAt)
+
+		// the former partner is back in singles, the new one is taken from singles
+		suite.Require().Len(event.Singles, 2)
+		suite.Equal("Jane Doe", event.Singles[0].FullName)
+		suite.Equal("Amalia Green", event.Singles[1].FullName)
+
+		suite.Require().Len(handler.notif, 2)
+		suite.Equal(models.TmplRegisteredWithSingle, handler.notif[0].TmplCode)
+		suite.Equal(kate.ID, handler.notif[0].Recipient.ID)
+		suite.Equal(models.TmplCanceledWithSingle, handler.notif[1].TmplCode)
+		suite.Equal(jane.ID, handler.notif[1].Recipient.ID)
+	})
+
+	suite.Run("partner registered by the dancer", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+
+		reg := handler.PartnerReplace(
+			&models.Dancer{Profile: jane, FullName: "Jane Doe"},
+			&models.Dancer{FullName: "Bob"},
+		)
+		suite.Equal(models.ResultPartnerReplaced, reg.Result)
+		suite.Equal("Bob", event.Couples[0].Dancers[0].FullName)
+		suite.Equal(models.RoleLeader, event.Couples[0].Dancers[0].Role)
+		suite.Equal(jane.ID, event.Couples[0].CreatedBy.ID)
+		suite.Len(event.Singles, 2)
+
+		suite.Require().Len(handler.notif, 1)
+		suite.Equal(models.TmplPartnerReplaced, handler.notif[0].TmplCode)
+		suite.Equal(john.ID, handler.notif[0].Recipient.ID)
+		suite.Equal("Bob", handler.notif[0].Payload.NewPartner.FullName)
+	})
+
+	suite.Run("with consent", func() {
+		event := sampleEvent()
+		event.Settings.Consent = true
+		createdAt := event.Couples[0].CreatedAt
+		handler := NewEventHandler(&event)
+
+		reg := handler.PartnerReplace(
+			&models.Dancer{Profile: john, FullName: "John Doe"},
+			&models.Dancer{Profile: kate, FullName: "Kate Brown"},
+		)
+		suite.Equal(models.ResultPendingConsent, reg.Result)
+		suite.Equal(models.StatusInCouple, reg.Status)
+		suite.Equal("Kate Brown", reg.Partner.FullName)
+		suite.Equal("Jane Doe", event.Couples[0].Dancers[1].FullName)
+		suite.Require().Len(event.Pending, 1)
+		suite.True(event.Pending[0].Replace)
+		suite.Require().Len(handler.notif, 1)
+		suite.Equal(models.TmplConsentRequest, handler.notif[0].TmplCode)
+		suite.Equal(kate.ID, handler.notif[0].Recipient.ID)
+
+		reg = handler.PartnerReplace(
+			&models.Dancer{Profile: john, FullName: "John Doe"},
+			&models.Dancer{FullName: "Bob"},
+		)
+		suite.Equal(models.ResultAlreadyPending, reg.Result)
+
+		reg = handler.PendingAccept(&models.Dancer{Profile: kate, FullName: "Kate Brown"}, john.ID)
+		suite.Equal(models.ResultRegisteredInCouple, reg.Result)
+		suite.Equal("John Doe", reg.Partner.FullName)
+		suite.Empty(event.Pending)
+		suite.Require().Len(event.Couples, 2)
+		suite.Equal("John Doe", event.Couples[0].Dancers[0].FullName)
+		suite.Equal("Kate Brown", event.Couples[0].Dancers[1].FullName)
+		suite.Equal(createdAt, event.Couples[0].CreatedAt)
+		suite.Require().Len(event.Singles, 2)
+		suite.Equal("Jane Doe", event.Singles[0].FullName)
+
+		suite.Require().Len(handler.notif, 3)
+		suite.Equal(models.TmplCanceledWithSingle, handler.notif[1].TmplCode)
+		suite.Equal(jane.ID, handler.notif[1].Recipient.ID)
+		suite.Equal(models.TmplConsentAccepted, handler.notif[2].TmplCode)
+		suite.Equal(john.ID, handler.notif[2].Recipient.ID)
+	})
+
+	suite.Run("failed", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+
+		reg := handler.PartnerReplace(&models.Dancer{Profile: kate, FullName: "Kate Brown"}, &models.Dancer{FullName: "Bob"})
+		suite.Equal(models.ResultWasNotRegistered, reg.Result)
+
+		reg = handler.PartnerReplace(
+			&models.Dancer{Profile: john, FullName: "John Doe"},
+			&models.Dancer{FullName: "@jillsmith"},
+		)
+		suite.Equal(models.ResultPartnerTaken, reg.Result)
+
+		reg = handler.PartnerReplace(
+			&models.Dancer{Profile: john, FullName: "John Doe"},
+			&models.Dancer{Profile: &models.Profile{ID: 3, FirstName: "Jack", LastName: "Smith"}, FullName: "Jack Smith"},
+		)
+		suite.Equal(models.ResultPartnerSameRole, reg.Result)
+
+		reg = handler.PartnerReplace(
+			&models.Dancer{Profile: john, FullName: "John Doe"},
+			&models.Dancer{Profile: jane, FullName: "Jane Doe"},
+		)
+		suite.Equal(models.ResultAlreadyInSameCouple, reg.Result)
+
+		reg = handler.PartnerReplace(&models.Dancer{Profile: john, FullName: "John Doe"}, &models.Dancer{Profile: john})
+		suite.Equal(models.ResultSelfNotAllowed, reg.Result)
+
+		event.Settings.ClosedFor = models.ClosedForAll
+		reg = handler.PartnerReplace(
+			&models.Dancer{Profile: john, FullName: "John Doe"},
+			&models.Dancer{Profile: kate, FullName: "Kate Brown"},
+		)
+		suite.Equal(models.ResultEventClosed, reg.Result)
+
+		event.Settings.ClosedFor = models.ClosedForNone
+		startsAt := nowFn().Add(2 * time.Hour)
+		event.StartsAt = &startsAt
+		event.Settings.CancelDeadline = 3 * time.Hour
+		reg = handler.PartnerReplace(
+			&models.Dancer{Profile: john, FullName: "John Doe"},
+			&models.Dancer{Profile: kate, FullName: "Kate Brown"},
+		)
+		suite.Equal(models.ResultDeadlinePassed, reg.Result)
+
+		suite.Equal("Jane Doe", event.Couples[0].Dancers[1].FullName)
+		suite.Len(handler.hist, 0)
+		suite.Len(handler.notif, 0)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestRoleSwitch() {
+	john := &models.Profile{ID: 1, FirstName: "John", LastName: "Doe", Username: "johndoe"}
+
+	suite.Run("success", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+
+		reg := handler.RoleSwitch(&models.Dancer{Profile: john, FullName: "John Doe"})
+		suite.Equal(models.ResultRoleSwitched, reg.Result)
+		suite.Equal(models.RoleFollower, reg.Role)
+		suite.Equal(models.RoleLeader, reg.Partner.Role)
+		suite.Require().Len(event.Couples, 2)
+		suite.Equal("Jane Doe", event.Couples[0].Dancers[0].FullName)
+		suite.Equal(models.RoleLeader, event.Couples[0].Dancers[0].Role)
+		suite.Equal("John Doe", event.Couples[0].Dancers[1].FullName)
+		suite.Equal(models.RoleFollower, event.Couples[0].Dancers[1].Role)
+
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistoryRoleSwitched, handler.hist[0].Action)
+		suite.Require().Len(handler.notif, 1)
+		suite.Equal(models.TmplRoleSwitched, handler.notif[0].TmplCode)
+		suite.Equal(int64(2), handler.notif[0].Recipient.ID)
+	})
+
+	suite.Run("not in couple", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+
+		reg := handler.RoleSwitch(&models.Dancer{Profile: &models.Profile{ID: 4}, FullName: "Kate Brown"})
+		suite.Equal(models.ResultWasNotRegistered, reg.Result)
+		suite.Len(handler.hist, 0)
+	})
+
+	suite.Run("event closed", func() {
+		event := sampleEvent()
+		event.Settings.ClosedFor = models.ClosedForAll
+		handler := NewEventHandler(&event)
+
+		reg := handler.RoleSwitch(&models.Dancer{Profile: john, FullName: "John Doe"})
+		suite.Equal(models.ResultEventClosed, reg.Result)
+		suite.Equal("John Doe", event.Couples[0].Dancers[0].FullName)
+		suite.Len(handler.hist, 0)
+		suite.Len(handler.notif, 0)
+	})
+
+	suite.Run("deadline passed", func() {
+		for _, settings := range []models.EventSettings{
+			{SignupDeadline: 3 * time.Hour},
+			{CancelDeadline: 3 * time.Hour},
+		} {
+			event := sampleEvent()
+			startsAt := nowFn().Add(2 * time.Hour)
+			event.StartsAt = &startsAt
+			event.Settings = settings
+			handler := NewEventHandler(&event)
+
+			reg := handler.RoleSwitch(&models.Dancer{Profile: john, FullName: "John Doe"})
+			suite.Equal(models.ResultDeadlinePassed, reg.Result)
+			suite.Equal(models.RoleLeader, event.Couples[0].Dancers[0].Role)
+			suite.Len(handler.hist, 0)
+			suite.Len(handler.notif, 0)
+		}
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestDeadlines() {
+	bob := &models.Profile{ID: 30, FirstName: "Bob"}
+	john := &models.Profile{ID: 1, FirstName: "John", LastName: "Doe", Username: "johndoe"}
+
+	suite.Run("registration before the deadline", func() {
+		event := sampleEvent()
+		startsAt := nowFn().Add(2 * time.Hour)
+		event.StartsAt = &startsAt
+		event.Settings.SignupDeadline = time.Hour
+		handler := NewEventHandler(&event)
+
+		reg := handler.SingleAdd(&models.Dancer{Profile: bob, FullName: "Bob", Role: models.RoleLeader})
+		suite.Equal(models.ResultRegisteredAsSingle, reg.Result)
+	})
+
+	suite.Run("registration after the deadline", func() {
+		event := sampleEvent()
+		startsAt := nowFn().Add(2 * time.Hour)
+		event.StartsAt = &startsAt
+		event.Settings.SignupDeadline = 3 * time.Hour
+		event.Settings.AutoPairing = true
+		handler := NewEventHandler(&event)
+
+		reg := handler.SingleAdd(&models.Dancer{Profile: bob, FullName: "Bob", Role: models.RoleLeader})
+		suite.Equal(models.ResultDeadlinePassed, reg.Result)
+		reg = handler.CoupleAdd(
+			&models.Dancer{Profile: bob, FullName: "Bob", Role: models.RoleLeader},
+			&models.Dancer{FullName: "Alice", Role: models.RoleFollower},
+		)
+		suite.Equal(models.ResultDeadlinePassed, reg.Result)
+		suite.Len(event.Couples, 2)
+		suite.Len(event.Singles, 2)
+
+		// the organizer still can register dancers
+		reg = handler.SingleAddByOrganizer(&models.Dancer{Profile: bob, FullName: "Bob", Role: models.RoleLeader}, &event.Owner)
+		suite.Equal(models.ResultRegisteredInCouple, reg.Result)
+	})
+
+	suite.Run("late cancellation", func() {
+		event := sampleEvent()
+		startsAt := nowFn().Add(2 * time.Hour)
+		event.StartsAt = &startsAt
+		event.Settings.CancelDeadline = 3 * time.Hour
+		handler := NewEventHandler(&event)
+
+		reg := handler.DancerRemove(&models.Dancer{Profile: john, FullName: "John Doe"})
+		suite.Equal(models.ResultCancelClosed, reg.Result)
+		suite.Len(event.Couples, 2)
+
+		reg = handler.DancerRemoveByOrganizer(&models.Dancer{Profile: john, FullName: "John Doe"}, &event.Owner)
+		suite.Equal(models.ResultRegistrationRemoved, reg.Result)
+		suite.Len(event.Couples, 1)
+	})
+
+	suite.Run("no start time", func() {
+		event := sampleEvent()
+		event.Settings.SignupDeadline = time.Hour
+		event.Settings.CancelDeadline = time.Hour
+		handler := NewEventHandler(&event)
+		suite.Nil(event.SignupDeadlineAt())
+
+		reg := handler.DancerRemove(&models.Dancer{Profile: john, FullName: "John Doe"})
+		suite.Equal(models.ResultRegistrationRemoved, reg.Result)
+	})
+
+	suite.Run("deadlines set", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+		suite.True(handler.DeadlinesSet(time.Hour, 3*time.Hour, &event.Owner))
+		suite.False(handler.DeadlinesSet(time.Hour, 3*time.Hour, &event.Owner))
+		suite.Equal(time.Hour, event.Settings.SignupDeadline)
+		suite.Equal(3*time.Hour, event.Settings.CancelDeadline)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistoryDeadlinesChanged, handler.hist[0].Action)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestSinglesExpire() {
+	kate := &models.Dancer{Profile: &models.Profile{ID: 4}, FullName: "Kate Brown"}
+
+	suite.Run("warned and removed", func() {
+		event := sampleEvent()
+		event.Settings.SingleTTL = 3 * time.Minute
+		handler := NewEventHandler(&event)
+
+		// the first run only warns the stale single
+		suite.Equal(0, handler.SinglesExpire(time.Hour))
+		suite.Len(event.Singles, 2)
+		suite.NotNil(event.Singles[0].WarnedAt)
+		suite.Nil(event.Singles[1].WarnedAt)
+		suite.Require().Len(handler.Notifications(), 1)
+		suite.Equal(models.TmplSingleExpiring, handler.Notifications()[0].TmplCode)
+		suite.Equal(int64(4), handler.Notifications()[0].Recipient.ID)
+
+		// the grace period has not passed yet
+		suite.Equal(0, handler.SinglesExpire(time.Hour))
+		suite.Len(event.Singles, 2)
+		suite.Len(handler.Notifications(), 1)
+
+		// the grace period has passed
+		suite.Equal(1, handler.SinglesExpire(0))
+		suite.Require().Len(event.Singles, 1)
+		suite.Equal("Amalia Green", event.Singles[0].FullName)
+		suite.Require().Len(handler.Notifications(), 2)
+		suite.Equal(models.TmplSingleExpired, handler.Notifications()[1].TmplCode)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistorySingleRemoved, handler.hist[0].Action)
+		suite.Equal(config.BotProfile().ID, handler.hist[0].Initiator.ID)
+	})
+
+	suite.Run("refreshed", func() {
+		event := sampleEvent()
+		event.Settings.SingleTTL = 3 * time.Minute
+		handler := NewEventHandler(&event)
+
+		createdAt := event.Singles[0].CreatedAt
+
+		suite.Equal(0, handler.SinglesExpire(time.Hour))
+		suite.True(handler.SingleRefresh(kate))
+		suite.Equal("Kate Brown", event.Singles[0].FullName)
+		suite.Equal(createdAt, event.Singles[0].CreatedAt)
+		suite.NotNil(event.Singles[0].RefreshedAt)
+		suite.Nil(event.Singles[0].WarnedAt)
+		suite.Equal(0, handler.SinglesExpire(0))
+		suite.Len(event.Singles, 2)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistorySingleRefreshed, handler.hist[0].Action)
+	})
+
+	suite.Run("started or closed", func() {
+		event := sampleEvent()
+		event.Settings.SingleTTL = 3 * time.Minute
+		startsAt := nowFn().Add(-time.Hour)
+		event.StartsAt = &startsAt
+		handler := NewEventHandler(&event)
+		suite.Equal(0, handler.SinglesExpire(0))
+
+		event.StartsAt = nil
+		event.Settings.ClosedFor = models.ClosedForAll
+		suite.Equal(0, handler.SinglesExpire(0))
+		suite.Len(event.Singles, 2)
+		suite.Nil(event.Singles[0].WarnedAt)
+		suite.Empty(handler.Notifications())
+	})
+
+	suite.Run("not a single", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+		suite.False(handler.SingleRefresh(&models.Dancer{Profile: &models.Profile{ID: 30}, FullName: "Bob"}))
+	})
+
+	suite.Run("no ttl", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+		suite.Equal(0, handler.SinglesExpire(0))
+		suite.Empty(handler.Notifications())
+	})
+
+	suite.Run("ttl set", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+		suite.True(handler.SingleTTLSet(72*time.Hour, &event.Owner))
+		suite.False(handler.SingleTTLSet(72*time.Hour, &event.Owner))
+		suite.Equal(72*time.Hour, event.Settings.SingleTTL)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistorySingleTTLChanged, handler.hist[0].Action)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestSinglesAlert() {
+	bob := &models.Dancer{
+		Profile:   &models.Profile{ID: 6, FirstName: "Bob", LastName: "White"},
+		FullName:  "Bob White",
+		Role:      models.RoleLeader,
+		CreatedAt: nowFn(),
+	}
+
+	suite.Run("compatible singles notified", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+
+		reg := handler.SingleAdd(bob)
+		suite.Require().Equal(models.ResultRegisteredAsSingle, reg.Result)
+		suite.Equal(2, handler.SinglesAlert(reg.Dancer))
+		notif := handler.Notifications()
+		suite.Require().Len(notif, 2)
+		suite.Equal(models.TmplSingleJoined, notif[0].TmplCode)
+		suite.Equal(int64(4), notif[0].Recipient.ID)
+		suite.Equal("Bob White", notif[0].Payload.Partner.FullName)
+		suite.Equal(int64(5), notif[1].Recipient.ID)
+	})
+
+	suite.Run("same role not notified", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+
+		follower := &models.Dancer{Profile: &models.Profile{ID: 7}, FullName: "Jane", Role: models.RoleFollower}
+		suite.Equal(0, handler.SinglesAlert(follower))
+		suite.Empty(handler.Notifications())
+	})
+
+	suite.Run("auto pairing", func() {
+		event := sampleEvent()
+		event.Settings.AutoPairing = true
+		handler := NewEventHandler(&event)
+
+		suite.Equal(0, handler.SinglesAlert(bob))
+		suite.Empty(handler.Notifications())
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestOwnerActivity() {
+	bob := &models.Dancer{
+		Profile:   &models.Profile{ID: 6, FirstName: "Bob", LastName: "White"},
+		FullName:  "Bob White",
+		Role:      models.RoleLeader,
+		CreatedAt: nowFn(),
+	}
+
+	suite.Run("realtime", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event).WithOwnerAlerts(models.OwnerAlertsRealtime)
+
+		reg := handler.CoupleAdd(bob, &models.Dancer{FullName: "Alice", Role: models.RoleFollower})
+		suite.Require().Equal(models.ResultRegisteredInCouple, reg.Result)
+		handler.SingleAdd(&models.Dancer{Profile: &models.Profile{ID: 7}, FullName: "Jane", Role: models.RoleFollower})
+
+		n := handler.OwnerActivity()
+		suite.Require().NotNil(n)
+		suite.Equal(models.TmplOwnerActivity, n.TmplCode)
+		suite.Equal(event.Owner.ID, n.Recipient.ID)
+		suite.Require().Len(n.Payload.Activity, 2)
+		suite.Equal(models.HistoryCoupleAdded, n.Payload.Activity[0].Action)
+		suite.Equal("Bob White", n.Payload.Activity[0].Dancers[0].FullName)
+		suite.Equal("Alice", n.Payload.Activity[0].Dancers[1].FullName)
+		suite.Equal(models.HistorySingleAdded, n.Payload.Activity[1].Action)
+		suite.Equal("Jane", n.Payload.Activity[1].Dancers[0].FullName)
+	})
+
+	suite.Run("changes by the owner skipped", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event).WithOwnerAlerts(models.OwnerAlertsRealtime)
+
+		handler.SingleAddByOrganizer(bob, &event.Owner)
+		handler.LimitSet(10, &event.Owner)
+		suite.Nil(handler.OwnerActivity())
+	})
+
+	suite.Run("not subscribed", func() {
+		for _, alerts := range []models.OwnerAlerts{models.OwnerAlertsOff, models.OwnerAlertsDigest} {
+			event := sampleEvent()
+			handler := NewEventHandler(&event).WithOwnerAlerts(alerts)
+			handler.SingleAdd(bob)
+			suite.Nil(handler.OwnerActivity())
+		}
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestSinglesPair() {
+	leader := models.Dancer{
+		Profile:   &models.Profile{ID: 6, FirstName: "Bob", LastName: "White"},
+		FullName:  "Bob White",
+		Role:      models.RoleLeader,
+		AsSingle:  true,
+		CreatedAt: nowFn(),
+	}
+
+	suite.Run("singles paired", func() {
+		event := sampleEvent()
+		event.Singles = append(event.Singles, leader)
+		handler := NewEventHandler(&event)
+		follower := event.Singles[1]
+
+		reg := handler.SinglesPair(&leader, &follower, &event.Owner)
+
+		suite.Equal(models.ResultRegisteredInCouple, reg.Result)
+		suite.Require().Len(event.Singles, 1)
+		suite.Equal(int64(4), event.Singles[0].Profile.ID)
+		suite.Require().Len(event.Couples, 3)
+		suite.True(event.Couples[2].ByOrganizer)
+		suite.Equal(event.Owner, event.Couples[2].CreatedBy)
+		suite.Equal(leader.Profile, event.Couples[2].Dancers[0].Profile)
+		suite.Equal(follower.Profile, event.Couples[2].Dancers[1].Profile)
+		suite.Require().Len(handler.hist, 3)
+		suite.Equal(models.HistoryCoupleAdded, handler.hist[2].Action)
+		suite.Equal(&event.Owner, handler.hist[2].Initiator)
+		suite.Require().Len(handler.notif, 2)
+		suite.Equal(models.TmplPairedByOrganizer, handler.notif[0].TmplCode)
+		suite.Equal(leader.Profile, handler.notif[0].Recipient)
+		suite.Equal(follower.Profile, handler.notif[0].Payload.Partner.Profile)
+		suite.Equal(models.TmplPairedByOrganizer, handler.notif[1].TmplCode)
+		suite.Equal(follower.Profile, handler.notif[1].Recipient)
+		suite.Equal(leader.Profile, handler.notif[1].Payload.Partner.Profile)
+	})
+
+	suite.Run("singles with the same role", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+		d, p := event.Singles[0], event.Singles[1]
+
+		reg := handler.SinglesPair(&d, &p, &event.Owner)
+
+		suite.Equal(models.ResultPartnerSameRole, reg.Result)
+		suite.Len(event.Singles, 2)
+		suite.Len(handler.hist, 0)
+		suite.Len(handler.notif, 0)
+	})
+
+	suite.Run("dancer is not in the singles list", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+		follower := event.Singles[0]
+
+		reg := handler.SinglesPair(&event.Couples[0].Dancers[0], &follower, &event.Owner)
+
+		suite.Equal(models.ResultWasNotRegistered, reg.Result)
+		suite.Len(event.Singles, 2)
+		suite.Len(event.Couples, 2)
+		suite.Len(handler.notif, 0)
+	})
+
+	suite.Run("switch singles paired", func() {
+		event := sampleEvent()
+		event.Singles[0].Role = models.RoleSwitch
+		event.Singles[1].Role = models.RoleSwitch
+		handler := NewEventHandler(&event)
+		d, p := event.Singles[1], event.Singles[0]
+
+		reg := handler.SinglesPair(&d, &p, &event.Owner)
+
+		suite.Equal(models.ResultRegisteredInCouple, reg.Result)
+		suite.Len(event.Singles, 0)
+		suite.Require().Len(event.Couples, 3)
+		suite.Equal(int64(5), event.Couples[2].Dancers[0].Profile.ID)
+		suite.Equal(models.RoleLeader, event.Couples[2].Dancers[0].Role)
+		suite.Equal(int64(4), event.Couples[2].Dancers[1].Profile.ID)
+		suite.Equal(models.RoleFollower, event.Couples[2].Dancers[1].Role)
+	})
+
+	suite.Run("switch single paired with itself", func() {
+		event := sampleEvent()
+		event.Singles[0].Role = models.RoleSwitch
+		handler := NewEventHandler(&event)
+		d := event.Singles[0]
+
+		reg := handler.SinglesPair(&d, &d, &event.Owner)
+
+		suite.Equal(models.ResultSelfNotAllowed, reg.Result)
+		suite.Len(event.Singles, 2)
+		suite.Len(handler.hist, 0)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestSwitchDancer() {
+	newSwitch := func() *models.Dancer {
+		return &models.Dancer{
+			Profile: &models.Profile{ID: 700, FirstName: "Alex", LastName: "Fisher"},
+			Role:    models.RoleSwitch,
+		}
+	}
+
+	suite.Run("signed up as single", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+
+		got := handler.SingleAdd(newSwitch())
+
+		suite.Equal(models.ResultRegisteredAsSingle, got.Result)
+		suite.Require().Len(event.Singles, 3)
+		suite.Equal(models.RoleSwitch, event.Singles[2].Role)
+		suite.True(event.Singles[2].Switch)
+	})
+
+	suite.Run("closed for single leaders", func() {
+		event := sampleEvent()
+		event.Settings.ClosedFor = models.ClosedForSingleLeaders
+		handler := NewEventHandler(&event)
+
+		got := handler.SingleAdd(newSwitch())
+
+		suite.Equal(models.ResultRegisteredAsSingle, got.Result)
+		suite.Require().Len(event.Singles, 3)
+		suite.Equal(models.RoleFollower, event.Singles[2].Role)
+		suite.False(event.Singles[2].Switch)
+	})
+
+	suite.Run("closed for single followers", func() {
+		event := sampleEvent()
+		event.Settings.ClosedFor = models.ClosedForSingleFollowers
+		handler := NewEventHandler(&event)
+
+		got := handler.SingleAdd(newSwitch())
+
+		suite.Equal(models.ResultRegisteredAsSingle, got.Result)
+		suite.Require().Len(event.Singles, 3)
+		suite.Equal(models.RoleLeader, event.Singles[2].Role)
+		suite.False(event.Singles[2].Switch)
+	})
+
+	suite.Run("auto paired as leader", func() {
+		config.SetBotProfile(botUser)
+		event := sampleEvent()
+		event.Settings.AutoPairing = true
+		handler := NewEventHandler(&event)
+
+		got := handler.SingleAdd(newSwitch())
+
+		suite.Equal(models.ResultRegisteredInCouple, got.Result)
+		suite.Require().Len(event.Couples, 3)
+		couple := event.Couples[2]
+		suite.Equal(int64(700), couple.Dancers[0].Profile.ID)
+		suite.Equal(models.RoleLeader, couple.Dancers[0].Role)
+		suite.True(couple.Dancers[0].Switch)
+		suite.Equal(int64(4), couple.Dancers[1].Profile.ID)
+	})
+
+	suite.Run("auto paired with leader", func() {
+		config.SetBotProfile(botUser)
+		event := sampleEvent()
+		event.Settings.AutoPairing = true
+		event.Singles = []models.Dancer{*newSwitch()}
+		event.Singles[0].AsSingle = true
+		event.Singles[0].Switch = true
+		handler := NewEventHandler(&event)
+
+		got := handler.SingleAdd(&models.Dancer{
+			Profile: &models.Profile{ID: 800, FirstName: "Bobby"},
+			Role:    models.RoleLeader,
+		})
+
+		suite.Equal(models.ResultRegisteredInCouple, got.Result)
+		suite.Len(event.Singles, 0)
+		suite.Require().Len(event.Couples, 3)
+		couple := event.Couples[2]
+		suite.Equal(int64(800), couple.Dancers[0].Profile.ID)
+		suite.Equal(int64(700), couple.Dancers[1].Profile.ID)
+		suite.Equal(models.RoleFollower, couple.Dancers[1].Role)
+	})
+
+	suite.Run("chosen as partner with the requested role", func() {
+		event := sampleEvent()
+		event.Singles = []models.Dancer{*newSwitch()}
+		event.Singles[0].AsSingle = true
+		event.Singles[0].Switch = true
+		handler := NewEventHandler(&event)
+
+		got := handler.CoupleAdd(&models.Dancer{
+			Profile: &models.Profile{ID: 800, FirstName: "Mary"},
+			Role:    models.RoleFollower,
+		}, &models.Dancer{
+			Profile: &models.Profile{ID: 700},
+			Role:    models.RoleLeader,
+		})
+
+		suite.Equal(models.ResultRegisteredInCouple, got.Result)
+		suite.Len(event.Singles, 0)
+		suite.Require().Len(event.Couples, 3)
+		couple := event.Couples[2]
+		suite.Equal(int64(700), couple.Dancers[0].Profile.ID)
+		suite.Equal(models.RoleLeader, couple.Dancers[0].Role)
+		suite.Equal(int64(800), couple.Dancers[1].Profile.ID)
+	})
+
+	suite.Run("name-only switch single paired by organizer", func() {
+		event := sampleEvent()
+		event.Singles = []models.Dancer{{
+			FullName:  "Alex Fisher",
+			Role:      models.RoleSwitch,
+			AsSingle:  true,
+			Switch:    true,
+			CreatedAt: nowFn().Add(-time.Minute),
+		}}
+		handler := NewEventHandler(&event)
+		single := event.Singles[0]
+
+		got := handler.CoupleAddByOrganizer(&models.Dancer{
+			FullName:  "Mary Smith",
+			Role:      models.RoleLeader,
+			CreatedAt: nowFn(),
+		}, &single, &event.Owner)
+
+		suite.Equal(models.ResultRegisteredInCouple, got.Result)
+		suite.Len(event.Singles, 0)
+		suite.Require().Len(event.Couples, 3)
+		couple := event.Couples[2]
+		suite.Equal("Alex Fisher", couple.Dancers[1].FullName)
+		suite.Equal(models.RoleFollower, couple.Dancers[1].Role)
+	})
+
+	suite.Run("restored as switch single", func() {
+		event := sampleEvent()
+		event.Couples[0].Dancers[1].Switch = true
+		handler := NewEventHandler(&event)
+
+		got := handler.DancerRemove(&models.Dancer{Profile: &models.Profile{ID: 1}})
+
+		suite.Equal(models.ResultRegistrationRemoved, got.Result)
+		suite.Require().Len(event.Singles, 3)
+		i := slices.IndexFunc(event.Singles, func(d models.Dancer) bool { return d.Profile.ID == 2 })
+		suite.Require().GreaterOrEqual(i, 0)
+		suite.Equal(models.RoleSwitch, event.Singles[i].Role)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestDancerRemoveByOrganizer() {
+	suite.Run("dancer without profile and username", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+		handler.SingleAddByOrganizer(&models.Dancer{FullName: "Ivan Petrov", Role: models.RoleLeader}, &event.Owner)
+		suite.Require().Len(event.Singles, 3)
+
+		// the dancer with the same name is not the same dancer
+		reg := handler.DancerRemoveByOrganizer(&models.Dancer{FullName: "Ivan Petrov"}, &event.Owner)
+		suite.Equal(models.ResultWasNotRegistered, reg.Result)
+
+		single := event.Singles[2]
+		reg = handler.DancerRemoveByOrganizer(&single, &event.Owner)
+		suite.Equal(models.ResultRegistrationRemoved, reg.Result)
+		suite.Len(event.Singles, 2)
+		suite.Require().Len(handler.hist, 2)
+		suite.Equal(models.HistorySingleRemoved, handler.hist[1].Action)
+		suite.Equal(&event.Owner, handler.hist[1].Initiator)
+		suite.Len(handler.notif, 0)
+	})
+
+	suite.Run("dancer registered in couple, event is closed", func() {
+		event := sampleEvent()
+		event.Settings.ClosedFor = models.ClosedForAll
+		handler := NewEventHandler(&event)
+		dancer := event.Couples[0].Dancers[0]
+		partner := event.Couples[0].Dancers[1]
+
+		reg := handler.DancerRemoveByOrganizer(&dancer, &event.Owner)
+
+		suite.Equal(models.ResultRegistrationRemoved, reg.Result)
+		suite.Len(event.Couples, 1)
+		suite.Require().Len(event.Singles, 3)
+		suite.Equal(partner.Profile, event.Singles[1].Profile)
+		suite.Require().Len(handler.notif, 2)
+		suite.Equal(models.TmplPartnerRemovedWithSingle, handler.notif[0].TmplCode)
+		suite.Equal(partner.Profile, handler.notif[0].Recipient)
+		suite.Equal(models.TmplRemovedByOrganizer, handler.notif[1].TmplCode)
+		suite.Equal(dancer.Profile, handler.notif[1].Recipient)
+	})
+
+	suite.Run("dancer not registered", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+
+		reg := handler.DancerRemoveByOrganizer(&models.Dancer{FullName: "@alicew"}, &event.Owner)
+
+		suite.Equal(models.ResultWasNotRegistered, reg.Result)
+		suite.Len(handler.hist, 0)
+		suite.Len(handler.notif, 0)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestDancerForbid() {
+	suite.Run("forbidden dancer registration", func() {
+		event := sampleEvent()
+		event.Forbidden = []models.Dancer{{FullName: "@alicew"}}
+		d := &models.Dancer{
+			Profile: &models.Profile{ID: 600, FirstName: "Alice", Username: "alicew"},
+			Role:    models.RoleFollower,
+		}
+
+		got := NewEventHandler(&event).RegistrationGet(d)
+
+		suite.Require().NotNil(got)
+		suite.Equal(models.StatusForbidden, got.Status)
+		suite.Equal(d, got.Dancer)
+	})
+
+	suite.Run("forbidden dancer can not signup as single", func() {
+		event := sampleEvent()
+		event.Forbidden = []models.Dancer{{Profile: &models.Profile{ID: 600}, FullName: "Alice"}}
+		handler := NewEventHandler(&event)
+
+		got := handler.SingleAdd(&models.Dancer{
+			Profile: &models.Profile{ID: 600, FirstName: "Alice"},
+			Role:    models.RoleFollower,
+		})
+
+		suite.Equal(models.ResultDancerForbidden, got.Result)
+		suite.Len(event.Singles, 2)
+		suite.Len(handler.hist, 0)
+	})
+
+	suite.Run("dancer not registered", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+
+		suite.True(handler.DancerForbid(&models.Dancer{FullName: "@alicew"}, &event.Owner))
+
+		suite.Require().Len(event.Forbidden, 1)
+		suite.Equal("@alicew", event.Forbidden[0].FullName)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistoryDancerForbidden, handler.hist[0].Action)
+		suite.Equal(&event.Owner, handler.hist[0].Initiator)
+		suite.Equal(&event.Forbidden[0], handler.hist[0].Details)
+		suite.Len(handler.notif, 0)
+	})
+
+	suite.Run("dancer registered as single", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+		single := event.Singles[0]
+
+		suite.True(handler.DancerForbid(&models.Dancer{FullName: "@katbrown"}, &event.Owner))
+
+		suite.Require().Len(event.Singles, 1)
+		suite.Equal(int64(5), event.Singles[0].Profile.ID)
+		suite.Require().Len(event.Forbidden, 1)
+		suite.Equal(single.Profile, event.Forbidden[0].Profile)
+		suite.Require().Len(handler.hist, 2)
+		suite.Equal(models.HistorySingleRemoved, handler.hist[0].Action)
+		suite.Equal(&event.Owner, handler.hist[0].Initiator)
+		suite.Equal(models.HistoryDancerForbidden, handler.hist[1].Action)
+		suite.Require().Len(handler.notif, 1)
+		suite.Equal(models.TmplDancerForbidden, handler.notif[0].TmplCode)
+		suite.Equal(single.Profile, handler.notif[0].Recipient)
+	})
+
+	suite.Run("dancer registered in couple, partner is from singles list", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+		partner := event.Couples[0].Dancers[1]
+
+		suite.True(handler.DancerForbid(&models.Dancer{
+			Profile: &models.Profile{ID: 1, FirstName: "John", LastName: "Doe"},
+		}, &event.Owner))
+
+		suite.Len(event.Couples, 1)
+		suite.Require().Len(event.Singles, 3)
+		suite.Equal(partner.Profile, event.Singles[1].Profile)
+		suite.Require().Len(handler.hist, 3)
+		suite.Equal(models.HistoryCoupleRemoved, handler.hist[0].Action)
+		suite.Equal(&event.Owner, handler.hist[0].Initiator)
+		suite.Equal(models.HistorySingleAdded, handler.hist[1].Action)
+		suite.Equal(&event.Owner, handler.hist[1].Initiator)
+		suite.Equal(models.HistoryDancerForbidden, handler.hist[2].Action)
+		suite.Require().Len(handler.notif, 2)
+		suite.Equal(models.TmplPartnerRemovedWithSingle, handler.notif[0].TmplCode)
+		suite.Equal(partner.Profile, handler.notif[0].Recipient)
+		suite.Equal(models.TmplDancerForbidden, handler.notif[1].TmplCode)
+		suite.Equal(int64(1), handler.notif[1].Recipient.ID)
+	})
+
+	suite.Run("dancer registered in couple by username", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+
+		suite.True(handler.DancerForbid(&models.Dancer{
+			Profile: &models.Profile{ID: 20, FirstName: "Jill", Username: "jillsmith"},
+		}, &event.Owner))
+
+		// partner signed up as a single and moved back to the singles list
+		suite.Len(event.Couples, 1)
+		suite.Len(event.Singles, 3)
+		suite.Require().Len(event.Forbidden, 1)
+		suite.Equal("@jillsmith", event.Forbidden[0].FullName)
+		// the dancer was registered without profile and can not be notified
+		suite.Require().Len(handler.notif, 1)
+		suite.Equal(models.TmplPartnerRemovedWithSingle, handler.notif[0].TmplCode)
+		suite.Equal(int64(3), handler.notif[0].Recipient.ID)
+	})
+
+	suite.Run("partner is the couple creator", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event)
+
+		suite.True(handler.DancerForbid(&models.Dancer{
+			Profile: &models.Profile{ID: 2, FirstName: "Jane", LastName: "Doe"},
+		}, &event.Owner))
+
+		suite.Len(event.Couples, 1)
+		suite.Len(event.Singles, 2)
+		suite.Require().Len(handler.notif, 2)
+		suite.Equal(models.TmplPartnerRemoved, handler.notif[0].TmplCode)
+		suite.Equal(int64(1), handler.notif[0].Recipient.ID)
+		suite.Equal(int64(2), handler.notif[0].Payload.Partner.Profile.ID)
+		suite.Equal(models.TmplDancerForbidden, handler.notif[1].TmplCode)
+	})
+
+	suite.Run("dancer already forbidden", func() {
+		event := sampleEvent()
+		event.Forbidden = []models.Dancer{{FullName: "@alicew"}}
+		handler := NewEventHandler(&event)
+
+		suite.False(handler.DancerForbid(&models.Dancer{FullName: "@alicew"}, &event.Owner))
+		suite.Len(event.Forbidden, 1)
+		suite.Len(handler.hist, 0)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestDancerAllow() {
+	suite.Run("dancer allowed", func() {
+		event := sampleEvent()
+		event.Forbidden = []models.Dancer{{FullName: "@alicew"}, {FullName: "@bobb1", CreatedAt: time.Unix(1, 0)}}
+		handler := NewEventHandler(&event)
+
+		suite.True(handler.DancerAllow(event.Forbidden[0].Key(), &event.Owner))
+
+		suite.Require().Len(event.Forbidden, 1)
+		suite.Equal("@bobb1", event.Forbidden[0].FullName)
+		suite.Require().Len(handler.hist, 1)
+		suite.Equal(models.HistoryDancerAllowed, handler.hist[0].Action)
+		suite.Equal(&models.Dancer{FullName: "@alicew"}, handler.hist[0].Details)
+	})
+
+	suite.Run("unknown key", func() {
+		event := sampleEvent()
+		event.Forbidden = []models.Dancer{{FullName: "@alicew"}}
+		handler := NewEventHandler(&event)
+
+		suite.False(handler.DancerAllow("1", &event.Owner))
+		suite.False(handler.DancerAllow("", &event.Owner))
+		suite.Len(event.Forbidden, 1)
+		suite.Len(handler.hist, 0)
+	})
+}
+
+func (suite *TestEventHandlerSuite) TestBlocklist() {
+	suite.Run("blocked dancer registration", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event).WithBlocklist([]models.Dancer{{FullName: "@alicew"}})
+
+		got := handler.RegistrationGet(&models.Dancer{
+			Profile: &models.Profile{ID: 600, FirstName: "Alice", Username: "alicew"},
+			Role:    models.RoleFollower,
+		})
+
+		suite.Equal(models.StatusForbidden, got.Status)
+	})
+
+	suite.Run("blocked partner", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event).WithBlocklist([]models.Dancer{
+			{Profile: &models.Profile{ID: 700}, FullName: "Bob Builder"},
+		})
+
+		got := handler.CoupleAdd(
+			&models.Dancer{Profile: &models.Profile{ID: 600, FirstName: "Alice"}, Role: models.RoleFollower},
+			&models.Dancer{Profile: &models.Profile{ID: 700, FirstName: "Bob"}, Role: models.RoleLeader},
+		)
+
+		suite.Equal(models.ResultPartnerForbidden, got.Result)
+		suite.Len(event.Couples, 2)
+		suite.Len(handler.hist, 0)
+	})
+
+	suite.Run("blocked dancer keeps existing registration", func() {
+		event := sampleEvent()
+		handler := NewEventHandler(&event).WithBlocklist([]models.Dancer{{FullName: "@katbrown"}})
+
+		got := handler.RegistrationGet(&models.Dancer{
+			Profile: &models.Profile{ID: 4, FirstName: "Kate", Username: "katbrown"},
+			Role:    models.RoleFollower,
+		})
+
+		suite.Equal(models.StatusAsSingle, got.Status)
+	})
+}
+
 func sampleEvent() models.Event {
 	return models.Event{
 		ID:      "test12345678",
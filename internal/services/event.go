@@ -2,8 +2,12 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/ofstudio/dancegobot/internal/config"
@@ -21,6 +25,8 @@ type EventService struct {
 	notifier *NotifierService
 	renderer *RenderService
 	log      *slog.Logger
+	drafts   map[string]*models.Event // Drafts of new events that are not saved yet
+	draftsMu sync.Mutex
 }
 
 func NewEventService(cfg config.Settings, store store.Store, r *RenderService, n *NotifierService) *EventService {
@@ -30,6 +36,7 @@ func NewEventService(cfg config.Settings, store store.Store, r *RenderService, n
 		renderer: r,
 		notifier: n,
 		log:      noplog.Logger(),
+		drafts:   make(map[string]*models.Event),
 	}
 }
 
@@ -41,40 +48,38 @@ func (s *EventService) WithLogger(l *slog.Logger) *EventService {
 // Start starts draft cleanup scheduler.
 func (s *EventService) Start(ctx context.Context) {
 	go s.draftsCleanupScheduler(ctx)
+	go s.autoCloseScheduler(ctx)
+	go s.recurrenceScheduler(ctx)
+	go s.pendingExpireScheduler(ctx)
+	go s.singlesExpireScheduler(ctx)
 }
 
-// Create creates a new event.
-func (s *EventService) Create(
-	ctx context.Context,
+// Draft creates a new event draft.
+// The draft is kept in memory and saved only when its post is published:
+// see [EventService.PostAdd] and [EventService.PostChatAdd].
+func (s *EventService) Draft(
 	caption string,
 	owner models.Profile,
 	settings models.EventSettings,
 ) (*models.Event, error) {
 
 	event := &models.Event{
-		ID:        randtoken.New(s.cfg.EventIDLen),
-		Caption:   caption,
-		Settings:  settings,
-		Owner:     owner,
-		CreatedAt: nowFn(),
+		ID:           randtoken.New(s.cfg.EventIDLen),
+		Caption:      caption,
+		Settings:     settings,
+		Owner:        owner,
+		CoOrganizers: settings.CoOrganizers,
+		CreatedAt:    nowFn(),
 	}
+	event.Settings.CoOrganizers = nil
 
 	if err := s.validateEvent(event); err != nil {
 		return nil, fmt.Errorf("failed to validate event: %w", err)
 	}
 
-	if err := s.store.EventUpsert(ctx, event); err != nil {
-		return nil, fmt.Errorf("failed to upsert event: %w", err)
-	}
-
-	go s.historyInsert(ctx, &models.HistoryItem{
-		Action:    models.HistoryEventCreated,
-		Initiator: &event.Owner,
-		EventID:   &event.ID,
-		Details:   event,
-		CreatedAt: nowFn(),
-	})
-
+	s.draftsMu.Lock()
+	s.drafts[event.ID] = event
+	s.draftsMu.Unlock()
 	return event, nil
 }
 
@@ -87,14 +92,45 @@ func (s *EventService) Get(ctx context.Context, id string) (*models.Event, error
 	return event, nil
 }
 
+// GetByManager returns the recent published events managed by the profile as the owner or a co-organizer.
+func (s *EventService) GetByManager(ctx context.Context, profileID int64, limit, offset int) ([]*models.Event, error) {
+	events, err := s.store.EventGetByManager(ctx, profileID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events by manager: %w", err)
+	}
+	return events, nil
+}
+
+// GetManaged returns an event by ID if the given profile is allowed to manage it.
+// Otherwise, returns [models.ErrAccessDenied].
+func (s *EventService) GetManaged(ctx context.Context, id string, profile *models.Profile) (*models.Event, error) {
+	event, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !NewEventHandler(event).CanManage(profile) {
+		return nil, models.ErrAccessDenied
+	}
+	return event, nil
+}
+
 // RegistrationGet returns registration for the given event by profile and role.
-func (s *EventService) RegistrationGet(event *models.Event, profile *models.Profile, role models.Role) *models.Registration {
-	return NewEventHandler(event).RegistrationGet(&models.Dancer{
+func (s *EventService) RegistrationGet(
+	ctx context.Context,
+	event *models.Event,
+	profile *models.Profile,
+	role models.Role,
+) (*models.Registration, error) {
+	h, err := s.newHandler(ctx, s.store, event)
+	if err != nil {
+		return nil, err
+	}
+	return h.RegistrationGet(&models.Dancer{
 		Profile:   profile,
 		FullName:  profile.FullName(),
 		Role:      role,
 		CreatedAt: nowFn(),
-	})
+	}), nil
 }
 
 // PostAdd adds information about the post where the event is published.
@@ -106,6 +142,9 @@ func (s *EventService) PostAdd(
 	if inlineMessageID == "" {
 		return nil, nil, fmt.Errorf("inline message ID must be provided")
 	}
+	if err := s.draftSave(ctx, eventID); err != nil {
+		return nil, nil, err
+	}
 
 	var event *models.Event
 	var post *models.Post
@@ -139,6 +178,9 @@ func (s *EventService) PostChatAdd(
 	if chatMessageID == 0 {
 		return nil, nil, fmt.Errorf("chat message ID must be provided")
 	}
+	if err := s.draftSave(ctx, eventID); err != nil {
+		return nil, nil, err
+	}
 
 	// Update the event
 	var event *models.Event
@@ -168,100 +210,747 @@ func (s *EventService) CoupleAdd(
 	ctx context.Context,
 	eventID string,
 	profile *models.Profile,
-	role models.Role,
-	other any,
-) (*models.Registration, error) {
+	role models.Role,
+	other any,
+) (*models.Registration, error) {
+
+	if err := s.validateProfile(profile); err != nil {
+		return nil, fmt.Errorf("failed to validate profile: %w", err)
+	}
+
+	dancer := &models.Dancer{
+		Profile:   profile,
+		FullName:  profile.FullName(),
+		Role:      role,
+		CreatedAt: nowFn(),
+	}
+	partner, err := s.otherDancer(other, role.Opposite())
+	if err != nil {
+		return nil, err
+	}
+	// The partner registered by name can claim the registration later
+	if partner.Profile == nil {
+		partner.ClaimToken = randtoken.New(s.cfg.ClaimTokenLen)
+	}
+	if err = s.dancerSnapshot(ctx, dancer, partner); err != nil {
+		return nil, err
+	}
+
+	var reg *models.Registration
+	err = s.handle(ctx, eventID, func(h *EventHandler) {
+		reg = h.CoupleAdd(dancer, partner)
+	})
+	return reg, err
+}
+
+// DancerClaim attaches the profile to the partner registered by name with the given claim token.
+func (s *EventService) DancerClaim(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	token string,
+) (*models.Registration, error) {
+	if err := s.validateProfile(profile); err != nil {
+		return nil, fmt.Errorf("failed to validate profile: %w", err)
+	}
+	dancer := &models.Dancer{Profile: profile, FullName: profile.FullName()}
+	var reg *models.Registration
+	err := s.handle(ctx, eventID, func(h *EventHandler) {
+		reg = h.DancerClaim(dancer, token)
+	})
+	return reg, err
+}
+
+// PartnerReplace replaces the partner of the dancer with the given profile
+// keeping the place of the couple. The new partner can be specified by a profile or a full name,
+// the role of the new partner is defined by the couple.
+func (s *EventService) PartnerReplace(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	other any,
+) (*models.Registration, error) {
+	if err := s.validateProfile(profile); err != nil {
+		return nil, fmt.Errorf("failed to validate profile: %w", err)
+	}
+	dancer := &models.Dancer{Profile: profile, FullName: profile.FullName()}
+	partner, err := s.otherDancer(other, models.RoleSwitch)
+	if err != nil {
+		return nil, err
+	}
+	// The partner registered by name can claim the registration later
+	if partner.Profile == nil {
+		partner.ClaimToken = randtoken.New(s.cfg.ClaimTokenLen)
+	}
+	if err = s.dancerSnapshot(ctx, partner); err != nil {
+		return nil, err
+	}
+
+	var reg *models.Registration
+	err = s.handle(ctx, eventID, func(h *EventHandler) {
+		reg = h.PartnerReplace(dancer, partner)
+	})
+	return reg, err
+}
+
+// RoleSwitch switches the roles in the couple of the dancer with the given profile.
+func (s *EventService) RoleSwitch(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+) (*models.Registration, error) {
+	if err := s.validateProfile(profile); err != nil {
+		return nil, fmt.Errorf("failed to validate profile: %w", err)
+	}
+	dancer := &models.Dancer{Profile: profile, FullName: profile.FullName()}
+	var reg *models.Registration
+	err := s.handle(ctx, eventID, func(h *EventHandler) {
+		reg = h.RoleSwitch(dancer)
+	})
+	return reg, err
+}
+
+// SingleAdd adds a single dancer to the event.
+// If auto pair is enabled, tries to pair the dancer with another single dancer.
+func (s *EventService) SingleAdd(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	role models.Role,
+) (*models.Registration, error) {
+	if err := s.validateProfile(profile); err != nil {
+		return nil, fmt.Errorf("failed to validate profile: %w", err)
+	}
+	dancer := &models.Dancer{
+		Profile:   profile,
+		FullName:  profile.FullName(),
+		Role:      role,
+		CreatedAt: nowFn(),
+	}
+	if err := s.dancerSnapshot(ctx, dancer); err != nil {
+		return nil, err
+	}
+	var reg *models.Registration
+	err := s.handle(ctx, eventID, func(h *EventHandler) {
+		reg = h.SingleAdd(dancer)
+		if reg.Result == models.ResultRegisteredAsSingle {
+			h.SinglesAlert(reg.Dancer)
+		}
+	})
+	return reg, err
+}
+
+// PendingAccept registers the couple confirmed by the invited partner with the given profile.
+func (s *EventService) PendingAccept(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	requesterID int64,
+) (*models.Registration, error) {
+	if err := s.validateProfile(profile); err != nil {
+		return nil, fmt.Errorf("failed to validate profile: %w", err)
+	}
+	partner := &models.Dancer{
+		Profile:   profile,
+		FullName:  profile.FullName(),
+		CreatedAt: nowFn(),
+	}
+	if err := s.dancerSnapshot(ctx, partner); err != nil {
+		return nil, err
+	}
+	var reg *models.Registration
+	err := s.handle(ctx, eventID, func(h *EventHandler) {
+		reg = h.PendingAccept(partner, requesterID)
+	})
+	return reg, err
+}
+
+// PendingDecline removes the couple declined by the invited partner with the given profile.
+// Returns false if there is no such couple waiting for the partner confirmation.
+func (s *EventService) PendingDecline(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	requesterID int64,
+) (bool, error) {
+	if err := s.validateProfile(profile); err != nil {
+		return false, fmt.Errorf("failed to validate profile: %w", err)
+	}
+	partner := &models.Dancer{Profile: profile, FullName: profile.FullName()}
+	var ok bool
+	err := s.handle(ctx, eventID, func(h *EventHandler) {
+		ok = h.PendingDecline(partner, requesterID)
+	})
+	return ok, err
+}
+
+// SingleRefresh confirms that the single dancer with the given profile is still looking for a partner,
+// so the registration is not removed as stale. Returns false if the dancer is not registered as single.
+func (s *EventService) SingleRefresh(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+) (bool, error) {
+	if err := s.validateProfile(profile); err != nil {
+		return false, fmt.Errorf("failed to validate profile: %w", err)
+	}
+	dancer := &models.Dancer{Profile: profile, FullName: profile.FullName()}
+	var ok bool
+	err := s.handle(ctx, eventID, func(h *EventHandler) {
+		ok = h.SingleRefresh(dancer)
+	})
+	return ok, err
+}
+
+// ParticipantAdd registers the dancer individually for the solo event.
+// The role is optional and used only for the headcount.
+func (s *EventService) ParticipantAdd(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	role models.Role,
+) (*models.Registration, error) {
+	if err := s.validateProfile(profile); err != nil {
+		return nil, fmt.Errorf("failed to validate profile: %w", err)
+	}
+	if role != "" {
+		if err := s.validateRole(role); err != nil {
+			return nil, fmt.Errorf("failed to validate role: %w", err)
+		}
+	}
+	dancer := &models.Dancer{
+		Profile:   profile,
+		FullName:  profile.FullName(),
+		Role:      role,
+		CreatedAt: nowFn(),
+	}
+	if err := s.dancerSnapshot(ctx, dancer); err != nil {
+		return nil, err
+	}
+	var reg *models.Registration
+	err := s.handle(ctx, eventID, func(h *EventHandler) {
+		reg = h.ParticipantAdd(dancer)
+	})
+	return reg, err
+}
+
+// DancerRemove removes the dancer from the event.
+//
+// If the dancer is in a couple, and the partner initially signed up as a single,
+// the partner will be moved to the singles list back (or auto paired if enabled)
+// and a notification will be created.
+// Otherwise, the partner will be removed from the event as well.
+//
+// If the dancer is in a couple, and a couple was created by the partner,
+// the partner will be notified that the dancer has left the event.
+func (s *EventService) DancerRemove(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+) (*models.Registration, error) {
+	if err := s.validateProfile(profile); err != nil {
+		return nil, fmt.Errorf("failed to validate profile: %w", err)
+	}
+	var reg *models.Registration
+	err := s.handle(ctx, eventID, func(h *EventHandler) {
+		reg = h.DancerRemove(&models.Dancer{
+			Profile:  profile,
+			FullName: profile.FullName(),
+		})
+	})
+	return reg, err
+}
+
+// CoupleAddByOrganizer registers a couple for the event on behalf of the organizer.
+// The dancers can be either specified by a profile, a full name or a dancer already registered for the event.
+// Only the users allowed to manage the event can register the dancers.
+func (s *EventService) CoupleAddByOrganizer(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	leader, follower any,
+) (*models.Registration, error) {
+	d, err := s.otherDancer(leader, models.RoleLeader)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.otherDancer(follower, models.RoleFollower)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.dancerSnapshot(ctx, d, p); err != nil {
+		return nil, err
+	}
+	var reg *models.Registration
+	err = s.manage(ctx, eventID, profile, func(h *EventHandler) {
+		reg = h.CoupleAddByOrganizer(d, p, profile)
+	})
+	return reg, err
+}
+
+// SingleAddByOrganizer registers a single dancer for the event on behalf of the organizer.
+// The dancer can be either specified by a profile or a full name.
+// Only the users allowed to manage the event can register the dancers.
+func (s *EventService) SingleAddByOrganizer(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	role models.Role,
+	other any,
+) (*models.Registration, error) {
+	dancer, err := s.otherDancer(other, role)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.dancerSnapshot(ctx, dancer); err != nil {
+		return nil, err
+	}
+	var reg *models.Registration
+	err = s.manage(ctx, eventID, profile, func(h *EventHandler) {
+		reg = h.SingleAddByOrganizer(dancer, profile)
+	})
+	return reg, err
+}
+
+// DancerRemoveByOrganizer removes the dancer registered for the event on behalf of the organizer.
+// Only the users allowed to manage the event can remove the dancers.
+func (s *EventService) DancerRemoveByOrganizer(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	dancer *models.Dancer,
+) (*models.Registration, error) {
+	var reg *models.Registration
+	err := s.manage(ctx, eventID, profile, func(h *EventHandler) {
+		reg = h.DancerRemoveByOrganizer(dancer, profile)
+	})
+	return reg, err
+}
+
+// SinglesPair registers the couple of two dancers from the singles list on behalf of the organizer.
+// Only the event owner can pair the singles.
+func (s *EventService) SinglesPair(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	leader, follower *models.Dancer,
+) (*models.Registration, error) {
+	var reg *models.Registration
+	err := s.own(ctx, eventID, profile, func(h *EventHandler) {
+		reg = h.SinglesPair(leader, follower, profile)
+	})
+	return reg, err
+}
+
+// ClosedForSet changes the closure mode of the event.
+// Only the users allowed to manage the event can change the closure mode.
+func (s *EventService) ClosedForSet(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	closedFor models.ClosedFor,
+) (*models.Event, error) {
+	if err := s.validateClosedFor(closedFor); err != nil {
+		return nil, fmt.Errorf("failed to validate closed for: %w", err)
+	}
+	var event *models.Event
+	err := s.manage(ctx, eventID, profile, func(h *EventHandler) {
+		h.ClosedForSet(closedFor, profile)
+		event = h.Event()
+	})
+	return event, err
+}
+
+// LimitSet changes the maximum number of couples for the event. Zero means no limit.
+// Only the users allowed to manage the event can change the limit.
+func (s *EventService) LimitSet(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	limit int,
+) (*models.Event, error) {
+	if limit < 0 {
+		return nil, fmt.Errorf("limit must not be negative")
+	}
+	var event *models.Event
+	err := s.manage(ctx, eventID, profile, func(h *EventHandler) {
+		h.LimitSet(limit, profile)
+		event = h.Event()
+	})
+	return event, err
+}
+
+// CaptionSet changes the caption of the event. The event post is re-rendered
+// with the new caption, all registrations are kept.
+// Returns [models.ErrCaptionInvalid] if the caption is empty or too long.
+// Only the users allowed to manage the event can change the caption.
+func (s *EventService) CaptionSet(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	caption string,
+) (*models.Event, error) {
+	caption = strings.TrimSpace(caption)
+	if caption == "" || len(caption) > s.cfg.EventTextMaxLen {
+		return nil, models.ErrCaptionInvalid
+	}
+	var event *models.Event
+	err := s.manage(ctx, eventID, profile, func(h *EventHandler) {
+		h.CaptionSet(caption, profile)
+		event = h.Event()
+	})
+	return event, err
+}
+
+// StartsAtSet changes the start time of the event. Nil start time removes it.
+// The registration is closed automatically [config.Settings.AutoCloseBefore] the start time.
+// Only the users allowed to manage the event can change the start time.
+func (s *EventService) StartsAtSet(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	startsAt *time.Time,
+) (*models.Event, error) {
+	var event *models.Event
+	err := s.manage(ctx, eventID, profile, func(h *EventHandler) {
+		h.StartsAtSet(startsAt, profile)
+		event = h.Event()
+	})
+	return event, err
+}
+
+// RecurrenceSet changes the recurrence rule of the event.
+// Only the users allowed to manage the event can change the recurrence rule.
+func (s *EventService) RecurrenceSet(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	recurrence models.Recurrence,
+) (*models.Event, error) {
+	if !slices.Contains(models.Recurrences, recurrence) {
+		return nil, fmt.Errorf("unknown recurrence: '%s'", recurrence)
+	}
+	var event *models.Event
+	err := s.manage(ctx, eventID, profile, func(h *EventHandler) {
+		h.RecurrenceSet(recurrence, profile)
+		event = h.Event()
+	})
+	return event, err
+}
+
+// LevelsSet changes the dance level range of the dancers paired automatically.
+// Empty level means no bound.
+// Only the users allowed to manage the event can change the level range.
+func (s *EventService) LevelsSet(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	minLevel, maxLevel models.Level,
+) (*models.Event, error) {
+	for _, l := range []models.Level{minLevel, maxLevel} {
+		if l != models.LevelNone && !slices.Contains(models.Levels, l) {
+			return nil, fmt.Errorf("unknown level: '%s'", l)
+		}
+	}
+	if minLevel != models.LevelNone && maxLevel != models.LevelNone && minLevel.Rank() > maxLevel.Rank() {
+		return nil, fmt.Errorf("invalid level range: '%s' - '%s'", minLevel, maxLevel)
+	}
+	var event *models.Event
+	err := s.manage(ctx, eventID, profile, func(h *EventHandler) {
+		h.LevelsSet(minLevel, maxLevel, profile)
+		event = h.Event()
+	})
+	return event, err
+}
+
+// ConsentSet changes the partner consent requirement of the event.
+func (s *EventService) ConsentSet(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	consent bool,
+) (*models.Event, error) {
+	var event *models.Event
+	err := s.manage(ctx, eventID, profile, func(h *EventHandler) {
+		h.ConsentSet(consent, profile)
+		event = h.Event()
+	})
+	return event, err
+}
+
+// DeadlinesSet changes the registration and cancellation deadlines of the event.
+// The deadlines are set as durations before the start of the event.
+func (s *EventService) DeadlinesSet(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	signup, cancel time.Duration,
+) (*models.Event, error) {
+	var event *models.Event
+	err := s.manage(ctx, eventID, profile, func(h *EventHandler) {
+		h.DeadlinesSet(signup, cancel, profile)
+		event = h.Event()
+	})
+	return event, err
+}
+
+// SingleTTLSet changes the time after which the singles are removed from the event.
+// Zero TTL keeps the singles until the event starts.
+func (s *EventService) SingleTTLSet(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	ttl time.Duration,
+) (*models.Event, error) {
+	var event *models.Event
+	err := s.manage(ctx, eventID, profile, func(h *EventHandler) {
+		h.SingleTTLSet(ttl, profile)
+		event = h.Event()
+	})
+	return event, err
+}
+
+// SoloSet switches the event between the solo and the regular mode.
+// The mode can be changed only while nobody is registered for the event,
+// otherwise returns [models.ErrEventNotEmpty].
+func (s *EventService) SoloSet(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	solo bool,
+) (*models.Event, error) {
+	var event *models.Event
+	err := s.handleTx(ctx, eventID, func(h *EventHandler) error {
+		if !h.CanManage(profile) {
+			return models.ErrAccessDenied
+		}
+		if h.Event().Settings.Solo != solo && !h.IsEmpty() {
+			return models.ErrEventNotEmpty
+		}
+		h.SoloSet(solo, profile)
+		event = h.Event()
+		return nil
+	})
+	return event, err
+}
+
+// Clone creates a new draft event cloned from the event.
+// If withCouples is true, the couples opted in to repeat are signed up for the new event.
+// Only the users allowed to manage the event can clone it.
+func (s *EventService) Clone(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	withCouples bool,
+) (*models.Event, error) {
+	return s.clone(ctx, eventID, profile, withCouples, func(h *EventHandler) error {
+		if !h.CanManage(profile) {
+			return models.ErrAccessDenied
+		}
+		return nil
+	})
+}
+
+// CoupleRepeatSet changes the opt-in of the dancer couple to be signed up for the next recurring event.
+func (s *EventService) CoupleRepeatSet(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	repeat bool,
+) (*models.Registration, error) {
+	if err := s.validateProfile(profile); err != nil {
+		return nil, fmt.Errorf("failed to validate profile: %w", err)
+	}
+	var reg *models.Registration
+	err := s.handle(ctx, eventID, func(h *EventHandler) {
+		dancer := &models.Dancer{Profile: profile, FullName: profile.FullName()}
+		h.CoupleRepeatSet(dancer, repeat)
+		reg = h.RegistrationGet(dancer)
+	})
+	return reg, err
+}
+
+// DancerForbid forbids the dancer to sign in for the event and removes the dancer registration.
+// The dancer can be either specified by a profile or a full name with @username.
+// Returns [models.ErrUsernameRequired] if the full name does not contain @username.
+// Only the users allowed to manage the event can forbid the dancers.
+func (s *EventService) DancerForbid(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	other any,
+) (*models.Event, error) {
+	dancer, err := s.otherDancer(other, "")
+	if err != nil {
+		return nil, err
+	}
+	// The dancer without a profile can be matched only by @username
+	if _, ok := getUsername(dancer.FullName); dancer.Profile == nil && !ok {
+		return nil, models.ErrUsernameRequired
+	}
+	var event *models.Event
+	err = s.manage(ctx, eventID, profile, func(h *EventHandler) {
+		h.DancerForbid(dancer, profile)
+		event = h.Event()
+	})
+	return event, err
+}
+
+// DancerAllow removes the dancer with the given key from the forbidden list of the event.
+// Only the users allowed to manage the event can allow the dancers.
+func (s *EventService) DancerAllow(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	key string,
+) (*models.Event, error) {
+	var event *models.Event
+	err := s.manage(ctx, eventID, profile, func(h *EventHandler) {
+		h.DancerAllow(key, profile)
+		event = h.Event()
+	})
+	return event, err
+}
 
-	if err := s.validateProfile(profile); err != nil {
-		return nil, fmt.Errorf("failed to validate profile: %w", err)
+// dancerSnapshot copies the dance profiles of the users into the dancers.
+// The dancers without Telegram profile and the unknown users are skipped.
+func (s *EventService) dancerSnapshot(ctx context.Context, dancers ...*models.Dancer) error {
+	for _, dancer := range dancers {
+		if dancer.Profile == nil {
+			continue
+		}
+		user, err := s.store.UserGet(ctx, dancer.Profile.ID)
+		if errors.Is(err, store.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		dancer.Level = user.Settings.Dancer.Level
+		dancer.PartnerLevel = user.Settings.Dancer.PartnerLevel
 	}
+	return nil
+}
 
-	dancer := &models.Dancer{
-		Profile:   profile,
-		FullName:  profile.FullName(),
-		Role:      role,
-		CreatedAt: nowFn(),
-	}
-	var partner *models.Dancer
+// otherDancer makes a dancer with the given role from the other person.
+// The other person can be either specified by a profile, a full name
+// or a dancer already registered for the event.
+// The registered switch dancer keeps the switch role until the couple is formed.
+func (s *EventService) otherDancer(other any, role models.Role) (*models.Dancer, error) {
 	switch v := other.(type) {
+	case *models.Dancer:
+		if v.Role != role && v.Role != models.RoleSwitch {
+			return nil, fmt.Errorf("invalid role of other person: %s", v.Role)
+		}
+		dancer := *v
+		return &dancer, nil
 	case *models.Profile:
 		if err := s.validateProfile(v); err != nil {
 			return nil, fmt.Errorf("failed to validate other person profile: %w", err)
 		}
-		partner = &models.Dancer{
+		return &models.Dancer{
 			Profile:   v,
 			FullName:  v.FullName(),
-			Role:      role.Opposite(),
+			Role:      role,
 			CreatedAt: nowFn(),
-		}
+		}, nil
 	case string:
 		if err := s.validateFullname(v); err != nil {
 			return nil, fmt.Errorf("failed to validate other person name: %w", err)
 		}
-		partner = &models.Dancer{
+		return &models.Dancer{
 			FullName:  v,
-			Role:      role.Opposite(),
+			Role:      role,
 			CreatedAt: nowFn(),
-		}
+		}, nil
 	default:
 		return nil, fmt.Errorf("invalid type of other person: %T", other)
 	}
+}
 
-	var reg *models.Registration
-	err := s.handle(ctx, eventID, func(h *EventHandler) {
-		reg = h.CoupleAdd(dancer, partner)
+// InviteCreate creates the co-organizer invite token of the event.
+// Existing token is replaced with a new one only if renew is true.
+// Only the event owner can invite co-organizers.
+func (s *EventService) InviteCreate(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	renew bool,
+) (*models.Event, error) {
+	var event *models.Event
+	err := s.own(ctx, eventID, profile, func(h *EventHandler) {
+		if h.Event().InviteToken == "" || renew {
+			h.Event().InviteToken = randtoken.New(s.cfg.InviteTokenLen)
+		}
+		event = h.Event()
 	})
-	return reg, err
+	return event, err
 }
 
-// SingleAdd adds a single dancer to the event.
-// If auto pair is enabled, tries to pair the dancer with another single dancer.
-func (s *EventService) SingleAdd(
+// InviteAccept adds the profile to the co-organizers of the event.
+// The invite token can be used only once: it is reset after the profile is added.
+// Returns [models.ErrInviteInvalid] if the token does not match the event invite token.
+func (s *EventService) InviteAccept(
 	ctx context.Context,
 	eventID string,
+	token string,
 	profile *models.Profile,
-	role models.Role,
-) (*models.Registration, error) {
+) (*models.Event, error) {
 	if err := s.validateProfile(profile); err != nil {
 		return nil, fmt.Errorf("failed to validate profile: %w", err)
 	}
-	var reg *models.Registration
-	err := s.handle(ctx, eventID, func(h *EventHandler) {
-		reg = h.SingleAdd(&models.Dancer{
-			Profile:   profile,
-			FullName:  profile.FullName(),
-			Role:      role,
-			CreatedAt: nowFn(),
-		})
+	var event *models.Event
+	err := s.handleTx(ctx, eventID, func(h *EventHandler) error {
+		if token == "" || token != h.Event().InviteToken {
+			return models.ErrInviteInvalid
+		}
+		if h.CoOrganizerAdd(profile) {
+			h.Event().InviteToken = ""
+		}
+		event = h.Event()
+		return nil
 	})
-	return reg, err
+	return event, err
 }
 
-// DancerRemove removes the dancer from the event.
-//
-// If the dancer is in a couple, and the partner initially signed up as a single,
-// the partner will be moved to the singles list back (or auto paired if enabled)
-// and a notification will be created.
-// Otherwise, the partner will be removed from the event as well.
-//
-// If the dancer is in a couple, and a couple was created by the partner,
-// the partner will be notified that the dancer has left the event.
-func (s *EventService) DancerRemove(
+// CoOrganizerRemove removes the co-organizer with the given profile ID from the event.
+// Only the event owner can remove co-organizers.
+func (s *EventService) CoOrganizerRemove(
 	ctx context.Context,
 	eventID string,
 	profile *models.Profile,
-) (*models.Registration, error) {
-	if err := s.validateProfile(profile); err != nil {
-		return nil, fmt.Errorf("failed to validate profile: %w", err)
+	profileID int64,
+) (*models.Event, error) {
+	var event *models.Event
+	err := s.own(ctx, eventID, profile, func(h *EventHandler) {
+		h.CoOrganizerRemove(profileID, profile)
+		event = h.Event()
+	})
+	return event, err
+}
+
+// draftSave saves the draft of the new event with the given ID if it is not saved yet.
+func (s *EventService) draftSave(ctx context.Context, eventID string) error {
+	s.draftsMu.Lock()
+	defer s.draftsMu.Unlock()
+	event, ok := s.drafts[eventID]
+	if !ok {
+		return nil
 	}
-	var reg *models.Registration
-	err := s.handle(ctx, eventID, func(h *EventHandler) {
-		reg = h.DancerRemove(&models.Dancer{
-			Profile:  profile,
-			FullName: profile.FullName(),
-		})
+
+	if err := s.store.EventUpsert(ctx, event); err != nil {
+		return fmt.Errorf("failed to upsert event: %w", err)
+	}
+	delete(s.drafts, eventID)
+
+	go s.historyInsert(ctx, &models.HistoryItem{
+		Action:    models.HistoryEventCreated,
+		Initiator: &event.Owner,
+		EventID:   &event.ID,
+		Details:   event,
+		CreatedAt: nowFn(),
 	})
-	return reg, err
+	return nil
 }
 
 // handle is a wrapper for the event handler.
@@ -269,6 +958,54 @@ func (s *EventService) handle(
 	ctx context.Context,
 	eventID string,
 	handlerFunc func(*EventHandler),
+) error {
+	return s.handleTx(ctx, eventID, func(h *EventHandler) error {
+		handlerFunc(h)
+		return nil
+	})
+}
+
+// manage is a wrapper for the event handler for the actions
+// allowed only to the users who can manage the event.
+// Returns [models.ErrAccessDenied] if the profile is not allowed to manage the event.
+func (s *EventService) manage(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	handlerFunc func(*EventHandler),
+) error {
+	return s.handleTx(ctx, eventID, func(h *EventHandler) error {
+		if !h.CanManage(profile) {
+			return models.ErrAccessDenied
+		}
+		handlerFunc(h)
+		return nil
+	})
+}
+
+// own is a wrapper for the event handler for the actions allowed only to the event owner.
+// Returns [models.ErrNotOwner] if the profile is not the owner of the event.
+func (s *EventService) own(
+	ctx context.Context,
+	eventID string,
+	profile *models.Profile,
+	handlerFunc func(*EventHandler),
+) error {
+	return s.handleTx(ctx, eventID, func(h *EventHandler) error {
+		if !h.IsOwner(profile) {
+			return models.ErrNotOwner
+		}
+		handlerFunc(h)
+		return nil
+	})
+}
+
+// handleTx runs the event handler within a transaction.
+// If handlerFunc returns an error, the transaction is rolled back and the error is returned.
+func (s *EventService) handleTx(
+	ctx context.Context,
+	eventID string,
+	handlerFunc func(*EventHandler) error,
 ) error {
 	// Begin tx
 	tx, err := s.store.BeginTx(ctx)
@@ -285,10 +1022,15 @@ func (s *EventService) handle(
 	}
 
 	// Create event handler
-	handler := NewEventHandler(event)
+	handler, err := s.newHandler(ctx, tx, event)
+	if err != nil {
+		return err
+	}
 
 	// Run update function
-	handlerFunc(handler)
+	if err = handlerFunc(handler); err != nil {
+		return err
+	}
 
 	// After event handling is done, we need to:
 	// - upsert the event in the store
@@ -302,13 +1044,98 @@ func (s *EventService) handle(
 	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit tx: %w", err)
 	}
+	notifications := handler.Notifications()
+	if n := handler.OwnerActivity(); n != nil {
+		notifications = append(notifications, n)
+	}
 	go s.renderer.Render(ctx, event)
 	go s.historyInsert(ctx, handler.History()...)
-	go s.notify(ctx, handler.Notifications()...)
+	go s.notify(ctx, notifications...)
 
 	return nil
 }
 
+// clone clones the event into a new draft event within a transaction.
+// If checkFunc returns an error, the transaction is rolled back and the error is returned.
+func (s *EventService) clone(
+	ctx context.Context,
+	eventID string,
+	initiator *models.Profile,
+	withCouples bool,
+	checkFunc func(*EventHandler) error,
+) (*models.Event, error) {
+	// Begin tx
+	tx, err := s.store.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	//goland:noinspection ALL
+	defer tx.Rollback()
+
+	// Get event
+	event, err := tx.EventGet(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	// Create event handler and clone the event
+	handler, err := s.newHandler(ctx, tx, event)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkFunc(handler); err != nil {
+		return nil, err
+	}
+	next := handler.Clone(randtoken.New(s.cfg.EventIDLen), withCouples, initiator)
+	if err = s.validateEvent(next); err != nil {
+		return nil, fmt.Errorf("failed to validate event: %w", err)
+	}
+
+	// Upsert both events and commit
+	if err = tx.EventUpsert(ctx, next); err != nil {
+		return nil, fmt.Errorf("failed to upsert event: %w", err)
+	}
+	if err = tx.EventUpsert(ctx, event); err != nil {
+		return nil, fmt.Errorf("failed to upsert event: %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	go s.historyInsert(ctx, append(handler.History(), &models.HistoryItem{
+		Action:    models.HistoryEventCreated,
+		Initiator: initiator,
+		EventID:   &next.ID,
+		Details:   next,
+		CreatedAt: nowFn(),
+	})...)
+
+	return next, nil
+}
+
+// newHandler creates the event handler with the blocklist of the event owner.
+func (s *EventService) newHandler(ctx context.Context, st store.Store, event *models.Event) (*EventHandler, error) {
+	owner, err := st.UserGet(ctx, event.Owner.ID)
+	if errors.Is(err, store.ErrNotFound) {
+		return NewEventHandler(event), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event owner: %w", err)
+	}
+	h := NewEventHandler(event).
+		WithBlocklist(owner.Settings.Blocklist).
+		WithOwnerAlerts(owner.Settings.OwnerAlerts)
+	if event.Settings.AutoPairing && event.Settings.Pairing == models.PairingRecent {
+		recent, err := st.EventGetByManager(ctx, event.Owner.ID, s.cfg.RecentPartnersEvents, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get recent events: %w", err)
+		}
+		recent = slices.DeleteFunc(recent, func(e *models.Event) bool { return e.ID == event.ID })
+		h.WithRecentPartners(recentPartners(recent))
+	}
+	return h, nil
+}
+
 // historyInsert inserts a history item.
 func (s *EventService) historyInsert(ctx context.Context, items ...*models.HistoryItem) {
 	for _, item := range items {
@@ -350,6 +1177,14 @@ func (s *EventService) draftsCleanupScheduler(ctx context.Context) {
 
 func (s *EventService) draftsCleanup(ctx context.Context) {
 	before := time.Now().Add(-s.cfg.DraftCleanupOlderThan)
+	s.draftsMu.Lock()
+	for id, event := range s.drafts {
+		if event.CreatedAt.Before(before) {
+			delete(s.drafts, id)
+		}
+	}
+	s.draftsMu.Unlock()
+
 	ids, err := s.store.EventRemoveDraftsBefore(ctx, before)
 	if err != nil {
 		s.log.Error("[event service] failed to remove draft events: "+err.Error(), trace.Attr(ctx))
@@ -370,6 +1205,199 @@ func (s *EventService) draftsCleanup(ctx context.Context) {
 	)
 }
 
+func (s *EventService) autoCloseScheduler(ctx context.Context) {
+	if s.cfg.AutoCloseEvery == 0 {
+		s.log.Info("[event service] auto-close is disabled")
+		return
+	}
+
+	s.log.Info("[event service] starting auto-close scheduler",
+		slog.Duration("interval", s.cfg.AutoCloseEvery),
+		slog.Duration("before", s.cfg.AutoCloseBefore))
+
+	ticker := time.NewTicker(s.cfg.AutoCloseEvery)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			s.log.Info("[event service] auto-close scheduler stopped")
+			return
+		case <-ticker.C:
+			s.autoClose(trace.Context(ctx, "auto_close_"+randtoken.New(4)))
+		}
+	}
+}
+
+// autoClose closes the registration for the events starting soon.
+func (s *EventService) autoClose(ctx context.Context) {
+	events, err := s.store.EventGetStartingBefore(ctx, nowFn().Add(s.cfg.AutoCloseBefore))
+	if err != nil {
+		s.log.Error("[event service] failed to get events starting soon: "+err.Error(), trace.Attr(ctx))
+		return
+	}
+	for _, event := range events {
+		if err = s.handle(ctx, event.ID, func(h *EventHandler) { h.AutoClose() }); err != nil {
+			s.log.Error("[event service] failed to close event automatically: "+err.Error(),
+				"event", event.LogValue(),
+				trace.Attr(ctx))
+			continue
+		}
+		s.log.Info("[event service] event closed automatically",
+			"event", event.LogValue(),
+			trace.Attr(ctx))
+	}
+}
+
+func (s *EventService) recurrenceScheduler(ctx context.Context) {
+	if s.cfg.RecurrenceEvery == 0 {
+		s.log.Info("[event service] recurring events are disabled")
+		return
+	}
+
+	s.log.Info("[event service] starting recurring events scheduler",
+		slog.Duration("interval", s.cfg.RecurrenceEvery))
+
+	ticker := time.NewTicker(s.cfg.RecurrenceEvery)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.recur(trace.Context(ctx, "recurrence_"+randtoken.New(4)))
+		}
+	}
+}
+
+// recur creates the next drafts of the started recurring events
+// and notifies the owners to publish them.
+func (s *EventService) recur(ctx context.Context) {
+	events, err := s.store.EventGetRecurringBefore(ctx, nowFn())
+	if err != nil {
+		s.log.Error("[event service] failed to get started recurring events: "+err.Error(), trace.Attr(ctx))
+		return
+	}
+	for _, event := range events {
+		next, err := s.clone(ctx, event.ID, config.BotProfile(), true, func(h *EventHandler) error {
+			// the event could be cloned in the meantime
+			if h.Event().NextID != "" {
+				return errors.New("event is already cloned")
+			}
+			return nil
+		})
+		if err != nil {
+			s.log.Error("[event service] failed to create next recurring event: "+err.Error(),
+				"event", event.LogValue(),
+				trace.Attr(ctx))
+			continue
+		}
+		s.log.Info("[event service] next recurring event created",
+			"event", event.LogValue(),
+			"next", next.LogValue(),
+			trace.Attr(ctx))
+
+		go s.notify(ctx, &models.Notification{
+			TmplCode:  models.TmplNextEventCreated,
+			Recipient: &next.Owner,
+			Payload:   models.NotificationPayload{Event: next},
+		})
+	}
+}
+
+func (s *EventService) pendingExpireScheduler(ctx context.Context) {
+	if s.cfg.ConsentExpireEvery == 0 {
+		s.log.Info("[event service] pending couples expiration is disabled")
+		return
+	}
+
+	s.log.Info("[event service] starting pending couples expiration scheduler",
+		slog.Duration("interval", s.cfg.ConsentExpireEvery),
+		slog.Duration("timeout", s.cfg.ConsentTimeout))
+
+	ticker := time.NewTicker(s.cfg.ConsentExpireEvery)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.pendingExpire(trace.Context(ctx, "pending_expire_"+randtoken.New(4)))
+		}
+	}
+}
+
+// pendingExpire removes the couples that were not confirmed by the partners in time.
+func (s *EventService) pendingExpire(ctx context.Context) {
+	before := nowFn().Add(-s.cfg.ConsentTimeout)
+	events, err := s.store.EventGetPendingBefore(ctx, before)
+	if err != nil {
+		s.log.Error("[event service] failed to get events with expired pending couples: "+err.Error(), trace.Attr(ctx))
+		return
+	}
+	for _, event := range events {
+		var n int
+		if err = s.handle(ctx, event.ID, func(h *EventHandler) { n = h.PendingExpire(before) }); err != nil {
+			s.log.Error("[event service] failed to expire pending couples: "+err.Error(),
+				"event", event.LogValue(),
+				trace.Attr(ctx))
+			continue
+		}
+		s.log.Info("[event service] pending couples expired",
+			"event", event.LogValue(),
+			"count", n,
+			trace.Attr(ctx))
+	}
+}
+
+// singlesExpireScheduler periodically warns and removes the stale singles.
+func (s *EventService) singlesExpireScheduler(ctx context.Context) {
+	if s.cfg.SingleExpireEvery == 0 {
+		s.log.Info("[event service] stale singles expiration is disabled")
+		return
+	}
+
+	s.log.Info("[event service] starting stale singles expiration scheduler",
+		slog.Duration("interval", s.cfg.SingleExpireEvery),
+		slog.Duration("grace", s.cfg.SingleExpireGrace))
+
+	ticker := time.NewTicker(s.cfg.SingleExpireEvery)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.singlesExpire(trace.Context(ctx, "singles_expire_"+randtoken.New(4)))
+		}
+	}
+}
+
+// singlesExpire warns the singles registered longer than the event single TTL ago
+// and removes the ones that did not refresh the registration after the warning.
+func (s *EventService) singlesExpire(ctx context.Context) {
+	now := nowFn()
+	events, err := s.store.EventGetSinglesStale(ctx, now, now.Add(-s.cfg.SingleExpireGrace))
+	if err != nil {
+		s.log.Error("[event service] failed to get events with stale singles: "+err.Error(), trace.Attr(ctx))
+		return
+	}
+	for _, event := range events {
+		var n int
+		if err = s.handle(ctx, event.ID, func(h *EventHandler) { n = h.SinglesExpire(s.cfg.SingleExpireGrace) }); err != nil {
+			s.log.Error("[event service] failed to expire stale singles: "+err.Error(),
+				"event", event.LogValue(),
+				trace.Attr(ctx))
+			continue
+		}
+		if n > 0 {
+			s.log.Info("[event service] stale singles removed",
+				"event", event.LogValue(),
+				"count", n,
+				trace.Attr(ctx))
+		}
+	}
+}
+
 // validateEvent validates the event.
 func (s *EventService) validateEvent(e *models.Event) error {
 	errs := errMap{}
@@ -413,6 +1441,13 @@ func (s *EventService) validateRole(r models.Role) error {
 	return nil
 }
 
+func (s *EventService) validateClosedFor(c models.ClosedFor) error {
+	if !slices.Contains(models.ClosedForModes, c) {
+		return fmt.Errorf("unknown closed for value: %q", c)
+	}
+	return nil
+}
+
 func (s *EventService) validateFullname(fn string) error {
 	if len(fn) < 1 || len(fn) > s.cfg.DancerNameMaxLen {
 		return fmt.Errorf("full name must be between 1 and %d characters long", s.cfg.DancerNameMaxLen)
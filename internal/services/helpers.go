@@ -4,6 +4,8 @@ import (
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/ofstudio/dancegobot/internal/models"
 )
 
 // nowFn returns the current time in UTC.
@@ -33,6 +35,43 @@ func getUsername(s string) (string, bool) {
 	return "", false
 }
 
+// isSame checks if dancer is the same as the other dancer on the event
+func isSame(dancer, other *models.Dancer) bool {
+	switch {
+	// Compare profile IDs if both profiles are present
+	case dancer.Profile != nil && other.Profile != nil:
+		return dancer.ID == other.Profile.ID
+	// Compare dancer username (if present in profile ) and other username (if present in full name)
+	case dancer.Profile != nil && dancer.Profile.Username != "" && other.Profile == nil:
+		u, ok := getUsername(other.FullName)
+		return ok && (dancer.Profile.Username == u)
+	// Compare dancer username (if present in full name) and other username (if present in profile)
+	case dancer.Profile == nil && other.Profile != nil && other.Profile.Username != "":
+		u, ok := getUsername(dancer.FullName)
+		return ok && (u == other.Profile.Username)
+	// Compare usernames (if present in full names) if both profiles are missing.
+	// Dancers without usernames are the same only if they are the same record of the event:
+	// the record is identified by the full name and the creation time.
+	case dancer.Profile == nil && other.Profile == nil:
+		u1, ok1 := getUsername(dancer.FullName)
+		u2, ok2 := getUsername(other.FullName)
+		return (ok1 && ok2) && (u1 == u2) ||
+			!dancer.CreatedAt.IsZero() && dancer.CreatedAt.Equal(other.CreatedAt) && dancer.FullName == other.FullName
+	default:
+		return false
+	}
+}
+
+// isListed checks if the dancer is in the given list of dancers.
+func isListed(dancer *models.Dancer, list []models.Dancer) bool {
+	for _, d := range list {
+		if isSame(dancer, &d) {
+			return true
+		}
+	}
+	return false
+}
+
 // errMap represents a map of errors.
 // It implements the error interface and used to collect multiple errors.
 type errMap map[string]error
@@ -2,12 +2,16 @@ package services
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"slices"
+	"time"
 
 	"github.com/ofstudio/dancegobot/internal/config"
 	"github.com/ofstudio/dancegobot/internal/models"
 	"github.com/ofstudio/dancegobot/internal/store"
 	"github.com/ofstudio/dancegobot/pkg/noplog"
+	"github.com/ofstudio/dancegobot/pkg/randtoken"
 	"github.com/ofstudio/dancegobot/pkg/trace"
 )
 
@@ -35,22 +39,78 @@ func (s *NotifierService) WithLogger(l *slog.Logger) *NotifierService {
 	return s
 }
 
+// Start starts the reminders and the activity digest schedulers.
+func (s *NotifierService) Start(ctx context.Context) {
+	go s.remindersScheduler(ctx)
+	go s.digestScheduler(ctx)
+}
+
 // Notify sends a notification to the user.
+// The alerts about the compatible singles are sent only to the users who opted in to them
+// and not more often than the configured throttle duration.
+// Notifications muted by the recipient preferences are not sent
+// but recorded in the history with the reason.
 func (s *NotifierService) Notify(ctx context.Context, n *models.Notification) {
+	if n.TmplCode == models.TmplSingleJoined && !s.singleAlertAllowed(ctx, n.Recipient.ID) {
+		s.log.Info("[notifier service] single alert skipped", "", n, trace.Attr(ctx))
+		return
+	}
+	if n.Suppressed = s.muted(ctx, n); n.Suppressed != "" {
+		s.log.Info("[notifier service] notification suppressed", "", n, slog.String("reason", n.Suppressed), trace.Attr(ctx))
+		s.historyInsert(ctx, models.HistoryNotificationSuppressed, n)
+		return
+	}
+
 	if err := s.do(n); err != nil {
 		s.log.Error("[notifier service] failed to send notification: "+err.Error(), trace.Attr(ctx))
 		n.Error = err.Error()
 	} else {
 		s.log.Info("[notifier service] notification sent", "", n, trace.Attr(ctx))
 	}
+	s.historyInsert(ctx, models.HistoryNotificationSent, n)
+}
+
+// muted loads the recipient preferences and returns the reason the notification is muted.
+// Returns empty string if the notification should be sent.
+func (s *NotifierService) muted(ctx context.Context, n *models.Notification) string {
+	if n.TmplCode.Category() == models.CategoryNone && (n.Payload.Event == nil || !n.TmplCode.EventMutable()) {
+		return ""
+	}
+	user, err := s.store.UserGet(ctx, n.Recipient.ID)
+	if errors.Is(err, store.ErrNotFound) {
+		return ""
+	}
+	if err != nil {
+		s.log.Error("[notifier service] failed to get recipient: "+err.Error(), trace.Attr(ctx))
+		return ""
+	}
+	return muteReason(&user.Settings.Notifications, n)
+}
 
-	// Insert history item
+// muteReason returns the reason the notification is muted by the preferences.
+// Returns empty string if the notification should be sent.
+// The muted event mutes all notifications about it except the consent requests.
+func muteReason(prefs *models.NotificationSettings, n *models.Notification) string {
+	category := n.TmplCode.Category()
+	if category != models.CategoryNone && slices.Contains(prefs.MutedCategories, category) {
+		return models.SuppressedCategory
+	}
+	if n.Payload.Event != nil && n.TmplCode.EventMutable() && slices.ContainsFunc(prefs.MutedEvents, func(e models.MutedEvent) bool {
+		return e.EventID == n.Payload.Event.ID
+	}) {
+		return models.SuppressedEvent
+	}
+	return ""
+}
+
+// historyInsert inserts the history item about the notification.
+func (s *NotifierService) historyInsert(ctx context.Context, action models.HistoryAction, n *models.Notification) {
 	var eventID *string
 	if n.Payload.Event != nil {
 		eventID = &n.Payload.Event.ID
 	}
 	h := &models.HistoryItem{
-		Action:    models.HistoryNotificationSent,
+		Action:    action,
 		Initiator: config.BotProfile(),
 		EventID:   eventID,
 		Details:   n,
@@ -60,3 +120,223 @@ func (s *NotifierService) Notify(ctx context.Context, n *models.Notification) {
 		s.log.Error("[notifier service] failed to insert history item: "+err.Error(), trace.Attr(ctx))
 	}
 }
+
+// singleAlertAllowed checks the current settings of the recipient and the alerts sent to them recently.
+func (s *NotifierService) singleAlertAllowed(ctx context.Context, profileID int64) bool {
+	user, err := s.store.UserGet(ctx, profileID)
+	if errors.Is(err, store.ErrNotFound) {
+		return false
+	}
+	if err != nil {
+		s.log.Error("[notifier service] failed to get recipient: "+err.Error(), trace.Attr(ctx))
+		return false
+	}
+	if !user.Settings.Dancer.SingleAlerts {
+		return false
+	}
+	sent, err := s.store.HistoryNotifiedAfter(ctx, profileID, models.TmplSingleJoined, nowFn().Add(-s.cfg.SingleAlertThrottle))
+	if err != nil {
+		s.log.Error("[notifier service] failed to check recent alerts: "+err.Error(), trace.Attr(ctx))
+		return false
+	}
+	return !sent
+}
+
+func (s *NotifierService) remindersScheduler(ctx context.Context) {
+	if s.cfg.ReminderEvery == 0 || len(s.cfg.ReminderOffsets) == 0 {
+		s.log.Info("[notifier service] reminders are disabled")
+		return
+	}
+
+	s.log.Info("[notifier service] starting reminders scheduler",
+		slog.Duration("interval", s.cfg.ReminderEvery),
+		slog.Any("offsets", s.cfg.ReminderOffsets))
+
+	ticker := time.NewTicker(s.cfg.ReminderEvery)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.remind(trace.Context(ctx, "reminders_"+randtoken.New(4)))
+		}
+	}
+}
+
+// remind sends the reminders to the dancers registered for the events starting soon.
+// Each dancer receives at most one reminder per offset.
+// The reminder is recorded before sending and the record is removed if the sending fails.
+func (s *NotifierService) remind(ctx context.Context) {
+	now := nowFn()
+	events, err := s.store.EventGetStartingWithin(ctx, now, now.Add(slices.Max(s.cfg.ReminderOffsets)))
+	if err != nil {
+		s.log.Error("[notifier service] failed to get events starting soon: "+err.Error(), trace.Attr(ctx))
+		return
+	}
+	for _, event := range events {
+		offset, ok := reminderOffset(event, s.cfg.ReminderOffsets, now)
+		if !ok {
+			continue
+		}
+		for _, n := range reminders(event) {
+			ok, err = s.store.ReminderInsert(ctx, event.ID, n.Recipient.ID, offset)
+			if err != nil {
+				s.log.Error("[notifier service] failed to insert reminder: "+err.Error(), trace.Attr(ctx))
+				continue
+			}
+			if !ok {
+				continue
+			}
+			s.Notify(ctx, n)
+			if n.Error == "" {
+				continue
+			}
+			// The reminder failed to send is retried on the next run
+			if err = s.store.ReminderRemove(ctx, event.ID, n.Recipient.ID, offset); err != nil {
+				s.log.Error("[notifier service] failed to remove reminder: "+err.Error(), trace.Attr(ctx))
+			}
+		}
+	}
+}
+
+// reminderOffset returns the smallest of the offsets that is already reached
+// for the event start time at the given moment.
+func reminderOffset(event *models.Event, offsets []time.Duration, now time.Time) (time.Duration, bool) {
+	if event.StartsAt == nil || !now.Before(*event.StartsAt) {
+		return 0, false
+	}
+	var result time.Duration
+	found := false
+	for _, offset := range offsets {
+		if now.Before(event.StartsAt.Add(-offset)) {
+			continue
+		}
+		if !found || offset < result {
+			result, found = offset, true
+		}
+	}
+	return result, found
+}
+
+// reminders returns the reminder notifications for all dancers with profiles
+// registered in couples, as singles and as participants of the solo event.
+func reminders(event *models.Event) []*models.Notification {
+	var result []*models.Notification
+	for _, couple := range event.Couples {
+		for _, pair := range [][2]*models.Dancer{
+			{&couple.Dancers[0], &couple.Dancers[1]},
+			{&couple.Dancers[1], &couple.Dancers[0]},
+		} {
+			if pair[0].Profile == nil {
+				continue
+			}
+			result = append(result, &models.Notification{
+				TmplCode:  models.TmplReminderCouple,
+				Recipient: pair[0].Profile,
+				Payload:   models.NotificationPayload{Event: event, Partner: pair[1]},
+			})
+		}
+	}
+	for _, single := range event.Singles {
+		if single.Profile == nil {
+			continue
+		}
+		result = append(result, &models.Notification{
+			TmplCode:  models.TmplReminderSingle,
+			Recipient: single.Profile,
+			Payload:   models.NotificationPayload{Event: event},
+		})
+	}
+	for _, participant := range event.Participants {
+		if participant.Profile == nil {
+			continue
+		}
+		result = append(result, &models.Notification{
+			TmplCode:  models.TmplReminderParticipant,
+			Recipient: participant.Profile,
+			Payload:   models.NotificationPayload{Event: event},
+		})
+	}
+	return result
+}
+
+func (s *NotifierService) digestScheduler(ctx context.Context) {
+	if s.cfg.DigestEvery == 0 {
+		s.log.Info("[notifier service] activity digest is disabled")
+		return
+	}
+
+	s.log.Info("[notifier service] starting activity digest scheduler",
+		slog.Duration("interval", s.cfg.DigestEvery),
+		slog.Duration("period", s.cfg.DigestPeriod))
+
+	ticker := time.NewTicker(s.cfg.DigestEvery)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.digest(trace.Context(ctx, "digest_"+randtoken.New(4)))
+		}
+	}
+}
+
+// digest sends the summary of the registration changes for the last digest period
+// to the event owners subscribed to the activity digest.
+// Each owner receives at most one digest per period, the empty digests are not sent.
+func (s *NotifierService) digest(ctx context.Context) {
+	now := nowFn()
+	after := now.Add(-s.cfg.DigestPeriod)
+	users, err := s.store.UserGetDigestDue(ctx, after)
+	if err != nil {
+		s.log.Error("[notifier service] failed to get users due for digest: "+err.Error(), trace.Attr(ctx))
+		return
+	}
+	for _, user := range users {
+		counts, err := s.store.HistoryCountByOwner(ctx, user.Profile.ID, after, now, activityActions)
+		if err != nil {
+			s.log.Error("[notifier service] failed to count history items: "+err.Error(), trace.Attr(ctx))
+			continue
+		}
+		if items := s.digestItems(ctx, counts); len(items) > 0 {
+			s.Notify(ctx, &models.Notification{
+				TmplCode:  models.TmplOwnerDigest,
+				Recipient: &user.Profile,
+				Payload:   models.NotificationPayload{Digest: items},
+			})
+		}
+		if err = s.store.DigestSentSet(ctx, user.Profile.ID, now); err != nil {
+			s.log.Error("[notifier service] failed to set digest sent time: "+err.Error(), trace.Attr(ctx))
+		}
+	}
+}
+
+// digestItems groups the history counts by events keeping the order of the events.
+// The events that are not found are skipped.
+func (s *NotifierService) digestItems(ctx context.Context, counts []models.HistoryCount) []models.DigestItem {
+	var items []models.DigestItem
+	idx := make(map[string]int)
+	for _, c := range counts {
+		i, ok := idx[c.EventID]
+		if !ok {
+			event, err := s.store.EventGet(ctx, c.EventID)
+			if err != nil {
+				s.log.Error("[notifier service] failed to get event for digest: "+err.Error(),
+					"event_id", c.EventID,
+					trace.Attr(ctx))
+				idx[c.EventID] = -1
+				continue
+			}
+			i = len(items)
+			idx[c.EventID] = i
+			items = append(items, models.DigestItem{Caption: event.Caption, Counts: map[models.HistoryAction]int{}})
+		}
+		if i < 0 {
+			continue
+		}
+		items[i].Counts[c.Action] = c.Count
+	}
+	return items
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ofstudio/dancegobot/internal/models"
+)
+
+func Test_reminderOffset(t *testing.T) {
+	offsets := []time.Duration{24 * time.Hour, 2 * time.Hour}
+	startsAt := time.Date(2025, 12, 31, 19, 30, 0, 0, time.UTC)
+	event := &models.Event{StartsAt: &startsAt}
+
+	tests := []struct {
+		name   string
+		now    time.Time
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "too early", now: startsAt.Add(-25 * time.Hour)},
+		{name: "first offset", now: startsAt.Add(-24 * time.Hour), want: 24 * time.Hour, wantOK: true},
+		{name: "between offsets", now: startsAt.Add(-10 * time.Hour), want: 24 * time.Hour, wantOK: true},
+		{name: "second offset", now: startsAt.Add(-1 * time.Hour), want: 2 * time.Hour, wantOK: true},
+		{name: "already started", now: startsAt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := reminderOffset(event, offsets, tt.now)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("no start time", func(t *testing.T) {
+		_, ok := reminderOffset(&models.Event{}, offsets, startsAt)
+		assert.False(t, ok)
+	})
+}
+
+func Test_reminders(t *testing.T) {
+	event := sampleEvent()
+	got := reminders(&event)
+
+	// "@jillsmith" has no profile and is not reminded
+	require.Len(t, got, 5)
+	for i, want := range []struct {
+		tmpl      models.NotificationTmpl
+		recipient int64
+		partner   int64
+	}{
+		{models.TmplReminderCouple, 1, 2},
+		{models.TmplReminderCouple, 2, 1},
+		{models.TmplReminderCouple, 3, 0},
+		{models.TmplReminderSingle, 4, 0},
+		{models.TmplReminderSingle, 5, 0},
+	} {
+		assert.Equal(t, want.tmpl, got[i].TmplCode)
+		assert.Equal(t, want.recipient, got[i].Recipient.ID)
+		assert.Equal(t, &event, got[i].Payload.Event)
+		if want.partner != 0 {
+			assert.Equal(t, want.partner, got[i].Payload.Partner.Profile.ID)
+		}
+	}
+	assert.Equal(t, "@jillsmith", got[2].Payload.Partner.FullName)
+}
+
+func Test_muteReason(t *testing.T) {
+	event := &models.Event{ID: "event-1"}
+	prefs := &models.NotificationSettings{
+		MutedCategories: []models.NotificationCategory{models.CategoryReminder},
+		MutedEvents:     []models.MutedEvent{{EventID: "event-1", Title: "Event"}},
+	}
+
+	tests := []struct {
+		name  string
+		tmpl  models.NotificationTmpl
+		event *models.Event
+		want  string
+	}{
+		{name: "muted category", tmpl: models.TmplReminderCouple, event: event, want: models.SuppressedCategory},
+		{name: "muted event", tmpl: models.TmplPartnerReplaced, event: event, want: models.SuppressedEvent},
+		{name: "other event", tmpl: models.TmplPartnerReplaced, event: &models.Event{ID: "event-2"}},
+		{name: "no event", tmpl: models.TmplAutoPairPartnerFound},
+		{name: "muted event without category", tmpl: models.TmplConsentAccepted, event: event, want: models.SuppressedEvent},
+		{name: "muted event, singles", tmpl: models.TmplSingleJoined, event: event, want: models.SuppressedEvent},
+		{name: "consent request", tmpl: models.TmplConsentRequest, event: event},
+		{name: "no category, no event", tmpl: models.TmplOwnerDigest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &models.Notification{TmplCode: tt.tmpl, Payload: models.NotificationPayload{Event: tt.event}}
+			assert.Equal(t, tt.want, muteReason(prefs, n))
+		})
+	}
+
+	t.Run("no preferences", func(t *testing.T) {
+		n := &models.Notification{TmplCode: models.TmplReminderCouple, Payload: models.NotificationPayload{Event: event}}
+		assert.Empty(t, muteReason(&models.NotificationSettings{}, n))
+	})
+}
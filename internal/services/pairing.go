@@ -0,0 +1,117 @@
+package services
+
+import (
+	"math/rand/v2"
+	"slices"
+
+	"github.com/ofstudio/dancegobot/internal/models"
+)
+
+// PairingStrategy chooses a partner for the dancer on auto pairing.
+type PairingStrategy interface {
+	// Choose returns the index of the partner chosen from the candidates
+	// or -1 if no candidate fits. Candidates are the singles who can dance with the dancer
+	// sorted by the creation time.
+	Choose(dancer *models.Dancer, candidates []models.Dancer) int
+}
+
+// FIFOPairing chooses the single who signed up first.
+type FIFOPairing struct{}
+
+func (FIFOPairing) Choose(_ *models.Dancer, candidates []models.Dancer) int {
+	if len(candidates) == 0 {
+		return -1
+	}
+	return 0
+}
+
+// RandomPairing chooses a random single.
+type RandomPairing struct {
+	Rand *rand.Rand
+}
+
+func (p RandomPairing) Choose(_ *models.Dancer, candidates []models.Dancer) int {
+	if len(candidates) == 0 {
+		return -1
+	}
+	return p.Rand.IntN(len(candidates))
+}
+
+// RecentPartnersPairing chooses the first single who was not a recent partner of the dancer.
+// If all the candidates are recent partners, chooses the single who signed up first.
+type RecentPartnersPairing struct {
+	Recent map[int64][]int64 // Recent partners profile IDs by the dancer profile ID
+}
+
+func (p RecentPartnersPairing) Choose(dancer *models.Dancer, candidates []models.Dancer) int {
+	if len(candidates) == 0 {
+		return -1
+	}
+	if dancer.Profile == nil {
+		return 0
+	}
+	for i, c := range candidates {
+		if c.Profile == nil || !slices.Contains(p.Recent[dancer.Profile.ID], c.Profile.ID) {
+			return i
+		}
+	}
+	return 0
+}
+
+// recentPartners collects the partners of the dancers from the couples of the given events.
+// Returns the map of the partners profile IDs by the dancer profile ID.
+func recentPartners(events []*models.Event) map[int64][]int64 {
+	recent := make(map[int64][]int64)
+	for _, event := range events {
+		for _, couple := range event.Couples {
+			l, f := couple.Dancers[0].Profile, couple.Dancers[1].Profile
+			if l == nil || f == nil {
+				continue
+			}
+			recent[l.ID] = append(recent[l.ID], f.ID)
+			recent[f.ID] = append(recent[f.ID], l.ID)
+		}
+	}
+	return recent
+}
+
+// LevelPairing chooses the single with the closest dance level.
+// The singles matching the partner level preferences go first.
+// The singles without level go after the singles with known level.
+type LevelPairing struct{}
+
+func (LevelPairing) Choose(dancer *models.Dancer, candidates []models.Dancer) int {
+	best, bestScore := -1, 0
+	for i := range candidates {
+		score := levelScore(dancer, &candidates[i])
+		if best < 0 || score < bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best
+}
+
+// levelScore returns the pairing score of the dancers: the lower the better.
+// The score is the distance between the levels of the dancers
+// plus the penalty for each unmatched partner level preference.
+func levelScore(a, b *models.Dancer) int {
+	penalty := len(models.Levels) + 1
+	score := len(models.Levels)
+	if a.Level.Rank() > 0 && b.Level.Rank() > 0 {
+		score = abs(a.Level.Rank() - b.Level.Rank())
+	}
+	if a.PartnerLevel != models.LevelNone && a.PartnerLevel != b.Level {
+		score += penalty
+	}
+	if b.PartnerLevel != models.LevelNone && b.PartnerLevel != a.Level {
+		score += penalty
+	}
+	return score
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -5,11 +5,16 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/ofstudio/dancegobot/internal/config"
 	"github.com/ofstudio/dancegobot/internal/models"
 	"github.com/ofstudio/dancegobot/internal/store"
 	"github.com/ofstudio/dancegobot/pkg/noplog"
+	"github.com/ofstudio/dancegobot/pkg/randtoken"
+	"github.com/ofstudio/dancegobot/pkg/trace"
 )
 
 // UserService is a service that manages users.
@@ -52,3 +57,160 @@ func (s *UserService) Upsert(ctx context.Context, user *models.User) error {
 	}
 	return nil
 }
+
+// BlocklistAdd adds the person to the user blocklist.
+// The person can be either specified by a profile or a string with @username.
+// Returns [models.ErrUsernameRequired] if the string does not contain @username.
+// Dancers from the blocklist are forbidden to sign in for all events of the user.
+func (s *UserService) BlocklistAdd(ctx context.Context, user *models.User, other any) error {
+	var dancer models.Dancer
+	switch v := other.(type) {
+	case *models.Profile:
+		if v.ID < 1 {
+			return fmt.Errorf("profile ID must be positive")
+		}
+		dancer = models.Dancer{Profile: v, FullName: v.FullName()}
+	case string:
+		username, ok := getUsername(v)
+		if !ok {
+			return models.ErrUsernameRequired
+		}
+		dancer = models.Dancer{FullName: "@" + username}
+	default:
+		return fmt.Errorf("invalid type of other person: %T", other)
+	}
+
+	if isListed(&dancer, user.Settings.Blocklist) {
+		return nil
+	}
+	dancer.CreatedAt = nowFn()
+	user.Settings.Blocklist = append(user.Settings.Blocklist, dancer)
+	if err := s.Upsert(ctx, user); err != nil {
+		return err
+	}
+
+	s.historyInsert(ctx, &models.HistoryItem{
+		Action:    models.HistoryBlocklistAdded,
+		Initiator: &user.Profile,
+		Details:   &dancer,
+		CreatedAt: nowFn(),
+	})
+	return nil
+}
+
+// BlocklistRemove removes the dancer with the given key from the user blocklist.
+func (s *UserService) BlocklistRemove(ctx context.Context, user *models.User, key string) error {
+	i := -1
+	for j, d := range user.Settings.Blocklist {
+		if d.Key() == key {
+			i = j
+			break
+		}
+	}
+	if i < 0 {
+		return fmt.Errorf("blocklist dancer not found: %s", key)
+	}
+	dancer := user.Settings.Blocklist[i]
+	user.Settings.Blocklist = append(user.Settings.Blocklist[:i], user.Settings.Blocklist[i+1:]...)
+	if err := s.Upsert(ctx, user); err != nil {
+		return err
+	}
+
+	s.historyInsert(ctx, &models.HistoryItem{
+		Action:    models.HistoryBlocklistRemoved,
+		Initiator: &user.Profile,
+		Details:   &dancer,
+		CreatedAt: nowFn(),
+	})
+	return nil
+}
+
+// TemplateAdd adds the named event template to the user settings.
+// The template with the same name is replaced and keeps its ID.
+// Returns [models.ErrTemplateName] if the name is empty or too long
+// and [models.ErrTemplatesLimit] if the user has reached the maximum number of templates.
+func (s *UserService) TemplateAdd(ctx context.Context, user *models.User, tmpl models.EventTemplate) error {
+	tmpl.Name = strings.TrimSpace(tmpl.Name)
+	if tmpl.Name == "" || utf8.RuneCountInString(tmpl.Name) > s.cfg.TemplateNameMaxLen {
+		return models.ErrTemplateName
+	}
+
+	i := slices.IndexFunc(user.Settings.Templates, func(t models.EventTemplate) bool {
+		return t.Name == tmpl.Name
+	})
+	switch {
+	case i >= 0:
+		tmpl.ID = user.Settings.Templates[i].ID
+		user.Settings.Templates[i] = tmpl
+	case len(user.Settings.Templates) >= s.cfg.TemplatesMax:
+		return models.ErrTemplatesLimit
+	default:
+		tmpl.ID = randtoken.New(s.cfg.TemplateIDLen)
+		user.Settings.Templates = append(user.Settings.Templates, tmpl)
+	}
+	if err := s.Upsert(ctx, user); err != nil {
+		return err
+	}
+
+	s.historyInsert(ctx, &models.HistoryItem{
+		Action:    models.HistoryTemplateAdded,
+		Initiator: &user.Profile,
+		Details:   &tmpl,
+		CreatedAt: nowFn(),
+	})
+	return nil
+}
+
+// TemplateRemove removes the event template with the given ID from the user settings.
+func (s *UserService) TemplateRemove(ctx context.Context, user *models.User, id string) error {
+	i := slices.IndexFunc(user.Settings.Templates, func(t models.EventTemplate) bool {
+		return t.ID == id
+	})
+	if i < 0 {
+		return fmt.Errorf("template not found: %s", id)
+	}
+	tmpl := user.Settings.Templates[i]
+	user.Settings.Templates = append(user.Settings.Templates[:i], user.Settings.Templates[i+1:]...)
+	if err := s.Upsert(ctx, user); err != nil {
+		return err
+	}
+
+	s.historyInsert(ctx, &models.HistoryItem{
+		Action:    models.HistoryTemplateRemoved,
+		Initiator: &user.Profile,
+		Details:   &tmpl,
+		CreatedAt: nowFn(),
+	})
+	return nil
+}
+
+// EventMute mutes the notifications about the event for the user.
+// Does nothing if the event is already muted.
+func (s *UserService) EventMute(ctx context.Context, user *models.User, eventID, title string) error {
+	prefs := &user.Settings.Notifications
+	if slices.ContainsFunc(prefs.MutedEvents, func(e models.MutedEvent) bool { return e.EventID == eventID }) {
+		return nil
+	}
+	prefs.MutedEvents = append(prefs.MutedEvents, models.MutedEvent{EventID: eventID, Title: title})
+	return s.Upsert(ctx, user)
+}
+
+// EventUnmute unmutes the event with the given ID in the user notification preferences.
+func (s *UserService) EventUnmute(ctx context.Context, user *models.User, eventID string) error {
+	prefs := &user.Settings.Notifications
+	i := slices.IndexFunc(prefs.MutedEvents, func(e models.MutedEvent) bool {
+		return e.EventID == eventID
+	})
+	if i < 0 {
+		return fmt.Errorf("muted event not found: %s", eventID)
+	}
+	prefs.MutedEvents = append(prefs.MutedEvents[:i], prefs.MutedEvents[i+1:]...)
+	return s.Upsert(ctx, user)
+}
+
+// historyInsert inserts a history item.
+func (s *UserService) historyInsert(ctx context.Context, item *models.HistoryItem) {
+	if err := s.store.HistoryInsert(ctx, item); err != nil {
+		s.log.Error("[user service] failed to insert history item: "+err.Error(), trace.Attr(ctx))
+	}
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ofstudio/dancegobot/internal/models"
+)
+
+// DigestSentSet records the time the activity digest was sent to the profile.
+func (s *SQLiteStore) DigestSentSet(ctx context.Context, profileID int64, sentAt time.Time) error {
+	const query =
+	// language=SQLite
+	`INSERT INTO digests (profile_id, sent_at)
+VALUES (?1, datetime(?2, 'unixepoch'))
+ON CONFLICT (profile_id) DO UPDATE SET sent_at = excluded.sent_at;`
+	stmt, err := s.stmt(ctx, query)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrStmtPrepare, err)
+	}
+
+	if _, err = stmt.ExecContext(ctx, profileID, sentAt.Unix()); err != nil {
+		return fmt.Errorf("%w: %w", ErrStmtExec, err)
+	}
+
+	return nil
+}
+
+// UserGetDigestDue returns the users subscribed to the activity digest
+// who have not received it yet or received it before or at the specified time.
+func (s *SQLiteStore) UserGetDigestDue(ctx context.Context, sentBefore time.Time) ([]*models.User, error) {
+	const query =
+	// language=SQLite
+	`SELECT u.profile, u.session, u.settings, u.created_at, u.updated_at
+FROM users u
+         LEFT JOIN digests d ON d.profile_id = u.id
+WHERE json_extract(u.settings, '$.owner_alerts') = ?2
+  AND (d.sent_at IS NULL OR unixepoch(d.sent_at) <= ?1)`
+	stmt, err := s.stmt(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStmtPrepare, err)
+	}
+
+	rows, err := stmt.QueryxContext(ctx, sentBefore.Unix(), models.OwnerAlertsDigest)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStmtExec, err)
+	}
+	//goland:noinspection ALL
+	defer rows.Close()
+
+	var users []*models.User
+	for rows.Next() {
+		var row userRow
+		if err = rows.StructScan(&row); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrStmtExec, err)
+		}
+		user := &models.User{}
+		if err = s.userUnmarshalRow(row, user); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
@@ -0,0 +1,44 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/ofstudio/dancegobot/internal/models"
+)
+
+func (suite *TestStoreSuite) TestUserGetDigestDue() {
+	suite.Run("success", func() {
+		db, err := NewSQLite(":memory:", 4)
+		suite.Require().NoError(err)
+		store := NewSQLiteStore(db)
+		defer store.Close()
+
+		ctx := context.Background()
+		for id, alerts := range map[int64]models.OwnerAlerts{
+			1: models.OwnerAlertsDigest,
+			2: models.OwnerAlertsDigest,
+			3: models.OwnerAlertsRealtime,
+			4: models.OwnerAlertsDigest,
+		} {
+			user := &models.User{Profile: models.Profile{ID: id}}
+			user.Settings.OwnerAlerts = alerts
+			suite.Require().NoError(store.UserUpsert(ctx, user))
+		}
+		sentBefore := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		suite.Require().NoError(store.DigestSentSet(ctx, 2, sentBefore.Add(-time.Hour)))
+		suite.Require().NoError(store.DigestSentSet(ctx, 4, sentBefore.Add(time.Hour))) // sent recently
+
+		users, err := store.UserGetDigestDue(ctx, sentBefore)
+		suite.Require().NoError(err)
+		suite.Require().Len(users, 2)
+		suite.ElementsMatch([]int64{1, 2}, []int64{users[0].Profile.ID, users[1].Profile.ID})
+
+		// sent time is updated
+		suite.Require().NoError(store.DigestSentSet(ctx, 2, sentBefore.Add(time.Hour)))
+		users, err = store.UserGetDigestDue(ctx, sentBefore)
+		suite.Require().NoError(err)
+		suite.Require().Len(users, 1)
+		suite.Equal(int64(1), users[0].Profile.ID)
+	})
+}
@@ -68,46 +68,116 @@ func (s *SQLiteStore) EventGetUpdatedAfter(ctx context.Context, after time.Time)
 FROM events
 WHERE updated_at > ?1
   AND json_extract(data, '$.post.inline_message_id') IS NOT NULL`
-	stmt, err := s.stmt(ctx, query)
-	if err != nil {
-		return nil, fmt.Errorf("%w: %w", ErrStmtPrepare, err)
-	}
 
-	rows, err := stmt.QueryxContext(ctx, after)
-	if err != nil {
-		return nil, fmt.Errorf("%w: %w", ErrStmtExec, err)
-	}
-	//goland:noinspection ALL
-	defer rows.Close()
+	return s.eventSelect(ctx, query, after)
+}
 
-	var events []*models.Event
-	for rows.Next() {
-		var data []byte
-		if err = rows.Scan(&data); err != nil {
-			return nil, fmt.Errorf("%w: %w", ErrStmtExec, err)
-		}
+// EventGetByManager returns non-draft events where the profile is the owner or a co-organizer
+// ordered from the newest to the oldest.
+func (s *SQLiteStore) EventGetByManager(ctx context.Context, profileID int64, limit, offset int) ([]*models.Event, error) {
+	// language=SQLite
+	const query = `SELECT data
+FROM events
+WHERE (owner_id = ?1 OR EXISTS (SELECT 1
+                                FROM json_each(data, '$.co_organizers')
+                                WHERE json_extract(value, '$.id') = ?1))
+  AND json_extract(data, '$.post.inline_message_id') IS NOT NULL
+ORDER BY created_at DESC, rowid DESC
+LIMIT ?2 OFFSET ?3`
+
+	return s.eventSelect(ctx, query, profileID, limit, offset)
+}
 
-		event := &models.Event{}
-		if err = json.Unmarshal(data, event); err != nil {
-			return nil, fmt.Errorf("%w: %w", ErrUnmarshal, err)
-		}
+// EventGetStartingBefore returns non-draft events that start before the specified time
+// and were not closed automatically yet.
+func (s *SQLiteStore) EventGetStartingBefore(ctx context.Context, before time.Time) ([]*models.Event, error) {
+	// language=SQLite
+	const query = `SELECT data
+FROM events
+WHERE unixepoch(json_extract(data, '$.starts_at')) <= ?1
+  AND json_extract(data, '$.auto_closed_at') IS NULL
+  AND json_extract(data, '$.post.inline_message_id') IS NOT NULL`
 
-		events = append(events, event)
-	}
+	return s.eventSelect(ctx, query, before.Unix())
+}
 
-	return events, nil
+// EventGetStartingWithin returns non-draft events that start after the first time
+// and before or at the second time.
+func (s *SQLiteStore) EventGetStartingWithin(ctx context.Context, after, before time.Time) ([]*models.Event, error) {
+	// language=SQLite
+	const query = `SELECT data
+FROM events
+WHERE unixepoch(json_extract(data, '$.starts_at')) > ?1
+  AND unixepoch(json_extract(data, '$.starts_at')) <= ?2
+  AND json_extract(data, '$.post.inline_message_id') IS NOT NULL`
+
+	return s.eventSelect(ctx, query, after.Unix(), before.Unix())
+}
+
+// EventGetRecurringBefore returns non-draft recurring events that start before the specified time
+// and were not cloned yet.
+func (s *SQLiteStore) EventGetRecurringBefore(ctx context.Context, before time.Time) ([]*models.Event, error) {
+	// language=SQLite
+	const query = `SELECT data
+FROM events
+WHERE unixepoch(json_extract(data, '$.starts_at')) <= ?1
+  AND ifnull(json_extract(data, '$.recurrence'), '') != ''
+  AND ifnull(json_extract(data, '$.next_id'), '') = ''
+  AND json_extract(data, '$.post.inline_message_id') IS NOT NULL`
+
+	return s.eventSelect(ctx, query, before.Unix())
+}
+
+// EventGetPendingBefore returns non-draft events with the couples waiting for the partner confirmation
+// created before or at the specified time.
+func (s *SQLiteStore) EventGetPendingBefore(ctx context.Context, before time.Time) ([]*models.Event, error) {
+	// language=SQLite
+	const query = `SELECT data
+FROM events
+WHERE EXISTS (SELECT 1
+              FROM json_each(data, '$.pending')
+              WHERE unixepoch(json_extract(value, '$.created_at')) <= ?1)
+  AND json_extract(data, '$.post.inline_message_id') IS NOT NULL`
+
+	return s.eventSelect(ctx, query, before.Unix())
+}
+
+// EventGetSinglesStale returns non-draft events with the singles registered or refreshed longer than
+// the event single TTL ago at the specified time, which were not warned yet or were warned before
+// or at the specified time. Events started before the specified time or closed for all are skipped.
+func (s *SQLiteStore) EventGetSinglesStale(ctx context.Context, now, warnedBefore time.Time) ([]*models.Event, error) {
+	// language=SQLite
+	const query = `SELECT data
+FROM events
+WHERE ifnull(json_extract(data, '$.settings.single_ttl'), 0) > 0
+  AND ifnull(json_extract(data, '$.settings.closed_for'), '') != 'all'
+  AND ifnull(unixepoch(json_extract(data, '$.starts_at')), ?1 + 1) > ?1
+  AND EXISTS (SELECT 1
+              FROM json_each(data, '$.singles')
+              WHERE ifnull(unixepoch(json_extract(value, '$.refreshed_at')), unixepoch(json_extract(value, '$.created_at')))
+                        + json_extract(events.data, '$.settings.single_ttl') / 1000000000 <= ?1
+                AND ifnull(unixepoch(json_extract(value, '$.warned_at')), 0) <= ?2)
+  AND json_extract(data, '$.post.inline_message_id') IS NOT NULL`
+
+	return s.eventSelect(ctx, query, now.Unix(), warnedBefore.Unix())
 }
 
 // EventRemoveDraftsBefore removes all draft events updated before the specified time.
+// Drafts with the start time, like the next recurring events, are removed
+// only if they start before the specified time, even if they have dancers carried over.
 // Returns the ids of the removed events.
 func (s *SQLiteStore) EventRemoveDraftsBefore(ctx context.Context, before time.Time) ([]string, error) {
 	// language=SQLite
 	const query = `DELETE
 FROM events
-WHERE updated_at < ?1
-  AND json_extract(data, '$.post.inline_message_id') IS NULL
-  AND ifnull(json_array_length(data, '$.couples'), 0) = 0
-  AND ifnull(json_array_length(data, '$.singles'), 0) = 0
+WHERE json_extract(data, '$.post.inline_message_id') IS NULL
+  AND CASE
+          WHEN json_extract(data, '$.starts_at') IS NULL
+              THEN updated_at < ?1
+              AND ifnull(json_array_length(data, '$.couples'), 0) = 0
+              AND ifnull(json_array_length(data, '$.singles'), 0) = 0
+          ELSE unixepoch(json_extract(data, '$.starts_at')) < ?2
+      END
 RETURNING id;`
 
 	stmt, err := s.stmt(ctx, query)
@@ -115,7 +185,7 @@ RETURNING id;`
 		return nil, fmt.Errorf("%w: %w", ErrStmtPrepare, err)
 	}
 
-	rows, err := stmt.QueryxContext(ctx, before)
+	rows, err := stmt.QueryxContext(ctx, before, before.Unix())
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrStmtExec, err)
 	}
@@ -133,3 +203,32 @@ RETURNING id;`
 
 	return ids, nil
 }
+
+// eventSelect executes the query that selects events data and returns the list of events.
+func (s *SQLiteStore) eventSelect(ctx context.Context, query string, args ...any) ([]*models.Event, error) {
+	stmt, err := s.stmt(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStmtPrepare, err)
+	}
+
+	rows, err := stmt.QueryxContext(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStmtExec, err)
+	}
+	//goland:noinspection ALL
+	defer rows.Close()
+
+	var events []*models.Event
+	for rows.Next() {
+		var data []byte
+		if err = rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrStmtExec, err)
+		}
+		event := &models.Event{}
+		if err = json.Unmarshal(data, event); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrUnmarshal, err)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
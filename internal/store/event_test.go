@@ -143,4 +143,181 @@ VALUES ('abc', 1, '{"id": "abc", "post": {"inline_message_id": "qwe"} }', '2021-
 		suite.Contains(idsFromDB, "xxx")
 		suite.Contains(idsFromDB, "yyy")
 	})
+
+	suite.Run("drafts with start time", func() {
+		_, err := suite.store.db.Exec(`
+INSERT INTO events (id, owner_id, data, updated_at)
+VALUES ('abc', 1, '{"id": "abc", "starts_at": "2021-01-06T19:00:00Z" }', '2021-01-01 00:00:00'),                   -- This should NOT be removed
+       ('def', 1, '{"id": "def", "starts_at": "2021-01-04T19:00:00Z", "couples": [1,2] }', '2021-01-04 12:00:00'), -- This should BE removed
+       ('ghi', 1, '{"id": "ghi", "starts_at": "2021-01-04T19:00:00Z", "post": {"inline_message_id": "qwe"} }', '2021-01-01 00:00:00') -- This should NOT be removed
+`)
+		suite.Require().NoError(err)
+
+		ids, err := suite.store.EventRemoveDraftsBefore(
+			context.Background(),
+			time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+		)
+		suite.Require().NoError(err)
+		suite.Equal([]string{"def"}, ids)
+	})
+}
+
+func (suite *TestStoreSuite) TestEventGetByManager() {
+	suite.Run("success", func() {
+		_, err := suite.store.db.Exec(`
+INSERT INTO events (id, owner_id, data, created_at)
+VALUES ('abc', 1, '{"id": "abc", "post": {"inline_message_id": "qwe"} }', '2021-01-01 00:00:00'),
+       ('def', 1, '{"id": "def", "post": {"inline_message_id": "rty"} }', '2021-01-03 00:00:00'),
+       ('ghi', 1, '{"id": "ghi"}', '2021-01-04 00:00:00'),                                        -- draft
+       ('jkl', 2, '{"id": "jkl", "post": {"inline_message_id": "uio"} }', '2021-01-05 00:00:00'), -- another owner
+       ('mno', 1, '{"id": "mno", "post": {"inline_message_id": "asd"} }', '2021-01-02 00:00:00')
+`)
+		suite.Require().NoError(err)
+
+		events, err := suite.store.EventGetByManager(context.Background(), 1, 2, 0)
+		suite.Require().NoError(err)
+		suite.Require().Len(events, 2)
+		suite.Equal("def", events[0].ID)
+		suite.Equal("mno", events[1].ID)
+
+		events, err = suite.store.EventGetByManager(context.Background(), 1, 2, 2)
+		suite.Require().NoError(err)
+		suite.Require().Len(events, 1)
+		suite.Equal("abc", events[0].ID)
+	})
+
+	suite.Run("co-organizer", func() {
+		_, err := suite.store.db.Exec(`
+INSERT INTO events (id, owner_id, data, created_at)
+VALUES ('abc', 1, '{"id": "abc", "post": {"inline_message_id": "qwe"}, "co_organizers": [{"id": 3}, {"id": 2}] }', '2021-01-01 00:00:00'),
+       ('def', 1, '{"id": "def", "post": {"inline_message_id": "rty"}, "co_organizers": [{"id": 3}] }', '2021-01-03 00:00:00'),
+       ('ghi', 1, '{"id": "ghi", "co_organizers": [{"id": 2}] }', '2021-01-04 00:00:00'), -- draft
+       ('jkl', 2, '{"id": "jkl", "post": {"inline_message_id": "uio"} }', '2021-01-05 00:00:00')
+`)
+		suite.Require().NoError(err)
+
+		events, err := suite.store.EventGetByManager(context.Background(), 2, 10, 0)
+		suite.Require().NoError(err)
+		suite.Require().Len(events, 2)
+		suite.Equal("jkl", events[0].ID)
+		suite.Equal("abc", events[1].ID)
+	})
+
+	suite.Run("no events", func() {
+		events, err := suite.store.EventGetByManager(context.Background(), 1, 10, 0)
+		suite.Require().NoError(err)
+		suite.Empty(events)
+	})
+}
+
+func (suite *TestStoreSuite) TestEventGetStartingBefore() {
+	suite.Run("success", func() {
+		_, err := suite.store.db.Exec(`
+INSERT INTO events (id, owner_id, data)
+VALUES ('abc', 1, '{"id": "abc", "post": {"inline_message_id": "qwe"}, "starts_at": "2025-12-31T19:30:00+03:00" }'),
+       ('def', 1, '{"id": "def", "post": {"inline_message_id": "rty"}, "starts_at": "2025-12-31T18:00:00+01:00" }'), -- later
+       ('ghi', 1, '{"id": "ghi", "starts_at": "2025-12-31T12:00:00+03:00" }'),                                      -- draft
+       ('jkl', 1, '{"id": "jkl", "post": {"inline_message_id": "uio"}, "starts_at": "2025-12-31T12:00:00+03:00",
+                    "auto_closed_at": "2025-12-31T11:00:00+03:00" }'),                                           -- already closed
+       ('mno', 1, '{"id": "mno", "post": {"inline_message_id": "asd"} }')                                          -- no start time
+`)
+		suite.Require().NoError(err)
+
+		before := time.Date(2025, 12, 31, 16, 30, 0, 0, time.UTC)
+		events, err := suite.store.EventGetStartingBefore(context.Background(), before)
+		suite.Require().NoError(err)
+		suite.Require().Len(events, 1)
+		suite.Equal("abc", events[0].ID)
+	})
+
+	suite.Run("no events", func() {
+		events, err := suite.store.EventGetStartingBefore(context.Background(), time.Now())
+		suite.Require().NoError(err)
+		suite.Empty(events)
+	})
+}
+
+func (suite *TestStoreSuite) TestEventGetStartingWithin() {
+	suite.Run("success", func() {
+		_, err := suite.store.db.Exec(`
+INSERT INTO events (id, owner_id, data)
+VALUES ('abc', 1, '{"id": "abc", "post": {"inline_message_id": "qwe"}, "starts_at": "2025-12-31T19:30:00+03:00" }'),
+       ('def', 1, '{"id": "def", "post": {"inline_message_id": "rty"}, "starts_at": "2025-12-31T12:00:00+01:00" }'), -- started
+       ('ghi', 1, '{"id": "ghi", "starts_at": "2025-12-31T19:30:00+03:00" }'),                                      -- draft
+       ('jkl', 1, '{"id": "jkl", "post": {"inline_message_id": "uio"}, "starts_at": "2026-01-02T19:30:00+03:00" }'), -- later
+       ('mno', 1, '{"id": "mno", "post": {"inline_message_id": "asd"} }')                                          -- no start time
+`)
+		suite.Require().NoError(err)
+
+		now := time.Date(2025, 12, 31, 12, 0, 0, 0, time.UTC)
+		events, err := suite.store.EventGetStartingWithin(context.Background(), now, now.Add(24*time.Hour))
+		suite.Require().NoError(err)
+		suite.Require().Len(events, 1)
+		suite.Equal("abc", events[0].ID)
+	})
+}
+
+func (suite *TestStoreSuite) TestEventGetRecurringBefore() {
+	suite.Run("success", func() {
+		_, err := suite.store.db.Exec(`
+INSERT INTO events (id, owner_id, data)
+VALUES ('abc', 1, '{"id": "abc", "post": {"inline_message_id": "qwe"}, "starts_at": "2025-12-31T19:30:00+03:00", "recurrence": "weekly" }'),
+       ('def', 1, '{"id": "def", "post": {"inline_message_id": "rty"}, "starts_at": "2025-12-31T19:30:00+03:00" }'),                         -- not recurring
+       ('ghi', 1, '{"id": "ghi", "starts_at": "2025-12-31T19:30:00+03:00", "recurrence": "weekly" }'),                                      -- draft
+       ('jkl', 1, '{"id": "jkl", "post": {"inline_message_id": "uio"}, "starts_at": "2025-12-31T19:30:00+03:00", "recurrence": "weekly",
+                    "next_id": "mno" }'),                                                                                                 -- already cloned
+       ('mno', 1, '{"id": "mno", "post": {"inline_message_id": "asd"}, "starts_at": "2026-01-07T19:30:00+03:00", "recurrence": "weekly" }') -- later
+`)
+		suite.Require().NoError(err)
+
+		now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		events, err := suite.store.EventGetRecurringBefore(context.Background(), now)
+		suite.Require().NoError(err)
+		suite.Require().Len(events, 1)
+		suite.Equal("abc", events[0].ID)
+	})
+}
+
+func (suite *TestStoreSuite) TestEventGetPendingBefore() {
+	suite.Run("success", func() {
+		_, err := suite.store.db.Exec(`
+INSERT INTO events (id, owner_id, data)
+VALUES ('abc', 1, '{"id": "abc", "post": {"inline_message_id": "qwe"}, "pending": [{"created_at": "2025-12-31T10:00:00+03:00"}] }'),
+       ('def', 1, '{"id": "def", "post": {"inline_message_id": "rty"}, "pending": [{"created_at": "2025-12-31T12:00:00+03:00"}] }'), -- later
+       ('ghi', 1, '{"id": "ghi", "pending": [{"created_at": "2025-12-31T10:00:00+03:00"}] }'),                                      -- draft
+       ('jkl', 1, '{"id": "jkl", "post": {"inline_message_id": "uio"} }')                                                          -- no pending
+`)
+		suite.Require().NoError(err)
+
+		before := time.Date(2025, 12, 31, 8, 0, 0, 0, time.UTC)
+		events, err := suite.store.EventGetPendingBefore(context.Background(), before)
+		suite.Require().NoError(err)
+		suite.Require().Len(events, 1)
+		suite.Equal("abc", events[0].ID)
+	})
+}
+
+func (suite *TestStoreSuite) TestEventGetSinglesStale() {
+	suite.Run("success", func() {
+		_, err := suite.store.db.Exec(`
+INSERT INTO events (id, owner_id, data)
+VALUES ('abc', 1, '{"id": "abc", "post": {"inline_message_id": "qwe"}, "settings": {"single_ttl": 3600000000000}, "singles": [{"created_at": "2025-12-31T10:00:00+03:00"}] }'),
+       ('def', 1, '{"id": "def", "post": {"inline_message_id": "rty"}, "settings": {"single_ttl": 3600000000000}, "singles": [{"created_at": "2025-12-31T12:00:00+03:00"}] }'),                                             -- later
+       ('ghi', 1, '{"id": "ghi", "settings": {"single_ttl": 3600000000000}, "singles": [{"created_at": "2025-12-31T10:00:00+03:00"}] }'),                                                                             -- draft
+       ('jkl', 1, '{"id": "jkl", "post": {"inline_message_id": "uio"}, "singles": [{"created_at": "2025-12-31T10:00:00+03:00"}] }'),                                                                                   -- no TTL
+       ('mno', 1, '{"id": "mno", "post": {"inline_message_id": "pas"}, "settings": {"single_ttl": 3600000000000}, "singles": [{"created_at": "2025-12-31T09:00:00+03:00", "warned_at": "2025-12-31T10:30:00+03:00"}] }'), -- warned recently
+       ('pqr', 1, '{"id": "pqr", "post": {"inline_message_id": "dfg"}, "settings": {"single_ttl": 3600000000000}, "singles": [{"created_at": "2025-12-31T09:00:00+03:00", "warned_at": "2025-12-31T10:00:00+03:00"}] }'), -- grace passed
+       ('stu', 1, '{"id": "stu", "post": {"inline_message_id": "hjk"}, "settings": {"single_ttl": 3600000000000}, "singles": [{"created_at": "2025-12-31T09:00:00+03:00", "refreshed_at": "2025-12-31T10:30:00+03:00"}] }'), -- refreshed recently
+       ('vwx', 1, '{"id": "vwx", "post": {"inline_message_id": "lzx"}, "settings": {"single_ttl": 3600000000000}, "starts_at": "2025-12-31T10:30:00+03:00", "singles": [{"created_at": "2025-12-31T09:00:00+03:00"}] }'),  -- started
+       ('yza', 1, '{"id": "yza", "post": {"inline_message_id": "cvb"}, "settings": {"single_ttl": 3600000000000, "closed_for": "all"}, "singles": [{"created_at": "2025-12-31T09:00:00+03:00"}] }')                    -- closed
+`)
+		suite.Require().NoError(err)
+
+		now := time.Date(2025, 12, 31, 8, 0, 0, 0, time.UTC)
+		warnedBefore := time.Date(2025, 12, 31, 7, 0, 0, 0, time.UTC)
+		events, err := suite.store.EventGetSinglesStale(context.Background(), now, warnedBefore)
+		suite.Require().NoError(err)
+		suite.Require().Len(events, 2)
+		suite.ElementsMatch([]string{"abc", "pqr"}, []string{events[0].ID, events[1].ID})
+	})
 }
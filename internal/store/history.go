@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 
@@ -37,6 +38,44 @@ VALUES (?1, ?2, ?3, $4);`
 	return nil
 }
 
+// HistoryCountByOwner returns the number of history items with the given actions
+// for each event owned by the profile, created after the first time and before or at the second time.
+// The actions of the owner are not counted.
+func (s *SQLiteStore) HistoryCountByOwner(
+	ctx context.Context,
+	ownerID int64,
+	after, before time.Time,
+	actions []models.HistoryAction,
+) ([]models.HistoryCount, error) {
+	if len(actions) == 0 {
+		return nil, nil
+	}
+	// language=SQLite
+	query, args, err := sqlx.In(
+		`SELECT h.event_id, h.action, count(*) AS count
+FROM history h
+         JOIN events e ON e.id = h.event_id
+WHERE e.owner_id = ?
+  AND h.initiator_id IS NOT e.owner_id
+  AND unixepoch(h.created_at) > ?
+  AND unixepoch(h.created_at) <= ?
+  AND h.action IN (?)
+GROUP BY h.event_id, h.action
+ORDER BY min(h.id)`,
+		ownerID, after.Unix(), before.Unix(), actions,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind query: %w", err)
+	}
+
+	var counts []models.HistoryCount
+	if err = s.db.SelectContext(ctx, &counts, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return counts, nil
+}
+
 // HistoryRemoveByEventIDs removes history items by event IDs.
 // Returns the number of removed items.
 func (s *SQLiteStore) HistoryRemoveByEventIDs(ctx context.Context, eventIDs []string) (int, error) {
@@ -64,3 +103,33 @@ func (s *SQLiteStore) HistoryRemoveByEventIDs(ctx context.Context, eventIDs []st
 
 	return int(affected), nil
 }
+
+// HistoryNotifiedAfter returns true if the notification with the given template
+// was successfully sent to the profile after the specified time.
+func (s *SQLiteStore) HistoryNotifiedAfter(
+	ctx context.Context,
+	profileID int64,
+	tmpl models.NotificationTmpl,
+	after time.Time,
+) (bool, error) {
+	const query =
+	// language=SQLite
+	`SELECT EXISTS (SELECT 1
+               FROM history
+               WHERE action = ?1
+                 AND json_extract(data, '$.details.template') = ?2
+                 AND json_extract(data, '$.details.recipient.id') = ?3
+                 AND json_extract(data, '$.details.error') IS NULL
+                 AND unixepoch(created_at) > ?4);`
+	stmt, err := s.stmt(ctx, query)
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrStmtPrepare, err)
+	}
+
+	var exists bool
+	if err = stmt.QueryRowContext(ctx, models.HistoryNotificationSent, tmpl, profileID, after.Unix()).Scan(&exists); err != nil {
+		return false, fmt.Errorf("%w: %w", ErrStmtExec, err)
+	}
+
+	return exists, nil
+}
@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/ofstudio/dancegobot/internal/models"
 )
@@ -92,3 +93,56 @@ VALUES ('added', 1, 'abc', '{"foo": "bar"}', '2021-01-01 00:00:00'),
 		suite.Equal(0, count)
 	})
 }
+
+func (suite *TestStoreSuite) TestStoreHistoryNotifiedAfter() {
+	suite.Run("success", func() {
+		_, err := suite.store.db.Exec(`
+INSERT INTO history (action, initiator_id, event_id, data, created_at)
+VALUES ('notification_sent', 0, 'abc', '{"details": {"template": "single_joined", "recipient": {"id": 1}}}', '2025-12-31 10:00:00'),
+       ('notification_sent', 0, 'def', '{"details": {"template": "single_joined", "recipient": {"id": 2}}}', '2025-12-31 08:00:00'),                   -- earlier
+       ('notification_sent', 0, 'def', '{"details": {"template": "single_joined", "recipient": {"id": 3}, "error": "blocked"}}', '2025-12-31 10:00:00'), -- failed
+       ('notification_sent', 0, 'def', '{"details": {"template": "single_expired", "recipient": {"id": 4}}}', '2025-12-31 10:00:00')                   -- other template
+`)
+		suite.Require().NoError(err)
+
+		after := time.Date(2025, 12, 31, 9, 0, 0, 0, time.UTC)
+		for id, want := range map[int64]bool{1: true, 2: false, 3: false, 4: false, 5: false} {
+			got, err := suite.store.HistoryNotifiedAfter(context.Background(), id, models.TmplSingleJoined, after)
+			suite.Require().NoError(err)
+			suite.Equal(want, got, "profile %d", id)
+		}
+	})
+}
+
+func (suite *TestStoreSuite) TestStoreHistoryCountByOwner() {
+	suite.Run("success", func() {
+		_, err := suite.store.db.Exec(`
+INSERT INTO events (id, owner_id, data)
+VALUES ('abc', 1, '{"id": "abc"}'),
+       ('def', 1, '{"id": "def"}'),
+       ('ghi', 2, '{"id": "ghi"}');
+INSERT INTO history (action, initiator_id, event_id, data, created_at)
+VALUES ('couple_added', 10, 'abc', '{}', '2021-01-01 10:00:00'),
+       ('couple_added', 11, 'abc', '{}', '2021-01-01 11:00:00'),
+       ('single_added', 12, 'abc', '{}', '2021-01-01 12:00:00'),
+       ('limit_changed', 1, 'abc', '{}', '2021-01-01 12:00:00'), -- other action
+       ('couple_added', 13, 'abc', '{}', '2020-12-31 10:00:00'), -- earlier
+       ('couple_added', 1, 'abc', '{}', '2021-01-01 12:00:00'),  -- by owner
+       ('single_added', NULL, 'def', '{}', '2021-01-01 12:00:00'),
+       ('single_added', 14, 'def', '{}', '2021-01-01 13:00:00'),
+       ('couple_added', 15, 'ghi', '{}', '2021-01-01 13:00:00')  -- other owner
+`)
+		suite.Require().NoError(err)
+
+		after := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		before := time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)
+		counts, err := suite.store.HistoryCountByOwner(context.Background(), 1, after, before,
+			[]models.HistoryAction{models.HistoryCoupleAdded, models.HistorySingleAdded})
+		suite.Require().NoError(err)
+		suite.Equal([]models.HistoryCount{
+			{EventID: "abc", Action: models.HistoryCoupleAdded, Count: 2},
+			{EventID: "abc", Action: models.HistorySingleAdded, Count: 1},
+			{EventID: "def", Action: models.HistorySingleAdded, Count: 2},
+		}, counts)
+	})
+}
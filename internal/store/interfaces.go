@@ -15,9 +15,21 @@ type Store interface {
 	EventGet(ctx context.Context, eventID string) (*models.Event, error)
 	EventUpsert(ctx context.Context, event *models.Event) error
 	EventGetUpdatedAfter(ctx context.Context, after time.Time) ([]*models.Event, error)
+	EventGetByManager(ctx context.Context, profileID int64, limit, offset int) ([]*models.Event, error)
+	EventGetStartingBefore(ctx context.Context, before time.Time) ([]*models.Event, error)
+	EventGetStartingWithin(ctx context.Context, after, before time.Time) ([]*models.Event, error)
+	EventGetRecurringBefore(ctx context.Context, before time.Time) ([]*models.Event, error)
+	EventGetPendingBefore(ctx context.Context, before time.Time) ([]*models.Event, error)
+	EventGetSinglesStale(ctx context.Context, now, warnedBefore time.Time) ([]*models.Event, error)
 	EventRemoveDraftsBefore(ctx context.Context, before time.Time) ([]string, error)
 	UserGet(ctx context.Context, id int64) (*models.User, error)
 	UserUpsert(ctx context.Context, user *models.User) error
+	UserGetDigestDue(ctx context.Context, sentBefore time.Time) ([]*models.User, error)
 	HistoryInsert(ctx context.Context, item *models.HistoryItem) error
 	HistoryRemoveByEventIDs(ctx context.Context, eventIDs []string) (int, error)
+	HistoryNotifiedAfter(ctx context.Context, profileID int64, tmpl models.NotificationTmpl, after time.Time) (bool, error)
+	HistoryCountByOwner(ctx context.Context, ownerID int64, after, before time.Time, actions []models.HistoryAction) ([]models.HistoryCount, error)
+	ReminderInsert(ctx context.Context, eventID string, profileID int64, before time.Duration) (bool, error)
+	ReminderRemove(ctx context.Context, eventID string, profileID int64, before time.Duration) error
+	DigestSentSet(ctx context.Context, profileID int64, sentAt time.Time) error
 }
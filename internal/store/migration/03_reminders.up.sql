@@ -0,0 +1,8 @@
+CREATE TABLE "reminders"
+(
+    "event_id"   TEXT      NOT NULL,
+    "profile_id" INTEGER   NOT NULL,
+    "before_sec" INTEGER   NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+    PRIMARY KEY ("event_id", "profile_id", "before_sec")
+);
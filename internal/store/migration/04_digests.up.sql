@@ -0,0 +1,5 @@
+CREATE TABLE "digests"
+(
+    "profile_id" INTEGER PRIMARY KEY,
+    "sent_at"    TIMESTAMP NOT NULL
+);
@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ReminderInsert records the reminder sent to the profile the specified duration before the event start.
+// Returns false if the reminder was already recorded.
+func (s *SQLiteStore) ReminderInsert(ctx context.Context, eventID string, profileID int64, before time.Duration) (bool, error) {
+	const query =
+	// language=SQLite
+	`INSERT OR IGNORE INTO reminders (event_id, profile_id, before_sec)
+VALUES (?1, ?2, ?3);`
+	stmt, err := s.stmt(ctx, query)
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrStmtPrepare, err)
+	}
+
+	result, err := stmt.ExecContext(ctx, eventID, profileID, int64(before.Seconds()))
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrStmtExec, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrStmtExec, err)
+	}
+
+	return affected > 0, nil
+}
+
+// ReminderRemove removes the record of the reminder, so it can be sent again.
+func (s *SQLiteStore) ReminderRemove(ctx context.Context, eventID string, profileID int64, before time.Duration) error {
+	const query =
+	// language=SQLite
+	`DELETE FROM reminders
+WHERE event_id = ?1
+  AND profile_id = ?2
+  AND before_sec = ?3;`
+	stmt, err := s.stmt(ctx, query)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrStmtPrepare, err)
+	}
+
+	if _, err = stmt.ExecContext(ctx, eventID, profileID, int64(before.Seconds())); err != nil {
+		return fmt.Errorf("%w: %w", ErrStmtExec, err)
+	}
+
+	return nil
+}
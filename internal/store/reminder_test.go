@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+func (suite *TestStoreSuite) TestReminderInsert() {
+	suite.Run("at most once", func() {
+		db, err := NewSQLite(":memory:", 3)
+		suite.Require().NoError(err)
+		store := NewSQLiteStore(db)
+		defer store.Close()
+
+		ok, err := store.ReminderInsert(context.Background(), "abc", 1, 24*time.Hour)
+		suite.Require().NoError(err)
+		suite.True(ok)
+
+		ok, err = store.ReminderInsert(context.Background(), "abc", 1, 24*time.Hour)
+		suite.Require().NoError(err)
+		suite.False(ok)
+
+		ok, err = store.ReminderInsert(context.Background(), "abc", 1, 2*time.Hour)
+		suite.Require().NoError(err)
+		suite.True(ok)
+
+		ok, err = store.ReminderInsert(context.Background(), "abc", 2, 24*time.Hour)
+		suite.Require().NoError(err)
+		suite.True(ok)
+
+		ok, err = store.ReminderInsert(context.Background(), "def", 1, 24*time.Hour)
+		suite.Require().NoError(err)
+		suite.True(ok)
+	})
+}
+
+func (suite *TestStoreSuite) TestReminderRemove() {
+	suite.Run("can be sent again", func() {
+		db, err := NewSQLite(":memory:", 3)
+		suite.Require().NoError(err)
+		store := NewSQLiteStore(db)
+		defer store.Close()
+
+		ok, err := store.ReminderInsert(context.Background(), "abc", 1, 24*time.Hour)
+		suite.Require().NoError(err)
+		suite.True(ok)
+
+		suite.Require().NoError(store.ReminderRemove(context.Background(), "abc", 1, 24*time.Hour))
+
+		ok, err = store.ReminderInsert(context.Background(), "abc", 1, 24*time.Hour)
+		suite.Require().NoError(err)
+		suite.True(ok)
+	})
+}
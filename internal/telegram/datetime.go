@@ -0,0 +1,108 @@
+package telegram
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ofstudio/dancegobot/internal/locale"
+	"github.com/ofstudio/dancegobot/internal/models"
+)
+
+// startsAtLayout - layout of the event start time.
+const startsAtLayout = "02.01.2006 15:04"
+
+// parseStartsAt parses the event start time.
+//
+// Format: "02.01.2006 15:04 [Time/Zone]"
+//
+// If the time zone is omitted, the given default time zone is used.
+func parseStartsAt(text, tz string) (*time.Time, error) {
+	fields := strings.Fields(text)
+	if len(fields) == 3 {
+		tz = fields[2]
+		fields = fields[:2]
+	}
+	if len(fields) != 2 {
+		return nil, errors.New("invalid start time format")
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone: %w", err)
+	}
+	t, err := time.ParseInLocation(startsAtLayout, fields[0]+" "+fields[1], loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start time: %w", err)
+	}
+	return &t, nil
+}
+
+// fmtStartsAt formats the event start time in the event time zone.
+// Format: "02.01.2006 15:04 (Time/Zone)"
+func fmtStartsAt(event *models.Event) string {
+	if event.StartsAt == nil {
+		return ""
+	}
+	return fmtEventTime(event, *event.StartsAt) + " (" + event.Timezone + ")"
+}
+
+// fmtEventTime formats the given time in the event time zone.
+// Format: "02.01.2006 15:04"
+func fmtEventTime(event *models.Event, t time.Time) string {
+	if loc, err := time.LoadLocation(event.Timezone); err == nil {
+		t = t.In(loc)
+	}
+	return t.Format(startsAtLayout)
+}
+
+// deadlines - registration and cancellation deadlines available for the event,
+// as durations before the start.
+var deadlines = []time.Duration{0, time.Hour, 3 * time.Hour, 12 * time.Hour, 24 * time.Hour, 48 * time.Hour}
+
+// nextDeadline returns the deadline following the given one.
+func nextDeadline(d time.Duration) time.Duration {
+	i := slices.Index(deadlines, d)
+	return deadlines[(i+1)%len(deadlines)]
+}
+
+// fmtDeadline formats the deadline as a duration before the start.
+func fmtDeadline(d time.Duration) string {
+	if d <= 0 {
+		return locale.DeadlineNone
+	}
+	return fmt.Sprintf(locale.DeadlineBefore, int(d.Hours()))
+}
+
+// singleTTLs - times after which the singles are removed from the event.
+var singleTTLs = []time.Duration{0, 24 * time.Hour, 72 * time.Hour, 168 * time.Hour}
+
+// nextSingleTTL returns the single TTL following the given one.
+func nextSingleTTL(d time.Duration) time.Duration {
+	i := slices.Index(singleTTLs, d)
+	return singleTTLs[(i+1)%len(singleTTLs)]
+}
+
+// fmtSingleTTL formats the single TTL in days.
+func fmtSingleTTL(d time.Duration) string {
+	if d <= 0 {
+		return locale.SingleTTLNone
+	}
+	return fmt.Sprintf(locale.SingleTTLDays, int(d.Hours())/24)
+}
+
+// fmtHours formats the duration as a whole number of hours for the callback data.
+func fmtHours(d time.Duration) string {
+	return strconv.Itoa(int(d.Hours()))
+}
+
+// parseHours parses the whole number of hours from the callback data.
+func parseHours(s string) (time.Duration, error) {
+	h, err := strconv.Atoi(s)
+	if err != nil || h < 0 {
+		return 0, fmt.Errorf("invalid hours: %q", s)
+	}
+	return time.Duration(h) * time.Hour, nil
+}
@@ -0,0 +1,71 @@
+package telegram
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ofstudio/dancegobot/internal/locale"
+	"github.com/ofstudio/dancegobot/internal/models"
+)
+
+func TestParseStartsAt(t *testing.T) {
+	t.Run("default time zone", func(t *testing.T) {
+		got, err := parseStartsAt("31.12.2025 19:30", "Europe/Moscow")
+		require.NoError(t, err)
+		assert.Equal(t, "2025-12-31T19:30:00+03:00", got.Format(time.RFC3339))
+		assert.Equal(t, "Europe/Moscow", got.Location().String())
+	})
+
+	t.Run("explicit time zone", func(t *testing.T) {
+		got, err := parseStartsAt(" 31.12.2025  19:30 Europe/Berlin ", "Europe/Moscow")
+		require.NoError(t, err)
+		assert.Equal(t, "2025-12-31T19:30:00+01:00", got.Format(time.RFC3339))
+		assert.Equal(t, "Europe/Berlin", got.Location().String())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, text := range []string{
+			"",
+			"31.12.2025",
+			"2025-12-31 19:30",
+			"31.12.2025 25:30",
+			"31.12.2025 19:30 Mars/Olympus",
+			"31.12.2025 19:30 Europe/Berlin extra",
+		} {
+			_, err := parseStartsAt(text, "Europe/Moscow")
+			assert.Error(t, err, text)
+		}
+	})
+}
+
+func TestFmtStartsAt(t *testing.T) {
+	startsAt := time.Date(2025, 12, 31, 16, 30, 0, 0, time.UTC)
+	event := &models.Event{StartsAt: &startsAt, Timezone: "Europe/Moscow"}
+	assert.Equal(t, "31.12.2025 19:30 (Europe/Moscow)", fmtStartsAt(event))
+	assert.Equal(t, "", fmtStartsAt(&models.Event{}))
+}
+
+func TestDeadlines(t *testing.T) {
+	assert.Equal(t, time.Hour, nextDeadline(0))
+	assert.Equal(t, time.Duration(0), nextDeadline(48*time.Hour))
+	assert.Equal(t, time.Duration(0), nextDeadline(5*time.Minute))
+	assert.Equal(t, locale.DeadlineNone, fmtDeadline(0))
+	assert.Equal(t, fmt.Sprintf(locale.DeadlineBefore, 24), fmtDeadline(24*time.Hour))
+
+	d, err := parseHours(fmtHours(12 * time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 12*time.Hour, d)
+	_, err = parseHours("-1")
+	assert.Error(t, err)
+}
+
+func TestSingleTTLs(t *testing.T) {
+	assert.Equal(t, 24*time.Hour, nextSingleTTL(0))
+	assert.Equal(t, time.Duration(0), nextSingleTTL(168*time.Hour))
+	assert.Equal(t, locale.SingleTTLNone, fmtSingleTTL(0))
+	assert.Equal(t, fmt.Sprintf(locale.SingleTTLDays, 3), fmtSingleTTL(72*time.Hour))
+}
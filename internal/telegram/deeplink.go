@@ -2,6 +2,7 @@ package telegram
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/ofstudio/dancegobot/internal/config"
@@ -21,11 +22,25 @@ const dlSeparator = "-"
 //
 //	https://t.me/dancegobot?start=AD6s-signup-huw8HMZsOp3-leader
 //
+// Example: accept the invite to co-organize the event with the ID "huw8HMZsOp3"
+//
+//	https://t.me/dancegobot?start=AD6s-invite-huw8HMZsOp3-Ab12Cd34
+//
+// Example: claim the registration of the partner registered by name in the event with the ID "huw8HMZsOp3"
+//
+//	https://t.me/dancegobot?start=AD6s-claim-huw8HMZsOp3-Xy12Zw34
+//
+// Example: sign up for the event with the ID "huw8HMZsOp3" in couple with the single with the profile ID 12345
+//
+//	https://t.me/dancegobot?start=AD6s-pair-huw8HMZsOp3-12345
+//
 // More info: https://core.telegram.org/api/links#bot-links
 type Deeplink struct {
-	Action  models.SessionAction
-	EventID string
-	Role    models.Role
+	Action    models.SessionAction
+	EventID   string
+	Role      models.Role
+	Token     string
+	PartnerID int64
 }
 
 // DeeplinkParse parses the deeplink from the URL.
@@ -62,6 +77,28 @@ func DeeplinkParsePayload(payload string) (*Deeplink, error) {
 			EventID: params[0],
 			Role:    models.Role(params[1]),
 		}, nil
+	case models.SessionInvite, models.SessionClaim:
+		if len(params) < 2 {
+			return nil, errPayload(payload)
+		}
+		return &Deeplink{
+			Action:  action,
+			EventID: params[0],
+			Token:   params[1],
+		}, nil
+	case models.SessionPair:
+		if len(params) < 2 {
+			return nil, errPayload(payload)
+		}
+		partnerID, err := strconv.ParseInt(params[1], 10, 64)
+		if err != nil {
+			return nil, errPayload(payload)
+		}
+		return &Deeplink{
+			Action:    action,
+			EventID:   params[0],
+			PartnerID: partnerID,
+		}, nil
 	default:
 		return nil, errPayload(payload)
 	}
@@ -74,6 +111,10 @@ func (d Deeplink) String() string {
 	switch d.Action {
 	case models.SessionSignup:
 		url += string(d.Action) + dlSeparator + d.EventID + dlSeparator + string(d.Role)
+	case models.SessionInvite, models.SessionClaim:
+		url += string(d.Action) + dlSeparator + d.EventID + dlSeparator + d.Token
+	case models.SessionPair:
+		url += string(d.Action) + dlSeparator + d.EventID + dlSeparator + strconv.FormatInt(d.PartnerID, 10)
 	default:
 	}
 	return url
@@ -18,6 +18,27 @@ func TestDeeplink_String(t *testing.T) {
 		Role:    models.RoleLeader,
 	}.String()
 	assert.Regexp(t, `^https://t.me/my_bot\?start=[a-zA-Z0-9]{4}-signup-eventID-leader$`, url)
+
+	url = Deeplink{
+		Action:  models.SessionInvite,
+		EventID: "eventID",
+		Token:   "token",
+	}.String()
+	assert.Regexp(t, `^https://t.me/my_bot\?start=[a-zA-Z0-9]{4}-invite-eventID-token$`, url)
+
+	url = Deeplink{
+		Action:  models.SessionClaim,
+		EventID: "eventID",
+		Token:   "token",
+	}.String()
+	assert.Regexp(t, `^https://t.me/my_bot\?start=[a-zA-Z0-9]{4}-claim-eventID-token$`, url)
+
+	url = Deeplink{
+		Action:    models.SessionPair,
+		EventID:   "eventID",
+		PartnerID: 12345,
+	}.String()
+	assert.Regexp(t, `^https://t.me/my_bot\?start=[a-zA-Z0-9]{4}-pair-eventID-12345$`, url)
 }
 
 func TestDeeplinkParsePayload(t *testing.T) {
@@ -37,6 +58,48 @@ func TestDeeplinkParsePayload(t *testing.T) {
 			},
 			err: false,
 		},
+		{
+			name:    "valid invite",
+			payload: "AD6s-invite-huw8HMZsOp3-Ab12Cd34",
+			expected: &Deeplink{
+				Action:  models.SessionInvite,
+				EventID: "huw8HMZsOp3",
+				Token:   "Ab12Cd34",
+			},
+			err: false,
+		},
+		{
+			name:    "valid claim",
+			payload: "AD6s-claim-huw8HMZsOp3-Xy12Zw34",
+			expected: &Deeplink{
+				Action:  models.SessionClaim,
+				EventID: "huw8HMZsOp3",
+				Token:   "Xy12Zw34",
+			},
+			err: false,
+		},
+		{
+			name:    "valid pair",
+			payload: "AD6s-pair-huw8HMZsOp3-12345",
+			expected: &Deeplink{
+				Action:    models.SessionPair,
+				EventID:   "huw8HMZsOp3",
+				PartnerID: 12345,
+			},
+			err: false,
+		},
+		{
+			name:     "invalid pair partner",
+			payload:  "AD6s-pair-huw8HMZsOp3-abc",
+			expected: nil,
+			err:      true,
+		},
+		{
+			name:     "missing invite token",
+			payload:  "AD6s-invite-huw8HMZsOp3",
+			expected: nil,
+			err:      true,
+		},
 		{
 			name:     "invalid action",
 			payload:  "AD6s-invalid-huw8HMZsOp3-leader",
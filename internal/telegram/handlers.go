@@ -2,7 +2,13 @@ package telegram
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	tele "gopkg.in/telebot.v4"
 
@@ -84,6 +90,12 @@ func (h *Handlers) Start(c tele.Context) error {
 		switch dl.Action {
 		case models.SessionSignup:
 			return h.signupScene(c, dl.EventID, dl.Role)
+		case models.SessionInvite:
+			return h.inviteAccept(c, dl.EventID, dl.Token)
+		case models.SessionClaim:
+			return h.dancerClaim(c, dl.EventID, dl.Token)
+		case models.SessionPair:
+			return h.pairScene(c, dl.EventID, dl.PartnerID)
 		default:
 			return h.sendErr(c, locale.ErrStartPayload)
 		}
@@ -102,202 +114,1941 @@ func (h *Handlers) Start(c tele.Context) error {
 func (h *Handlers) Settings(c tele.Context) error {
 	h.log.Info("[handlers] /settings received", telelog.Attr(c))
 	u := h.userGet(c)
+	u.Session = models.Session{}
+	h.userUpsert(c, u)
 	text, rm := msgSettingsScene(&u.Settings)
 	return c.Send(text, rm, tele.ModeHTML)
 }
 
+// Events - handles /events command.
+// Sends the first page of the events list managed by the user.
+func (h *Handlers) Events(c tele.Context) error {
+	h.log.Info("[handlers] /events received", telelog.Attr(c))
+	u := h.userGet(c)
+	u.Session = models.Session{}
+	h.userUpsert(c, u)
+
+	text, rm, err := h.eventsList(c, 0)
+	if err != nil {
+		return h.sendErr(c, locale.ErrSomethingWrong)
+	}
+	return c.Send(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
 // Query - handles inline query.
-// If query is not empty creates draft event.
+// If query is not empty creates draft event
+// and a draft event for each of the user templates.
+// Drafts are saved only when one of them is published.
 func (h *Handlers) Query(c tele.Context) error {
-	if c.Query().Text == "" {
+	text := c.Query().Text
+	if text == "" {
 		return answerQueryEmpty(c, h.cfg.QueryThumbUrl)
 	}
 
 	u := h.userGet(c)
-	event, err := h.events.Create(h.ctx(c), c.Query().Text, u.Profile, u.Settings.Event)
+	if event, ok := h.queryDraft(c, u); ok {
+		h.log.Info("[handlers] event draft requested", "event", event.LogValue(), telelog.Trace(c))
+		return answerQuery(c, queryResult(c, event.ID, event.Caption, h.cfg.QueryThumbUrl))
+	}
+
+	event, err := h.events.Draft(text, u.Profile, u.Settings.Event)
 	if err != nil {
 		h.log.Error("[handlers] failed to create event: "+err.Error(), telelog.Trace(c))
 		return h.sendErr(c, locale.ErrSomethingWrong)
 	}
 	h.log.Info("[handlers] event created", "event", event.LogValue(), telelog.Trace(c))
-	return answerQuery(c, event.ID, h.cfg.QueryThumbUrl)
+	results := tele.Results{queryResult(c, event.ID, text, h.cfg.QueryThumbUrl)}
+
+	for _, tmpl := range u.Settings.Templates {
+		caption := tmpl.EventCaption(text)
+		settings := tmpl.Settings
+		settings.CoOrganizers = u.Settings.Event.CoOrganizers
+		event, err = h.events.Draft(caption, u.Profile, settings)
+		if err != nil {
+			h.log.Error("[handlers] failed to create event from template: "+err.Error(),
+				"template", tmpl.Name,
+				telelog.Trace(c))
+			continue
+		}
+		h.log.Info("[handlers] event created from template",
+			"event", event.LogValue(),
+			"template", tmpl.Name,
+			telelog.Trace(c))
+		results = append(results, queryTemplateResult(event.ID, &tmpl, caption, h.cfg.QueryThumbUrl))
+	}
+	return answerQuery(c, results...)
+}
+
+// queryDraft returns the draft event requested to be published by the inline query.
+// Returns false if the query is not a draft request or the user can not manage the draft.
+func (h *Handlers) queryDraft(c tele.Context, u *models.User) (*models.Event, bool) {
+	eventID, ok := strings.CutPrefix(c.Query().Text, queryDraftPrefix)
+	if !ok || len(eventID) != h.cfg.EventIDLen {
+		return nil, false
+	}
+	event, err := h.events.GetManaged(h.ctx(c), eventID, &u.Profile)
+	if err != nil || event.Post != nil {
+		return nil, false
+	}
+	return event, true
+}
+
+// InlineResult handles chosen inline result.
+// Adds post to the event and re-renders event post.
+func (h *Handlers) InlineResult(c tele.Context) error {
+	h.log.Info("[handlers] chosen_inline_result received", telelog.Attr(c))
+	eventID := c.InlineResult().ResultID
+	inlineMessageID := c.InlineResult().MessageID
+
+	// Skip if no message ID or empty query
+	if inlineMessageID == "" || c.InlineResult().Query == "" {
+		return nil
+	}
+
+	// Add post to the event and re-render it
+	event, post, err := h.events.PostAdd(h.ctx(c), eventID, inlineMessageID)
+	if err != nil {
+		h.log.Error("[handlers] chosen_inline_result: failed add event post chat: "+err.Error(),
+			"event_id", eventID,
+			"inline_message_id", inlineMessageID,
+			telelog.Trace(c))
+		return h.sendErr(c, locale.ErrSomethingWrong)
+	}
+	h.log.Info("[handlers] chosen_inline_result: event post added",
+		"event", event.LogValue(),
+		"post", post.LogValue(),
+		telelog.Trace(c))
+	return nil
+}
+
+// CbSettingsAutoPair - toggles auto pair user setting.
+func (h *Handlers) CbSettingsAutoPair(c tele.Context) error {
+	h.log.Info("[handlers] settings_auto_pair callback received", telelog.Attr(c))
+	u := h.userGet(c)
+	u.Settings.Event.AutoPairing = !u.Settings.Event.AutoPairing
+	h.userUpsert(c, u)
+	_ = c.Respond()
+	text, rm := msgSettingsScene(&u.Settings)
+	return c.Edit(text, rm, tele.ModeHTML)
+}
+
+// CbSettingsPairing - switches the auto pairing strategy user setting to the next one.
+func (h *Handlers) CbSettingsPairing(c tele.Context) error {
+	h.log.Info("[handlers] settings_pairing callback received", telelog.Attr(c))
+	u := h.userGet(c)
+	u.Settings.Event.Pairing = nextPairing(u.Settings.Event.Pairing)
+	h.userUpsert(c, u)
+	_ = c.Respond()
+	text, rm := msgSettingsScene(&u.Settings)
+	return c.Edit(text, rm, tele.ModeHTML)
+}
+
+// CbSettingsOwnerAlerts - switches the owner notifications user setting to the next mode.
+func (h *Handlers) CbSettingsOwnerAlerts(c tele.Context) error {
+	h.log.Info("[handlers] settings_owner_alerts callback received", telelog.Attr(c))
+	u := h.userGet(c)
+	u.Settings.OwnerAlerts = nextOwnerAlerts(u.Settings.OwnerAlerts)
+	h.userUpsert(c, u)
+	_ = c.Respond()
+	text, rm := msgSettingsScene(&u.Settings)
+	return c.Edit(text, rm, tele.ModeHTML)
+}
+
+// CbSettingsNotify - sends the user notification preferences scene.
+func (h *Handlers) CbSettingsNotify(c tele.Context) error {
+	h.log.Info("[handlers] settings_notify callback received", telelog.Attr(c))
+	u := h.userGet(c)
+	u.Session = models.Session{}
+	h.userUpsert(c, u)
+	_ = c.Respond()
+	text, rm := msgNotificationsScene(&u.Settings)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbSettingsNotifyCategory - toggles the notification category in the user preferences.
+func (h *Handlers) CbSettingsNotifyCategory(c tele.Context) error {
+	h.log.Info("[handlers] settings_notify_category callback received", telelog.Attr(c))
+	if len(c.Args()) < 1 {
+		h.log.Error("[handlers] settings_notify_category callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+	category := models.NotificationCategory(c.Args()[0])
+	if !slices.Contains(models.NotificationCategories, category) {
+		h.log.Error("[handlers] settings_notify_category callback: unknown category",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	prefs := &u.Settings.Notifications
+	if i := slices.Index(prefs.MutedCategories, category); i >= 0 {
+		prefs.MutedCategories = slices.Delete(prefs.MutedCategories, i, i+1)
+	} else {
+		prefs.MutedCategories = append(prefs.MutedCategories, category)
+	}
+	h.userUpsert(c, u)
+	_ = c.Respond()
+	text, rm := msgNotificationsScene(&u.Settings)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbSettingsNotifyUnmute - unmutes the event in the user notification preferences.
+func (h *Handlers) CbSettingsNotifyUnmute(c tele.Context) error {
+	h.log.Info("[handlers] settings_notify_unmute callback received", telelog.Attr(c))
+	if len(c.Args()) < 1 {
+		h.log.Error("[handlers] settings_notify_unmute callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	if err := h.users.EventUnmute(h.ctx(c), u, eventID); err != nil {
+		h.log.Error("[handlers] settings_notify_unmute callback: "+err.Error(),
+			"event_id", eventID,
+			telelog.Trace(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+	h.log.Info("[handlers] event unmuted", "event_id", eventID, telelog.Trace(c))
+
+	_ = c.Respond(&tele.CallbackResponse{Text: locale.DoneOK})
+	text, rm := msgNotificationsScene(&u.Settings)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbSettingsDancer - sends the user dance profile scene.
+func (h *Handlers) CbSettingsDancer(c tele.Context) error {
+	h.log.Info("[handlers] settings_dancer callback received", telelog.Attr(c))
+	u := h.userGet(c)
+	_ = c.Respond()
+	text, rm := msgDancerProfileScene(&u.Settings)
+	return c.Edit(text, rm, tele.ModeHTML)
+}
+
+// CbSettingsDancerSet - changes the level of the user or the preferred level of the partner.
+func (h *Handlers) CbSettingsDancerSet(c tele.Context) error {
+	h.log.Info("[handlers] settings_dancer_set callback received", telelog.Attr(c))
+	if len(c.Args()) < 2 {
+		h.log.Error("[handlers] settings_dancer_set callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+	level := models.Level(c.Args()[1])
+	if level != models.LevelNone && !slices.Contains(models.Levels, level) {
+		h.log.Error("[handlers] settings_dancer_set callback: unknown level",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	switch c.Args()[0] {
+	case "level":
+		u.Settings.Dancer.Level = level
+	case "partner":
+		u.Settings.Dancer.PartnerLevel = level
+	default:
+		h.log.Error("[handlers] settings_dancer_set callback: unknown setting",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+	h.userUpsert(c, u)
+	_ = c.Respond()
+	text, rm := msgDancerProfileScene(&u.Settings)
+	return c.Edit(text, rm, tele.ModeHTML)
+}
+
+// CbSettingsSingleAlerts - toggles the notifications about the compatible singles joining the events.
+func (h *Handlers) CbSettingsSingleAlerts(c tele.Context) error {
+	h.log.Info("[handlers] settings_single_alerts callback received", telelog.Attr(c))
+	u := h.userGet(c)
+	u.Settings.Dancer.SingleAlerts = !u.Settings.Dancer.SingleAlerts
+	h.userUpsert(c, u)
+	_ = c.Respond()
+	text, rm := msgDancerProfileScene(&u.Settings)
+	return c.Edit(text, rm, tele.ModeHTML)
+}
+
+// CbSettingsHelp - sends settings help message.
+func (h *Handlers) CbSettingsHelp(c tele.Context) error {
+	h.log.Info("[handlers] settings_help callback received", telelog.Attr(c))
+	_ = c.Respond()
+	text, rm := msgSettingsHelp()
+	return c.Edit(text, rm, tele.ModeHTML)
+}
+
+// CbSettingsBack - sends settings scene message.
+func (h *Handlers) CbSettingsBack(c tele.Context) error {
+	h.log.Info("[handlers] settings_back callback received", telelog.Attr(c))
+	u := h.userGet(c)
+	u.Session = models.Session{}
+	h.userUpsert(c, u)
+	_ = c.Respond()
+	text, rm := msgSettingsScene(&u.Settings)
+	return c.Edit(text, rm, tele.ModeHTML)
+}
+
+// CbSettingsBlocklist - sends the user blocklist scene.
+func (h *Handlers) CbSettingsBlocklist(c tele.Context) error {
+	h.log.Info("[handlers] blocklist callback received", telelog.Attr(c))
+	u := h.userGet(c)
+	u.Session = models.Session{Action: models.SessionBlocklist}
+	h.userUpsert(c, u)
+	_ = c.Respond()
+	text, rm := msgBlocklistScene(&u.Settings)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbSettingsUnblock - removes the dancer from the user blocklist.
+func (h *Handlers) CbSettingsUnblock(c tele.Context) error {
+	h.log.Info("[handlers] settings_unblock callback received", telelog.Attr(c))
+	if len(c.Args()) < 1 {
+		h.log.Error("[handlers] settings_unblock callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	key := c.Args()[0]
+	if err := h.users.BlocklistRemove(h.ctx(c), u, key); err != nil {
+		h.log.Error("[handlers] settings_unblock callback: "+err.Error(),
+			"args", c.Args(),
+			telelog.Trace(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+	h.log.Info("[handlers] dancer removed from blocklist", "key", key, telelog.Trace(c))
+
+	_ = c.Respond(&tele.CallbackResponse{Text: locale.DoneOK})
+	text, rm := msgBlocklistScene(&u.Settings)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbSettingsCoOrganizers - sends the default co-organizers scene.
+func (h *Handlers) CbSettingsCoOrganizers(c tele.Context) error {
+	h.log.Info("[handlers] settings_co_organizers callback received", telelog.Attr(c))
+	u := h.userGet(c)
+	_ = c.Respond()
+	text, rm := msgDefaultCoOrganizersScene(&u.Settings)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbSettingsCoOrganizerRemove - removes the co-organizer from the default co-organizers of the user.
+func (h *Handlers) CbSettingsCoOrganizerRemove(c tele.Context) error {
+	h.log.Info("[handlers] settings_co_organizer_remove callback received", telelog.Attr(c))
+	if len(c.Args()) < 1 {
+		h.log.Error("[handlers] settings_co_organizer_remove callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	profileID, err := strconv.ParseInt(c.Args()[0], 10, 64)
+	if err != nil {
+		h.log.Error("[handlers] settings_co_organizer_remove callback: invalid profile ID",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+	defaults := u.Settings.Event.CoOrganizers[:0:0]
+	for _, p := range u.Settings.Event.CoOrganizers {
+		if p.ID != profileID {
+			defaults = append(defaults, p)
+		}
+	}
+	u.Settings.Event.CoOrganizers = defaults
+	h.userUpsert(c, u)
+	h.log.Info("[handlers] default co-organizer removed", "profile_id", profileID, telelog.Trace(c))
+
+	_ = c.Respond(&tele.CallbackResponse{Text: locale.DoneOK})
+	text, rm := msgDefaultCoOrganizersScene(&u.Settings)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbSettingsTemplates - sends the user event templates scene.
+func (h *Handlers) CbSettingsTemplates(c tele.Context) error {
+	h.log.Info("[handlers] settings_templates callback received", telelog.Attr(c))
+	u := h.userGet(c)
+	u.Session = models.Session{}
+	h.userUpsert(c, u)
+	_ = c.Respond()
+	text, rm := msgTemplatesScene(&u.Settings)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbSettingsTemplateApply - applies the settings of the event template
+// to the default settings for new events of the user.
+func (h *Handlers) CbSettingsTemplateApply(c tele.Context) error {
+	h.log.Info("[handlers] settings_template_apply callback received", telelog.Attr(c))
+	if len(c.Args()) < 1 {
+		h.log.Error("[handlers] settings_template_apply callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	id := c.Args()[0]
+	var tmpl *models.EventTemplate
+	for i := range u.Settings.Templates {
+		if u.Settings.Templates[i].ID == id {
+			tmpl = &u.Settings.Templates[i]
+			break
+		}
+	}
+	if tmpl == nil {
+		h.log.Error("[handlers] settings_template_apply callback: template not found",
+			"args", c.Args(),
+			telelog.Trace(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+	settings := tmpl.Settings
+	settings.CoOrganizers = u.Settings.Event.CoOrganizers
+	u.Settings.Event = settings
+	h.userUpsert(c, u)
+	h.log.Info("[handlers] event template applied", "id", id, telelog.Trace(c))
+
+	_ = c.Respond(&tele.CallbackResponse{Text: fmt.Sprintf(locale.TemplateApplied, tmpl.Name)})
+	text, rm := msgSettingsScene(&u.Settings)
+	return c.Edit(text, rm, tele.ModeHTML)
+}
+
+// CbSettingsTemplateRemove - removes the event template from the user settings.
+func (h *Handlers) CbSettingsTemplateRemove(c tele.Context) error {
+	h.log.Info("[handlers] settings_template_remove callback received", telelog.Attr(c))
+	if len(c.Args()) < 1 {
+		h.log.Error("[handlers] settings_template_remove callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	name := c.Args()[0]
+	if err := h.users.TemplateRemove(h.ctx(c), u, name); err != nil {
+		h.log.Error("[handlers] settings_template_remove callback: "+err.Error(),
+			"args", c.Args(),
+			telelog.Trace(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+	h.log.Info("[handlers] event template removed", "name", name, telelog.Trace(c))
+
+	_ = c.Respond(&tele.CallbackResponse{Text: locale.DoneOK})
+	text, rm := msgTemplatesScene(&u.Settings)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbEvents - sends the requested page of the events list.
+func (h *Handlers) CbEvents(c tele.Context) error {
+	h.log.Info("[handlers] events callback received", telelog.Attr(c))
+	var page int
+	if len(c.Args()) > 0 {
+		page, _ = strconv.Atoi(c.Args()[0])
+	}
+
+	u := h.userGet(c)
+	u.Session = models.Session{}
+	h.userUpsert(c, u)
+
+	text, rm, err := h.eventsList(c, page)
+	if err != nil {
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+	_ = c.Respond()
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbEventManage - sends the event management scene.
+func (h *Handlers) CbEventManage(c tele.Context) error {
+	h.log.Info("[handlers] manage callback received", telelog.Attr(c))
+	if len(c.Args()) < 1 {
+		h.log.Error("[handlers] manage callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+	return h.eventScene(c, c.Args()[0])
+}
+
+// CbEventClose - changes the closure mode of the event.
+func (h *Handlers) CbEventClose(c tele.Context) error {
+	h.log.Info("[handlers] event_close callback received", telelog.Attr(c))
+	if len(c.Args()) < 2 {
+		h.log.Error("[handlers] event_close callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	closedFor := models.ClosedFor(c.Args()[1])
+	event, err := h.events.ClosedForSet(h.ctx(c), eventID, &u.Profile, closedFor)
+	if err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+	h.log.Info("[handlers] event closure mode changed",
+		"event", event.LogValue(),
+		"closed_for", closedFor,
+		telelog.Trace(c))
+
+	_ = c.Respond(&tele.CallbackResponse{Text: locale.EventClosedOK})
+	text, rm := msgEventScene(event, &u.Profile)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbEventLimit - asks the owner for the couples limit of the event.
+func (h *Handlers) CbEventLimit(c tele.Context) error {
+	h.log.Info("[handlers] event_limit callback received", telelog.Attr(c))
+	if len(c.Args()) < 1 {
+		h.log.Error("[handlers] event_limit callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	if _, err := h.events.GetManaged(h.ctx(c), eventID, &u.Profile); err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+	u.Session = models.Session{
+		Action:  models.SessionLimit,
+		EventID: eventID,
+	}
+	h.userUpsert(c, u)
+
+	_ = c.Respond()
+	return c.Edit(locale.EventLimitAsk, btnEventBack(eventID), tele.ModeHTML)
+}
+
+// CbEventCaption - asks the owner to send a new caption for the event.
+func (h *Handlers) CbEventCaption(c tele.Context) error {
+	h.log.Info("[handlers] event_caption callback received", telelog.Attr(c))
+	if len(c.Args()) < 1 {
+		h.log.Error("[handlers] event_caption callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	if _, err := h.events.GetManaged(h.ctx(c), eventID, &u.Profile); err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+	u.Session = models.Session{
+		Action:  models.SessionCaption,
+		EventID: eventID,
+	}
+	h.userUpsert(c, u)
+
+	_ = c.Respond()
+	return c.Edit(locale.EventCaptionAsk, btnEventBack(eventID), tele.ModeHTML)
+}
+
+// CbEventTemplate - asks the owner to send a name for the new event template.
+func (h *Handlers) CbEventTemplate(c tele.Context) error {
+	h.log.Info("[handlers] event_template callback received", telelog.Attr(c))
+	if len(c.Args()) < 1 {
+		h.log.Error("[handlers] event_template callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	if _, err := h.events.GetManaged(h.ctx(c), eventID, &u.Profile); err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+	u.Session = models.Session{
+		Action:  models.SessionTemplate,
+		EventID: eventID,
+	}
+	h.userUpsert(c, u)
+
+	_ = c.Respond()
+	return c.Edit(locale.TemplateAsk, btnEventBack(eventID), tele.ModeHTML)
+}
+
+// CbEventStartsAt - asks the owner to send the start time of the event.
+func (h *Handlers) CbEventStartsAt(c tele.Context) error {
+	h.log.Info("[handlers] event_starts_at callback received", telelog.Attr(c))
+	if len(c.Args()) < 1 {
+		h.log.Error("[handlers] event_starts_at callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	event, err := h.events.GetManaged(h.ctx(c), eventID, &u.Profile)
+	if err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+	u.Session = models.Session{
+		Action:  models.SessionStartsAt,
+		EventID: eventID,
+	}
+	h.userUpsert(c, u)
+
+	_ = c.Respond()
+	return c.Edit(fmt.Sprintf(locale.EventStartsAtAsk, h.timezone(event)), btnEventBack(eventID), tele.ModeHTML)
+}
+
+// CbEventRecurrence - changes the recurrence rule of the event.
+func (h *Handlers) CbEventRecurrence(c tele.Context) error {
+	h.log.Info("[handlers] event_recurrence callback received", telelog.Attr(c))
+	if len(c.Args()) < 2 {
+		h.log.Error("[handlers] event_recurrence callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	recurrence := models.Recurrence(c.Args()[1])
+	event, err := h.events.RecurrenceSet(h.ctx(c), eventID, &u.Profile, recurrence)
+	if err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+	h.log.Info("[handlers] event recurrence changed",
+		"event", event.LogValue(),
+		"recurrence", recurrence,
+		telelog.Trace(c))
+
+	_ = c.Respond(&tele.CallbackResponse{Text: locale.DoneOK})
+	text, rm := msgEventScene(event, &u.Profile)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbEventLevels - sends the scene to change the level range of the dancers paired automatically.
+func (h *Handlers) CbEventLevels(c tele.Context) error {
+	h.log.Info("[handlers] event_levels callback received", telelog.Attr(c))
+	if len(c.Args()) < 1 {
+		h.log.Error("[handlers] event_levels callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	event, err := h.events.GetManaged(h.ctx(c), eventID, &u.Profile)
+	if err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+
+	_ = c.Respond()
+	text, rm := msgLevelsScene(event)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbEventLevelsSet - changes the level range of the dancers paired automatically.
+func (h *Handlers) CbEventLevelsSet(c tele.Context) error {
+	h.log.Info("[handlers] event_levels_set callback received", telelog.Attr(c))
+	if len(c.Args()) < 3 {
+		h.log.Error("[handlers] event_levels_set callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	minLevel, maxLevel := models.Level(c.Args()[1]), models.Level(c.Args()[2])
+	event, err := h.events.LevelsSet(h.ctx(c), eventID, &u.Profile, minLevel, maxLevel)
+	if err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+	h.log.Info("[handlers] event levels changed",
+		"event", event.LogValue(),
+		"min_level", minLevel,
+		"max_level", maxLevel,
+		telelog.Trace(c))
+
+	_ = c.Respond(&tele.CallbackResponse{Text: locale.DoneOK})
+	text, rm := msgLevelsScene(event)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbEventSolo - switches the event between the solo and the regular mode.
+func (h *Handlers) CbEventSolo(c tele.Context) error {
+	h.log.Info("[handlers] event_solo callback received", telelog.Attr(c))
+	if len(c.Args()) < 2 {
+		h.log.Error("[handlers] event_solo callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	solo, err := strconv.ParseBool(c.Args()[1])
+	if err != nil {
+		h.log.Error("[handlers] event_solo callback: invalid mode",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+	event, err := h.events.SoloSet(h.ctx(c), eventID, &u.Profile, solo)
+	if errors.Is(err, models.ErrEventNotEmpty) {
+		return c.RespondAlert(locale.ErrSoloNotEmpty)
+	}
+	if err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+	h.log.Info("[handlers] event solo mode changed",
+		"event", event.LogValue(),
+		"solo", solo,
+		telelog.Trace(c))
+
+	_ = c.Respond(&tele.CallbackResponse{Text: locale.DoneOK})
+	text, rm := msgEventScene(event, &u.Profile)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbEventConsent - switches the partner consent requirement of the event.
+func (h *Handlers) CbEventConsent(c tele.Context) error {
+	h.log.Info("[handlers] event_consent callback received", telelog.Attr(c))
+	if len(c.Args()) < 2 {
+		h.log.Error("[handlers] event_consent callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	consent, err := strconv.ParseBool(c.Args()[1])
+	if err != nil {
+		h.log.Error("[handlers] event_consent callback: invalid value",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+	event, err := h.events.ConsentSet(h.ctx(c), eventID, &u.Profile, consent)
+	if err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+	h.log.Info("[handlers] event consent changed",
+		"event", event.LogValue(),
+		"consent", consent,
+		telelog.Trace(c))
+
+	_ = c.Respond(&tele.CallbackResponse{Text: locale.DoneOK})
+	text, rm := msgEventScene(event, &u.Profile)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbEventDeadlines - changes the registration and cancellation deadlines of the event.
+func (h *Handlers) CbEventDeadlines(c tele.Context) error {
+	h.log.Info("[handlers] event_deadlines callback received", telelog.Attr(c))
+	if len(c.Args()) < 3 {
+		h.log.Error("[handlers] event_deadlines callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	signup, err1 := parseHours(c.Args()[1])
+	cancel, err2 := parseHours(c.Args()[2])
+	if err := errors.Join(err1, err2); err != nil {
+		h.log.Error("[handlers] event_deadlines callback: "+err.Error(),
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+	event, err := h.events.DeadlinesSet(h.ctx(c), eventID, &u.Profile, signup, cancel)
+	if err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+	h.log.Info("[handlers] event deadlines changed",
+		"event", event.LogValue(),
+		"signup_deadline", signup,
+		"cancel_deadline", cancel,
+		telelog.Trace(c))
+
+	_ = c.Respond(&tele.CallbackResponse{Text: locale.DoneOK})
+	text, rm := msgEventScene(event, &u.Profile)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbEventSingleTTL - changes the time after which the singles are removed from the event.
+func (h *Handlers) CbEventSingleTTL(c tele.Context) error {
+	h.log.Info("[handlers] event_single_ttl callback received", telelog.Attr(c))
+	if len(c.Args()) < 2 {
+		h.log.Error("[handlers] event_single_ttl callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	ttl, err := parseHours(c.Args()[1])
+	if err != nil {
+		h.log.Error("[handlers] event_single_ttl callback: "+err.Error(),
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+	event, err := h.events.SingleTTLSet(h.ctx(c), eventID, &u.Profile, ttl)
+	if err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+	h.log.Info("[handlers] event single ttl changed",
+		"event", event.LogValue(),
+		"single_ttl", ttl,
+		telelog.Trace(c))
+
+	_ = c.Respond(&tele.CallbackResponse{Text: locale.DoneOK})
+	text, rm := msgEventScene(event, &u.Profile)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbEventClone - clones the event with the couples opted in to repeat
+// and sends the button to publish the new event.
+func (h *Handlers) CbEventClone(c tele.Context) error {
+	h.log.Info("[handlers] event_clone callback received", telelog.Attr(c))
+	if len(c.Args()) < 1 {
+		h.log.Error("[handlers] event_clone callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	next, err := h.events.Clone(h.ctx(c), eventID, &u.Profile, true)
+	if err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+	h.log.Info("[handlers] event cloned",
+		"event_id", eventID,
+		"next", next.LogValue(),
+		telelog.Trace(c))
+
+	_ = c.Respond()
+	text, rm := msgEventCloned(next, eventID)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbEventForbid - sends the scene with the dancers forbidden to sign in for the event.
+func (h *Handlers) CbEventForbid(c tele.Context) error {
+	h.log.Info("[handlers] forbid callback received", telelog.Attr(c))
+	if len(c.Args()) < 1 {
+		h.log.Error("[handlers] forbid callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	event, err := h.events.GetManaged(h.ctx(c), eventID, &u.Profile)
+	if err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+	u.Session = models.Session{
+		Action:  models.SessionForbid,
+		EventID: eventID,
+	}
+	h.userUpsert(c, u)
+
+	_ = c.Respond()
+	text, rm := msgForbiddenScene(event)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbEventAllow - allows the forbidden dancer to sign in for the event.
+func (h *Handlers) CbEventAllow(c tele.Context) error {
+	h.log.Info("[handlers] event_allow callback received", telelog.Attr(c))
+	if len(c.Args()) < 2 {
+		h.log.Error("[handlers] event_allow callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID, key := c.Args()[0], c.Args()[1]
+	event, err := h.events.DancerAllow(h.ctx(c), eventID, &u.Profile, key)
+	if err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+	h.log.Info("[handlers] dancer allowed",
+		"event", event.LogValue(),
+		"key", key,
+		telelog.Trace(c))
+
+	_ = c.Respond(&tele.CallbackResponse{Text: locale.DoneOK})
+	text, rm := msgForbiddenScene(event)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbEventDancers - sends the scene with the couples, waitlist and singles of the event.
+func (h *Handlers) CbEventDancers(c tele.Context) error {
+	h.log.Info("[handlers] event_dancers callback received", telelog.Attr(c))
+	if len(c.Args()) < 1 {
+		h.log.Error("[handlers] event_dancers callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	event, err := h.events.GetManaged(h.ctx(c), eventID, &u.Profile)
+	if err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+	u.Session = models.Session{
+		Action:  models.SessionManage,
+		EventID: eventID,
+	}
+	h.userUpsert(c, u)
+
+	page := 0
+	if len(c.Args()) > 1 {
+		page, _ = strconv.Atoi(c.Args()[1])
+	}
+	_ = c.Respond()
+	text, rm := msgDancersScene(event, page, h.cfg.DancersPageSize)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbEventDancerAdd - asks the organizer to send the couple or the single to be registered for the event.
+func (h *Handlers) CbEventDancerAdd(c tele.Context) error {
+	h.log.Info("[handlers] event_dancer_add callback received", telelog.Attr(c))
+	if len(c.Args()) < 2 {
+		h.log.Error("[handlers] event_dancer_add callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	if _, err := h.events.GetManaged(h.ctx(c), eventID, &u.Profile); err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+
+	var role models.Role
+	text := locale.DancerCoupleAsk
+	if c.Args()[1] != dancersCouple {
+		role = models.Role(c.Args()[1])
+		if role != models.RoleLeader && role != models.RoleFollower && role != models.RoleSwitch {
+			h.log.Error("[handlers] event_dancer_add callback: invalid role",
+				"args", c.Args(),
+				telelog.Attr(c))
+			return c.RespondAlert(locale.ErrSomethingWrong)
+		}
+		text = fmt.Sprintf(locale.DancerSingleAsk, locale.RoleIcon[role])
+	}
+	u.Session = models.Session{
+		Action:  models.SessionDancerAdd,
+		EventID: eventID,
+		Role:    role,
+	}
+	h.userUpsert(c, u)
+
+	_ = c.Respond()
+	return c.Edit(text, btnDancersBack(eventID), tele.ModeHTML)
+}
+
+// CbEventDancerPair - asks the organizer to send the partner for the single dancer.
+func (h *Handlers) CbEventDancerPair(c tele.Context) error {
+	h.log.Info("[handlers] event_dancer_pair callback received", telelog.Attr(c))
+	if len(c.Args()) < 2 {
+		h.log.Error("[handlers] event_dancer_pair callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	event, err := h.events.GetManaged(h.ctx(c), eventID, &u.Profile)
+	if err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+	single, ok := singleByKey(event, c.Args()[1])
+	if !ok {
+		return c.RespondAlert(locale.ResultByOrganizer[models.ResultWasNotRegistered])
+	}
+	u.Session = models.Session{
+		Action:  models.SessionDancerAdd,
+		EventID: eventID,
+		Role:    single.Role.Opposite(),
+		Dancer:  single,
+	}
+	h.userUpsert(c, u)
+
+	_ = c.Respond()
+	return c.Edit(fmt.Sprintf(locale.DancerPartnerAsk, fmtDancer(single)), btnDancersBack(eventID), tele.ModeHTML)
+}
+
+// CbEventDancerRemove - removes the dancer registration on behalf of the organizer.
+func (h *Handlers) CbEventDancerRemove(c tele.Context) error {
+	h.log.Info("[handlers] event_dancer_remove callback received", telelog.Attr(c))
+	if len(c.Args()) < 2 {
+		h.log.Error("[handlers] event_dancer_remove callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	event, err := h.events.GetManaged(h.ctx(c), eventID, &u.Profile)
+	if err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+	dancer, ok := dancerByKey(event, c.Args()[1])
+	if !ok {
+		return c.RespondAlert(locale.ResultByOrganizer[models.ResultWasNotRegistered])
+	}
+
+	reg, err := h.events.DancerRemoveByOrganizer(h.ctx(c), eventID, &u.Profile, dancer)
+	if err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+	if !reg.Result.IsSuccess() {
+		return c.RespondAlert(resultByOrganizer(reg.Result))
+	}
+	h.log.Info("[handlers] dancer removed by organizer", "", reg, telelog.Trace(c))
+
+	_ = c.Respond(&tele.CallbackResponse{Text: locale.DoneOK})
+	text, rm := msgDancersScene(reg.Event, 0, h.cfg.DancersPageSize)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbEventPairing - sends the scene to pair the singles of the event by the owner.
+// When both the leader and the follower are selected, registers them as a couple.
+func (h *Handlers) CbEventPairing(c tele.Context) error {
+	h.log.Info("[handlers] event_pairing callback received", telelog.Attr(c))
+	if len(c.Args()) < 3 {
+		h.log.Error("[handlers] event_pairing callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	event, err := h.events.GetManaged(h.ctx(c), eventID, &u.Profile)
+	if err == nil && event.Owner.ID != u.Profile.ID {
+		err = models.ErrNotOwner
+	}
+	if err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+
+	leaderKey, followerKey := c.Args()[1], c.Args()[2]
+	leader, okL := singleByKey(event, leaderKey)
+	follower, okF := singleByKey(event, followerKey)
+	if (leaderKey != pairingNone && (!okL || leader.Role != models.RoleLeader)) ||
+		(followerKey != pairingNone && (!okF || follower.Role != models.RoleFollower)) {
+		return c.RespondAlert(locale.ResultByOrganizer[models.ResultWasNotRegistered])
+	}
+	if !okL || !okF {
+		_ = c.Respond()
+		text, rm := msgPairingScene(event, leaderKey, followerKey)
+		return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+	}
+
+	reg, err := h.events.SinglesPair(h.ctx(c), eventID, &u.Profile, leader, follower)
+	if err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+	if !reg.Result.IsSuccess() {
+		return c.RespondAlert(resultByOrganizer(reg.Result))
+	}
+	h.log.Info("[handlers] singles paired by organizer", "", reg, telelog.Trace(c))
+
+	_ = c.Respond(&tele.CallbackResponse{Text: locale.DoneOK})
+	text, rm := msgPairingScene(reg.Event, pairingNone, pairingNone)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbNoop - answers the callback of the button that does nothing.
+func (h *Handlers) CbNoop(c tele.Context) error {
+	return c.Respond()
+}
+
+// CbEventCoOrganizers - sends the scene with the co-organizers of the event and the invite link.
+func (h *Handlers) CbEventCoOrganizers(c tele.Context) error {
+	return h.coOrganizersScene(c, "event_co_organizers", false)
+}
+
+// CbEventInviteRenew - renews the co-organizer invite link of the event.
+func (h *Handlers) CbEventInviteRenew(c tele.Context) error {
+	return h.coOrganizersScene(c, "event_invite_renew", true)
+}
+
+// CbEventCoOrganizerRemove - removes the co-organizer from the event.
+func (h *Handlers) CbEventCoOrganizerRemove(c tele.Context) error {
+	h.log.Info("[handlers] event_co_organizer_remove callback received", telelog.Attr(c))
+	if len(c.Args()) < 2 {
+		h.log.Error("[handlers] event_co_organizer_remove callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	profileID, err := strconv.ParseInt(c.Args()[1], 10, 64)
+	if err != nil {
+		h.log.Error("[handlers] event_co_organizer_remove callback: invalid profile ID",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+	event, err := h.events.CoOrganizerRemove(h.ctx(c), eventID, &u.Profile, profileID)
+	if err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+	h.log.Info("[handlers] co-organizer removed",
+		"event", event.LogValue(),
+		"profile_id", profileID,
+		telelog.Trace(c))
+
+	_ = c.Respond(&tele.CallbackResponse{Text: locale.DoneOK})
+	text, rm := msgCoOrganizersScene(event, &u.Settings)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbEventCoOrganizerDefault - adds the co-organizer of the event to the default co-organizers of the user
+// or removes it from the defaults if it is already there.
+func (h *Handlers) CbEventCoOrganizerDefault(c tele.Context) error {
+	h.log.Info("[handlers] event_co_organizer_default callback received", telelog.Attr(c))
+	if len(c.Args()) < 2 {
+		h.log.Error("[handlers] event_co_organizer_default callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	u := h.userGet(c)
+	eventID := c.Args()[0]
+	profileID, err := strconv.ParseInt(c.Args()[1], 10, 64)
+	if err != nil {
+		h.log.Error("[handlers] event_co_organizer_default callback: invalid profile ID",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+	event, err := h.events.InviteCreate(h.ctx(c), eventID, &u.Profile, false)
+	if err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+
+	defaults := u.Settings.Event.CoOrganizers[:0:0]
+	for _, p := range u.Settings.Event.CoOrganizers {
+		if p.ID != profileID {
+			defaults = append(defaults, p)
+		}
+	}
+	if len(defaults) == len(u.Settings.Event.CoOrganizers) {
+		for _, p := range event.CoOrganizers {
+			if p.ID == profileID {
+				defaults = append(defaults, p)
+			}
+		}
+	}
+	u.Settings.Event.CoOrganizers = defaults
+	h.userUpsert(c, u)
+	h.log.Info("[handlers] default co-organizers changed",
+		"event", event.LogValue(),
+		"profile_id", profileID,
+		telelog.Trace(c))
+
+	_ = c.Respond(&tele.CallbackResponse{Text: locale.DoneOK})
+	text, rm := msgCoOrganizersScene(event, &u.Settings)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// CbSignup handles signup callback buttons.
+// Adds post to the event, re-renders event post, redirects user to signup deeplink
+func (h *Handlers) CbSignup(c tele.Context) error {
+	h.log.Info("[handlers] signup callback received", telelog.Attr(c))
+
+	if len(c.Args()) < 2 {
+		h.log.Error("[handlers] signup callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	// Add post to the event and re-render it
+	eventID := c.Args()[0]
+	inlineMessageID := c.Callback().MessageID
+	event, post, err := h.events.PostAdd(h.ctx(c), eventID, inlineMessageID)
+	if err != nil {
+		h.log.Error("[handlers] signup callback: failed add event post chat: "+err.Error(),
+			"event_id", eventID,
+			telelog.Trace(c))
+		return h.sendErr(c, locale.ErrSomethingWrong)
+	}
+	h.log.Info("[handlers] signup callback: event post added",
+		"event", event.LogValue(),
+		"post", post.LogValue(),
+		telelog.Trace(c))
+	role := c.Args()[1]
+	dl := Deeplink{Action: models.SessionSignup, EventID: eventID, Role: models.Role(role)}
+	return c.Respond(&tele.CallbackResponse{URL: dl.String()})
+}
+
+// CbConsentAccept - handles the partner confirmation of the couple registration.
+func (h *Handlers) CbConsentAccept(c tele.Context) error {
+	h.log.Info("[handlers] consent_accept callback received", telelog.Attr(c))
+	eventID, requesterID, ok := h.consentArgs(c)
+	if !ok {
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	profile := models.NewProfile(*c.Sender())
+	reg, err := h.events.PendingAccept(h.ctx(c), eventID, &profile, requesterID)
+	if err != nil {
+		h.log.Error("[handlers] failed to accept couple: "+err.Error(),
+			"event_id", eventID,
+			"profile", profile.LogValue(),
+			telelog.Trace(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+	h.log.Info("[handlers] couple accept", "", reg, telelog.Trace(c))
+
+	_ = c.Respond()
+	return sendResult(c, reg, nil)
+}
+
+// CbConsentDecline - handles the partner refusal of the couple registration.
+func (h *Handlers) CbConsentDecline(c tele.Context) error {
+	h.log.Info("[handlers] consent_decline callback received", telelog.Attr(c))
+	eventID, requesterID, ok := h.consentArgs(c)
+	if !ok {
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	profile := models.NewProfile(*c.Sender())
+	declined, err := h.events.PendingDecline(h.ctx(c), eventID, &profile, requesterID)
+	if err != nil {
+		h.log.Error("[handlers] failed to decline couple: "+err.Error(),
+			"event_id", eventID,
+			"profile", profile.LogValue(),
+			telelog.Trace(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+	if !declined {
+		return c.RespondAlert(locale.ResultPendingNotFound)
+	}
+	h.log.Info("[handlers] couple declined",
+		"event_id", eventID,
+		"requester_id", requesterID,
+		telelog.Trace(c))
+
+	return c.Respond(&tele.CallbackResponse{Text: locale.ConsentDeclined})
+}
+
+// CbSingleRefresh - handles the single dancer confirmation that they are still looking for a partner.
+func (h *Handlers) CbSingleRefresh(c tele.Context) error {
+	h.log.Info("[handlers] single_refresh callback received", telelog.Attr(c))
+	if len(c.Args()) < 1 {
+		h.log.Error("[handlers] single_refresh callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	eventID := c.Args()[0]
+	profile := models.NewProfile(*c.Sender())
+	refreshed, err := h.events.SingleRefresh(h.ctx(c), eventID, &profile)
+	if err != nil {
+		h.log.Error("[handlers] failed to refresh single: "+err.Error(),
+			"event_id", eventID,
+			"profile", profile.LogValue(),
+			telelog.Trace(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+	if !refreshed {
+		return c.RespondAlert(locale.SingleRefreshNotFound)
+	}
+	h.log.Info("[handlers] single refreshed",
+		"event_id", eventID,
+		"profile", profile.LogValue(),
+		telelog.Trace(c))
+
+	return c.Respond(&tele.CallbackResponse{Text: locale.SingleRefreshed})
+}
+
+// CbNotifyMute - mutes the notifications about the event for the user.
+func (h *Handlers) CbNotifyMute(c tele.Context) error {
+	h.log.Info("[handlers] notify_mute callback received", telelog.Attr(c))
+	if len(c.Args()) < 1 {
+		h.log.Error("[handlers] notify_mute callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+
+	eventID := c.Args()[0]
+	event, err := h.events.Get(h.ctx(c), eventID)
+	if err != nil {
+		h.log.Error("[handlers] notify_mute callback: failed to get event: "+err.Error(),
+			"event_id", eventID,
+			telelog.Trace(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+	u := h.userGet(c)
+	if err = h.users.EventMute(h.ctx(c), u, event.ID, fmtEventTitle(event)); err != nil {
+		h.log.Error("[handlers] notify_mute callback: "+err.Error(),
+			"event_id", eventID,
+			telelog.Trace(c))
+		return c.RespondAlert(locale.ErrSomethingWrong)
+	}
+	h.log.Info("[handlers] event muted", "event_id", eventID, telelog.Trace(c))
+
+	return c.RespondAlert(locale.EventMuted)
+}
+
+// consentArgs parses the event ID and the requester profile ID from the consent callback.
+func (h *Handlers) consentArgs(c tele.Context) (string, int64, bool) {
+	if len(c.Args()) < 3 {
+		h.log.Error("[handlers] consent callback: not enough arguments",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return "", 0, false
+	}
+	requesterID, err := strconv.ParseInt(c.Args()[1], 10, 64)
+	if err != nil {
+		h.log.Error("[handlers] consent callback: invalid requester id",
+			"args", c.Args(),
+			telelog.Attr(c))
+		return "", 0, false
+	}
+	return c.Args()[0], requesterID, true
+}
+
+// UserShared - handles the user shared event.
+func (h *Handlers) UserShared(c tele.Context) error {
+	h.log.Info("[handlers] users_shared received", telelog.Attr(c))
+	u := h.userGet(c)
+	if u.Session.Action != models.SessionSignup && u.Session.Action != models.SessionReplace {
+		h.log.Error("[handlers] unexpected user_shared", telelog.Trace(c))
+		return nil
+	}
+
+	userShared := c.Message().UserShared.Users[0]
+	other := models.Profile{
+		ID:        userShared.UserID,
+		FirstName: userShared.FirstName,
+		LastName:  userShared.LastName,
+		Username:  userShared.Username,
+	}
+
+	if u.Session.Action == models.SessionReplace {
+		return h.partnerReplace(c, u.Session.EventID, u.Session.Role, &other)
+	}
+	return h.coupleAdd(c, u.Session.EventID, u.Session.Role, &other)
+
+}
+
+// Partner - handles the /partner command (legacy).
+// This is to provide familiar user experience with github.com/Tayrinn/CoopDance bot.
+// The payload will be treated as a text message.
+func (h *Handlers) Partner(c tele.Context) error {
+	h.log.Info("[handlers] /partner received: payload will be treated as text message", telelog.Attr(c))
+	if c.Message().Payload == "" {
+		return nil
+	}
+	c.Message().Text = c.Message().Payload
+	c.Message().Payload = ""
+	return h.Text(c)
+}
+
+// Text - handles text messages.
+func (h *Handlers) Text(c tele.Context) error {
+	h.log.Info("[handlers] text message received", "text", c.Text(), telelog.Attr(c))
+
+	u := h.userGet(c)
+	switch u.Session.Action {
+	case models.SessionSignup:
+		return h.signupText(c, u)
+	case models.SessionLimit:
+		return h.limitText(c, u)
+	case models.SessionCaption:
+		return h.captionText(c, u)
+	case models.SessionStartsAt:
+		return h.startsAtText(c, u)
+	case models.SessionForbid:
+		return h.forbidText(c, u)
+	case models.SessionBlocklist:
+		return h.blocklistText(c, u)
+	case models.SessionTemplate:
+		return h.templateText(c, u)
+	case models.SessionDancerAdd:
+		return h.dancerAddText(c, u)
+	case models.SessionReplace:
+		return h.replaceText(c, u)
+	default:
+		h.log.Info("[handlers] unexpected text", telelog.Trace(c))
+		return nil // todo maybe some help message or random joke or facts?
+	}
+}
+
+// signupText handles text messages in the signup scene.
+func (h *Handlers) signupText(c tele.Context, u *models.User) error {
+	text := c.Text()
+	switch {
+	case text == locale.BtnClose:
+		u.Session = models.Session{}
+		h.userUpsert(c, u)
+		return sendCloseOK(c)
+	case text == locale.BtnRemove:
+		return h.dancerRemove(c, u.Session.EventID)
+	case text == locale.BtnRegister:
+		return h.participantAdd(c, u.Session.EventID, u.Session.Role)
+	case text == locale.BtnReplacePartner:
+		u.Session.Action = models.SessionReplace
+		u.Session.Singles = nil
+		h.userUpsert(c, u)
+		return c.Send(locale.SignupReplace, btnReplaceScene(), tele.ModeHTML)
+	case text == locale.BtnRoleSwitch:
+		return h.roleSwitch(c, u.Session.EventID)
+	case text == locale.BtnRepeat[false]:
+		return h.coupleRepeat(c, u, true)
+	case text == locale.BtnRepeat[true]:
+		return h.coupleRepeat(c, u, false)
+	case text == locale.BtnAsSingle[u.Session.Role]:
+		return h.singleAdd(c, u.Session.EventID, u.Session.Role)
+	case text == locale.BtnAsSingle[models.RoleSwitch]:
+		return h.singleAdd(c, u.Session.EventID, models.RoleSwitch)
+	case isSingleCaption(text):
+		for _, single := range u.Session.Singles {
+			if single.Caption == text {
+				return h.coupleAdd(c, u.Session.EventID, u.Session.Role, &single.Profile)
+			}
+		}
+		return h.sendErr(c, locale.ErrSingleNotFound)
+	case len(text) > h.cfg.DancerNameMaxLen:
+		return h.sendErr(c, locale.ErrDancerNameTooLong)
+	default:
+		return h.coupleAdd(c, u.Session.EventID, u.Session.Role, text)
+	}
+}
+
+// replaceText handles text messages in the partner replacement scene.
+func (h *Handlers) replaceText(c tele.Context, u *models.User) error {
+	text := c.Text()
+	switch {
+	case text == locale.BtnClose:
+		u.Session = models.Session{}
+		h.userUpsert(c, u)
+		return sendCloseOK(c)
+	case len(text) > h.cfg.DancerNameMaxLen:
+		return h.sendErr(c, locale.ErrDancerNameTooLong)
+	default:
+		return h.partnerReplace(c, u.Session.EventID, u.Session.Role, text)
+	}
+}
+
+// coupleRepeat changes the opt-in of the user couple to be signed up for the next recurring event.
+func (h *Handlers) coupleRepeat(c tele.Context, u *models.User, repeat bool) error {
+	reg, err := h.events.CoupleRepeatSet(h.ctx(c), u.Session.EventID, &u.Profile, repeat)
+	if err != nil {
+		h.log.Error("[handlers] failed to set couple repeat: "+err.Error(),
+			"event_id", u.Session.EventID,
+			telelog.Trace(c))
+		return h.sendErr(c, locale.ErrSomethingWrong)
+	}
+	h.log.Info("[handlers] couple repeat set", "", reg, "repeat", repeat, telelog.Trace(c))
+
+	text := locale.RepeatOff
+	if repeat {
+		text = locale.RepeatOn
+	}
+	return c.Send(text, btnSignupScene(reg, u.Session.Singles), tele.ModeHTML)
+}
+
+// signupScene returns the signup scene for the user.
+func (h *Handlers) signupScene(c tele.Context, eventID string, role models.Role) error {
+	u := h.userGet(c)
+	event, err := h.events.Get(h.ctx(c), eventID)
+	if err != nil {
+		h.log.Error("[handlers] signup scene: failed to get event: "+err.Error(),
+			"event_id", eventID,
+			telelog.Trace(c))
+		return h.sendErr(c, locale.ErrSomethingWrong)
+	}
+
+	reg, err := h.events.RegistrationGet(h.ctx(c), event, &u.Profile, role)
+	if err != nil {
+		h.log.Error("[handlers] signup scene: failed to get registration: "+err.Error(),
+			"event_id", eventID,
+			telelog.Trace(c))
+		return h.sendErr(c, locale.ErrSomethingWrong)
+	}
+
+	// if the dancer can register or already registered, update the session.
+	// The switch single signs up in a couple with the role chosen in the post.
+	// The participant of the solo event keeps the role chosen in the post for the headcount.
+	var singles []models.SessionSingle
+	switch {
+	case event.Settings.Solo && (reg.Status.CanRegister() || reg.Status.IsRegistered()):
+		u.Session = models.Session{
+			Action:  models.SessionSignup,
+			EventID: eventID,
+			Role:    role,
+		}
+	case reg.Status.CanRegister() || reg.Status.IsRegistered():
+		singles = fmtSingles(event.Singles, role, &u.Profile)
+		u.Session = models.Session{
+			Action:  models.SessionSignup,
+			EventID: eventID,
+			Role:    reg.Dancer.Role.Resolve(role.Opposite()),
+			Singles: singles,
+		}
+	default:
+		// otherwise, reset the session
+		u.Session = models.Session{}
+	}
+	h.userUpsert(c, u)
+
+	h.log.Info("[handlers] signup scene", "", reg, telelog.Trace(c))
+	return sendSignupScene(c, reg, singles)
+}
+
+// pairScene returns the signup scene for the user with the single
+// with the given profile ID as the only partner to choose.
+func (h *Handlers) pairScene(c tele.Context, eventID string, partnerID int64) error {
+	u := h.userGet(c)
+	event, err := h.events.Get(h.ctx(c), eventID)
+	if err != nil {
+		h.log.Error("[handlers] pair scene: failed to get event: "+err.Error(),
+			"event_id", eventID,
+			telelog.Trace(c))
+		return h.sendErr(c, locale.ErrSomethingWrong)
+	}
+
+	i := slices.IndexFunc(event.Singles, func(d models.Dancer) bool {
+		return d.Profile != nil && d.Profile.ID == partnerID
+	})
+	if i < 0 {
+		return h.sendErr(c, locale.ErrPairNotFound)
+	}
+	partner := event.Singles[i]
+
+	reg, err := h.events.RegistrationGet(h.ctx(c), event, &u.Profile, partner.Role.Opposite())
+	if err != nil {
+		h.log.Error("[handlers] pair scene: failed to get registration: "+err.Error(),
+			"event_id", eventID,
+			telelog.Trace(c))
+		return h.sendErr(c, locale.ErrSomethingWrong)
+	}
+	if !reg.Status.CanRegister() || event.Settings.Solo {
+		return h.signupScene(c, eventID, partner.Role.Opposite())
+	}
+
+	role := reg.Dancer.Role.Resolve(partner.Role)
+	var singles []models.SessionSingle
+	for _, s := range fmtSingles(event.Singles, role, &u.Profile) {
+		if s.Profile.ID == partnerID {
+			singles = append(singles, s)
+		}
+	}
+	if len(singles) == 0 {
+		return h.sendErr(c, locale.ErrPairNotFound)
+	}
+	u.Session = models.Session{
+		Action:  models.SessionSignup,
+		EventID: eventID,
+		Role:    role,
+		Singles: singles,
+	}
+	h.userUpsert(c, u)
+
+	h.log.Info("[handlers] pair scene", "", reg, "partner_id", partnerID, telelog.Trace(c))
+	return c.Send(fmt.Sprintf(locale.SignupPair, fmtDancer(&partner)), btnSignupScene(reg, singles), tele.ModeHTML)
+}
+
+// eventsList returns the message with the page of the events list managed by the user.
+func (h *Handlers) eventsList(c tele.Context, page int) (string, *tele.ReplyMarkup, error) {
+	u := h.userGet(c)
+	if page < 0 {
+		page = 0
+	}
+
+	// Request one more event to find out if there is a next page
+	size := h.cfg.EventsPageSize
+	events, err := h.events.GetByManager(h.ctx(c), u.Profile.ID, size+1, page*size)
+	if err != nil {
+		h.log.Error("[handlers] events list: failed to get events: "+err.Error(),
+			"profile", u.Profile.LogValue(),
+			telelog.Trace(c))
+		return "", nil, err
+	}
+	more := len(events) > size
+	if more {
+		events = events[:size]
+	}
+
+	text, rm := msgEventsList(events, page, more)
+	return text, rm, nil
+}
+
+// eventScene edits the callback message to the event management scene.
+// Only the users allowed to manage the event can access the scene.
+func (h *Handlers) eventScene(c tele.Context, eventID string) error {
+	u := h.userGet(c)
+	event, err := h.events.GetManaged(h.ctx(c), eventID, &u.Profile)
+	if err != nil {
+		return h.respondManageErr(c, eventID, err)
+	}
+
+	u.Session = models.Session{
+		Action:  models.SessionManage,
+		EventID: eventID,
+	}
+	h.userUpsert(c, u)
+
+	h.log.Info("[handlers] event scene", "event", event.LogValue(), telelog.Trace(c))
+	_ = c.Respond()
+	text, rm := msgEventScene(event, &u.Profile)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
 }
 
-// InlineResult handles chosen inline result.
-// Adds post to the event and re-renders event post.
-func (h *Handlers) InlineResult(c tele.Context) error {
-	h.log.Info("[handlers] chosen_inline_result received", telelog.Attr(c))
-	eventID := c.InlineResult().ResultID
-	inlineMessageID := c.InlineResult().MessageID
-
-	// Skip if no message ID or empty query
-	if inlineMessageID == "" || c.InlineResult().Query == "" {
-		return nil
+// limitText handles the text with the couples limit of the event.
+func (h *Handlers) limitText(c tele.Context, u *models.User) error {
+	eventID := u.Session.EventID
+	limit, err := strconv.Atoi(strings.TrimSpace(c.Text()))
+	if err != nil || limit < 0 {
+		return c.Send(locale.ErrLimit, btnEventBack(eventID))
 	}
 
-	// Add post to the event and re-render it
-	event, post, err := h.events.PostAdd(h.ctx(c), eventID, inlineMessageID)
+	event, err := h.events.LimitSet(h.ctx(c), eventID, &u.Profile, limit)
 	if err != nil {
-		h.log.Error("[handlers] chosen_inline_result: failed add event post chat: "+err.Error(),
-			"event_id", eventID,
-			"inline_message_id", inlineMessageID,
-			telelog.Trace(c))
-		return h.sendErr(c, locale.ErrSomethingWrong)
+		return h.sendManageErr(c, eventID, err)
 	}
-	h.log.Info("[handlers] chosen_inline_result: event post added",
+	h.log.Info("[handlers] event limit changed",
 		"event", event.LogValue(),
-		"post", post.LogValue(),
+		"limit", limit,
 		telelog.Trace(c))
-	return nil
+	return h.sendEventScene(c, event)
 }
 
-// CbSettingsAutoPair - toggles auto pair user setting.
-func (h *Handlers) CbSettingsAutoPair(c tele.Context) error {
-	h.log.Info("[handlers] settings_auto_pair callback received", telelog.Attr(c))
-	u := h.userGet(c)
-	u.Settings.Event.AutoPairing = !u.Settings.Event.AutoPairing
-	h.userUpsert(c, u)
-	_ = c.Respond()
-	text, rm := msgSettingsScene(&u.Settings)
-	return c.Edit(text, rm, tele.ModeHTML)
+// captionText handles text messages with the new caption of the event.
+func (h *Handlers) captionText(c tele.Context, u *models.User) error {
+	eventID := u.Session.EventID
+	event, err := h.events.CaptionSet(h.ctx(c), eventID, &u.Profile, c.Text())
+	if errors.Is(err, models.ErrCaptionInvalid) {
+		return c.Send(fmt.Sprintf(locale.ErrCaption, h.cfg.EventTextMaxLen), btnEventBack(eventID))
+	}
+	if err != nil {
+		return h.sendManageErr(c, eventID, err)
+	}
+	h.log.Info("[handlers] event caption changed",
+		"event", event.LogValue(),
+		telelog.Trace(c))
+	return h.sendEventScene(c, event)
 }
 
-// CbSettingsHelp - sends settings help message.
-func (h *Handlers) CbSettingsHelp(c tele.Context) error {
-	h.log.Info("[handlers] settings_help callback received", telelog.Attr(c))
-	_ = c.Respond()
-	text, rm := msgSettingsHelp()
-	return c.Edit(text, rm, tele.ModeHTML)
-}
+// startsAtText handles text messages with the start time of the event.
+func (h *Handlers) startsAtText(c tele.Context, u *models.User) error {
+	eventID := u.Session.EventID
+	event, err := h.events.GetManaged(h.ctx(c), eventID, &u.Profile)
+	if err != nil {
+		return h.sendManageErr(c, eventID, err)
+	}
 
-// CbSettingsBack - sends settings scene message.
-func (h *Handlers) CbSettingsBack(c tele.Context) error {
-	h.log.Info("[handlers] settings_back callback received", telelog.Attr(c))
-	u := h.userGet(c)
-	_ = c.Respond()
-	text, rm := msgSettingsScene(&u.Settings)
-	return c.Edit(text, rm, tele.ModeHTML)
+	var startsAt *time.Time
+	if text := strings.TrimSpace(c.Text()); text != "0" {
+		if startsAt, err = parseStartsAt(text, h.timezone(event)); err != nil {
+			return c.Send(locale.ErrStartsAt, btnEventBack(eventID))
+		}
+	}
+
+	event, err = h.events.StartsAtSet(h.ctx(c), eventID, &u.Profile, startsAt)
+	if err != nil {
+		return h.sendManageErr(c, eventID, err)
+	}
+	h.log.Info("[handlers] event start time changed",
+		"event", event.LogValue(),
+		"starts_at", startsAt,
+		telelog.Trace(c))
+	return h.sendEventScene(c, event)
 }
 
-// CbSignup handles signup callback buttons.
-// Adds post to the event, re-renders event post, redirects user to signup deeplink
-func (h *Handlers) CbSignup(c tele.Context) error {
-	h.log.Info("[handlers] signup callback received", telelog.Attr(c))
+// timezone returns the time zone of the event start time
+// or the default time zone if the event has no start time.
+func (h *Handlers) timezone(event *models.Event) string {
+	if event.Timezone != "" {
+		return event.Timezone
+	}
+	return h.cfg.Timezone
+}
 
-	if len(c.Args()) < 2 {
-		h.log.Error("[handlers] signup callback: not enough arguments",
+// coOrganizersScene edits the callback message to the co-organizers scene.
+// If renew is true, the invite link is replaced with a new one.
+// Only the event owner can access the scene.
+func (h *Handlers) coOrganizersScene(c tele.Context, name string, renew bool) error {
+	h.log.Info("[handlers] "+name+" callback received", telelog.Attr(c))
+	if len(c.Args()) < 1 {
+		h.log.Error("[handlers] "+name+" callback: not enough arguments",
 			"args", c.Args(),
 			telelog.Attr(c))
 		return c.RespondAlert(locale.ErrSomethingWrong)
 	}
 
-	// Add post to the event and re-render it
+	u := h.userGet(c)
 	eventID := c.Args()[0]
-	inlineMessageID := c.Callback().MessageID
-	event, post, err := h.events.PostAdd(h.ctx(c), eventID, inlineMessageID)
+	event, err := h.events.InviteCreate(h.ctx(c), eventID, &u.Profile, renew)
 	if err != nil {
-		h.log.Error("[handlers] signup callback: failed add event post chat: "+err.Error(),
+		return h.respondManageErr(c, eventID, err)
+	}
+
+	if renew {
+		_ = c.Respond(&tele.CallbackResponse{Text: locale.DoneOK})
+	} else {
+		_ = c.Respond()
+	}
+	text, rm := msgCoOrganizersScene(event, &u.Settings)
+	return c.Edit(text, rm, tele.ModeHTML, tele.NoPreview)
+}
+
+// inviteAccept accepts the co-organizer invite and sends the event management scene.
+func (h *Handlers) inviteAccept(c tele.Context, eventID, token string) error {
+	u := h.userGet(c)
+	event, err := h.events.InviteAccept(h.ctx(c), eventID, token, &u.Profile)
+	if errors.Is(err, models.ErrInviteInvalid) {
+		h.log.Warn("[handlers] invite accept: invalid invite",
+			"event_id", eventID,
+			telelog.Trace(c))
+		return h.sendErr(c, locale.ErrInviteInvalid)
+	}
+	if err != nil {
+		h.log.Error("[handlers] invite accept: "+err.Error(),
 			"event_id", eventID,
 			telelog.Trace(c))
 		return h.sendErr(c, locale.ErrSomethingWrong)
 	}
-	h.log.Info("[handlers] signup callback: event post added",
+	h.log.Info("[handlers] invite accepted", "event", event.LogValue(), telelog.Trace(c))
+
+	if err = c.Send(locale.InviteAccepted, tele.RemoveKeyboard); err != nil {
+		return err
+	}
+	return h.sendEventScene(c, event)
+}
+
+// forbidText handles the text with the dancer to be forbidden to sign in for the event.
+// The dancer can be specified by @username or by forwarding a message from the dancer.
+func (h *Handlers) forbidText(c tele.Context, u *models.User) error {
+	eventID := u.Session.EventID
+	event, err := h.events.DancerForbid(h.ctx(c), eventID, &u.Profile, h.otherPerson(c))
+	if errors.Is(err, models.ErrUsernameRequired) {
+		return c.Send(locale.ErrForbidUsername, btnEventBack(eventID))
+	}
+	if err != nil {
+		return h.sendManageErr(c, eventID, err)
+	}
+	h.log.Info("[handlers] dancer forbidden",
 		"event", event.LogValue(),
-		"post", post.LogValue(),
 		telelog.Trace(c))
-	role := c.Args()[1]
-	dl := Deeplink{Action: models.SessionSignup, EventID: eventID, Role: models.Role(role)}
-	return c.Respond(&tele.CallbackResponse{URL: dl.String()})
+	text, rm := msgForbiddenScene(event)
+	return c.Send(text, rm, tele.ModeHTML, tele.NoPreview)
 }
 
-// UserShared - handles the user shared event.
-func (h *Handlers) UserShared(c tele.Context) error {
-	h.log.Info("[handlers] users_shared received", telelog.Attr(c))
-	u := h.userGet(c)
-	if u.Session.Action != models.SessionSignup {
-		h.log.Error("[handlers] unexpected user_shared", telelog.Trace(c))
-		return nil
+// blocklistText handles the text with the dancer to be added to the user blocklist.
+// The dancer can be specified by @username or by forwarding a message from the dancer.
+func (h *Handlers) blocklistText(c tele.Context, u *models.User) error {
+	err := h.users.BlocklistAdd(h.ctx(c), u, h.otherPerson(c))
+	if errors.Is(err, models.ErrUsernameRequired) {
+		return c.Send(locale.ErrForbidUsername, btnSettingsBack())
 	}
-
-	userShared := c.Message().UserShared.Users[0]
-	other := models.Profile{
-		ID:        userShared.UserID,
-		FirstName: userShared.FirstName,
-		LastName:  userShared.LastName,
-		Username:  userShared.Username,
+	if err != nil {
+		h.log.Error("[handlers] failed to add dancer to blocklist: "+err.Error(), telelog.Trace(c))
+		return h.sendErr(c, locale.ErrSomethingWrong)
 	}
+	h.log.Info("[handlers] dancer added to blocklist", telelog.Trace(c))
+	text, rm := msgBlocklistScene(&u.Settings)
+	return c.Send(text, rm, tele.ModeHTML, tele.NoPreview)
+}
 
-	return h.coupleAdd(c, u.Session.EventID, u.Session.Role, &other)
+// dancerAddText handles the text with the couple or the single to be registered by the organizer.
+// The couple is specified by two names separated by "+".
+// The single or the partner of the chosen single can be specified
+// by a name, @username or by forwarding a message from the dancer.
+func (h *Handlers) dancerAddText(c tele.Context, u *models.User) error {
+	eventID := u.Session.EventID
+	var (
+		reg *models.Registration
+		err error
+	)
+	switch {
+	case u.Session.Dancer != nil && u.Session.Dancer.Role == models.RoleLeader:
+		reg, err = h.events.CoupleAddByOrganizer(h.ctx(c), eventID, &u.Profile, u.Session.Dancer, h.otherPerson(c))
+	// the switch single is paired as a follower
+	case u.Session.Dancer != nil:
+		reg, err = h.events.CoupleAddByOrganizer(h.ctx(c), eventID, &u.Profile, h.otherPerson(c), u.Session.Dancer)
+	case u.Session.Role == "":
+		names := strings.Split(c.Text(), "+")
+		if len(names) != 2 {
+			return c.Send(locale.ErrDancerCouple, btnDancersBack(eventID))
+		}
+		reg, err = h.events.CoupleAddByOrganizer(h.ctx(c), eventID, &u.Profile,
+			strings.TrimSpace(names[0]), strings.TrimSpace(names[1]))
+	default:
+		reg, err = h.events.SingleAddByOrganizer(h.ctx(c), eventID, &u.Profile, u.Session.Role, h.otherPerson(c))
+	}
+	if err != nil {
+		return h.sendManageErr(c, eventID, err)
+	}
+	if !reg.Result.IsSuccess() {
+		return c.Send(resultByOrganizer(reg.Result), btnDancersBack(eventID))
+	}
+	h.log.Info("[handlers] dancer registered by organizer", "", reg, telelog.Trace(c))
 
+	u.Session = models.Session{
+		Action:  models.SessionManage,
+		EventID: eventID,
+	}
+	h.userUpsert(c, u)
+	text, rm := msgDancersScene(reg.Event, 0, h.cfg.DancersPageSize)
+	return c.Send(text, rm, tele.ModeHTML, tele.NoPreview)
 }
 
-// Partner - handles the /partner command (legacy).
-// This is to provide familiar user experience with github.com/Tayrinn/CoopDance bot.
-// The payload will be treated as a text message.
-func (h *Handlers) Partner(c tele.Context) error {
-	h.log.Info("[handlers] /partner received: payload will be treated as text message", telelog.Attr(c))
-	if c.Message().Payload == "" {
-		return nil
+// templateText handles text messages with the name of the new event template.
+// The template is made of the caption and settings of the event from the session.
+func (h *Handlers) templateText(c tele.Context, u *models.User) error {
+	eventID := u.Session.EventID
+	event, err := h.events.GetManaged(h.ctx(c), eventID, &u.Profile)
+	if err != nil {
+		return h.sendManageErr(c, eventID, err)
 	}
-	c.Message().Text = c.Message().Payload
-	c.Message().Payload = ""
-	return h.Text(c)
+
+	tmpl := models.EventTemplate{
+		Name:     strings.TrimSpace(c.Text()),
+		Caption:  event.Caption,
+		Settings: event.Settings,
+	}
+	err = h.users.TemplateAdd(h.ctx(c), u, tmpl)
+	switch {
+	case errors.Is(err, models.ErrTemplateName):
+		return c.Send(fmt.Sprintf(locale.ErrTemplateName, h.cfg.TemplateNameMaxLen), btnEventBack(eventID))
+	case errors.Is(err, models.ErrTemplatesLimit):
+		return c.Send(fmt.Sprintf(locale.ErrTemplatesLimit, h.cfg.TemplatesMax), btnEventBack(eventID))
+	case err != nil:
+		h.log.Error("[handlers] failed to add event template: "+err.Error(), telelog.Trace(c))
+		return h.sendErr(c, locale.ErrSomethingWrong)
+	}
+	h.log.Info("[handlers] event template added",
+		"event", event.LogValue(),
+		telelog.Trace(c))
+
+	if err = c.Send(fmt.Sprintf(locale.TemplateSaved, tmpl.Name)); err != nil {
+		return err
+	}
+	return h.sendEventScene(c, event)
 }
 
-// Text - handles text messages.
-func (h *Handlers) Text(c tele.Context) error {
-	h.log.Info("[handlers] text message received", "text", c.Text(), telelog.Attr(c))
+// otherPerson returns the other person specified in the message.
+// If the message is forwarded from a user, returns the profile of the user.
+// Otherwise, returns the message text.
+func (h *Handlers) otherPerson(c tele.Context) any {
+	if origin := c.Message().Origin; origin != nil && origin.Sender != nil {
+		profile := models.NewProfile(*origin.Sender)
+		return &profile
+	}
+	return strings.TrimSpace(c.Text())
+}
 
+// sendEventScene sends the event management scene as a new message.
+func (h *Handlers) sendEventScene(c tele.Context, event *models.Event) error {
 	u := h.userGet(c)
-	text := c.Text()
+	u.Session = models.Session{
+		Action:  models.SessionManage,
+		EventID: event.ID,
+	}
+	h.userUpsert(c, u)
+	text, rm := msgEventScene(event, &u.Profile)
+	return c.Send(text, rm, tele.ModeHTML, tele.NoPreview)
+}
 
-	switch {
-	case u.Session.Action != models.SessionSignup:
-		h.log.Info("[handlers] unexpected text", telelog.Trace(c))
-		return nil // todo maybe some help message or random joke or facts?
-	case text == locale.BtnClose:
-		u.Session = models.Session{}
-		h.userUpsert(c, u)
-		return sendCloseOK(c)
-	case text == locale.BtnRemove:
-		return h.dancerRemove(c, u.Session.EventID)
-	case text == locale.BtnAsSingle[u.Session.Role]:
-		return h.singleAdd(c, u.Session.EventID, u.Session.Role)
-	case isSingleCaption(text):
-		for _, single := range u.Session.Singles {
-			if single.Caption == text {
-				return h.coupleAdd(c, u.Session.EventID, u.Session.Role, &single.Profile)
-			}
-		}
-		return h.sendErr(c, locale.ErrSingleNotFound)
-	case len(text) > h.cfg.DancerNameMaxLen:
-		return h.sendErr(c, locale.ErrDancerNameTooLong)
-	default:
-		return h.coupleAdd(c, u.Session.EventID, u.Session.Role, text)
+// sendManageErr logs the event management error and sends an error message.
+func (h *Handlers) sendManageErr(c tele.Context, eventID string, err error) error {
+	if errors.Is(err, models.ErrNotOwner) {
+		h.log.Warn("[handlers] event management: not an owner",
+			"event_id", eventID,
+			telelog.Trace(c))
+		return h.sendErr(c, locale.ErrNotOwner)
+	}
+	if errors.Is(err, models.ErrAccessDenied) {
+		h.log.Warn("[handlers] event management: access denied",
+			"event_id", eventID,
+			telelog.Trace(c))
+		return h.sendErr(c, locale.ErrAccessDenied)
+	}
+	h.log.Error("[handlers] event management: "+err.Error(),
+		"event_id", eventID,
+		telelog.Trace(c))
+	return h.sendErr(c, locale.ErrSomethingWrong)
+}
+
+// respondManageErr logs the event management error and responds to the callback with an alert.
+func (h *Handlers) respondManageErr(c tele.Context, eventID string, err error) error {
+	if errors.Is(err, models.ErrNotOwner) {
+		h.log.Warn("[handlers] event management: not an owner",
+			"event_id", eventID,
+			telelog.Trace(c))
+		return c.RespondAlert(locale.ErrNotOwner)
+	}
+	if errors.Is(err, models.ErrAccessDenied) {
+		h.log.Warn("[handlers] event management: access denied",
+			"event_id", eventID,
+			telelog.Trace(c))
+		return c.RespondAlert(locale.ErrAccessDenied)
 	}
+	h.log.Error("[handlers] event management: "+err.Error(),
+		"event_id", eventID,
+		telelog.Trace(c))
+	return c.RespondAlert(locale.ErrSomethingWrong)
 }
 
-// signupScene returns the signup scene for the user.
-func (h *Handlers) signupScene(c tele.Context, eventID string, role models.Role) error {
+// coupleAdd handles the couple signup action
+func (h *Handlers) coupleAdd(c tele.Context, eventID string, role models.Role, other any) error {
 	u := h.userGet(c)
-	event, err := h.events.Get(h.ctx(c), eventID)
+
+	reg, err := h.events.CoupleAdd(h.ctx(c), eventID, &u.Profile, role, other)
 	if err != nil {
-		h.log.Error("[handlers] signup scene: failed to get event: "+err.Error(),
+		h.log.Error("[handlers] failed to add couple: "+err.Error(),
 			"event_id", eventID,
+			"profile", u.Profile.LogValue(),
 			telelog.Trace(c))
 		return h.sendErr(c, locale.ErrSomethingWrong)
 	}
+	h.log.Info("[handlers] couple add", "", reg, telelog.Trace(c))
 
-	reg := h.events.RegistrationGet(event, &u.Profile, role)
-
-	// if the dancer can register or already registered, update the session
+	// if the result is retryable, update the session
 	var singles []models.SessionSingle
-	if reg.Status.CanRegister() || reg.Status.IsRegistered() {
-		singles = fmtSingles(event.Singles, role.Opposite())
+	if reg.Result.IsRetryable() {
+		singles = fmtSingles(reg.Event.Singles, role, &u.Profile)
 		u.Session = models.Session{
 			Action:  models.SessionSignup,
 			EventID: eventID,
-			Role:    reg.Dancer.Role,
+			Role:    role,
 			Singles: singles,
 		}
 	} else {
@@ -305,41 +2056,54 @@ func (h *Handlers) signupScene(c tele.Context, eventID string, role models.Role)
 		u.Session = models.Session{}
 	}
 	h.userUpsert(c, u)
-
-	h.log.Info("[handlers] signup scene", "", reg, telelog.Trace(c))
-	return sendSignupScene(c, reg, singles)
+	return sendResult(c, reg, singles)
 }
 
-// coupleAdd handles the couple signup action
-func (h *Handlers) coupleAdd(c tele.Context, eventID string, role models.Role, other any) error {
+// partnerReplace handles the partner replacement action
+func (h *Handlers) partnerReplace(c tele.Context, eventID string, role models.Role, other any) error {
 	u := h.userGet(c)
 
-	reg, err := h.events.CoupleAdd(h.ctx(c), eventID, &u.Profile, role, other)
+	reg, err := h.events.PartnerReplace(h.ctx(c), eventID, &u.Profile, other)
 	if err != nil {
-		h.log.Error("[handlers] failed to add couple: "+err.Error(),
+		h.log.Error("[handlers] failed to replace partner: "+err.Error(),
 			"event_id", eventID,
 			"profile", u.Profile.LogValue(),
 			telelog.Trace(c))
 		return h.sendErr(c, locale.ErrSomethingWrong)
 	}
-	h.log.Info("[handlers] couple add", "", reg, telelog.Trace(c))
+	h.log.Info("[handlers] partner replace", "", reg, telelog.Trace(c))
 
-	// if the result is retryable, update the session
-	var singles []models.SessionSingle
+	// if the result is retryable, return to the signup scene
 	if reg.Result.IsRetryable() {
-		singles = fmtSingles(reg.Event.Singles, role.Opposite())
 		u.Session = models.Session{
 			Action:  models.SessionSignup,
 			EventID: eventID,
 			Role:    role,
-			Singles: singles,
 		}
 	} else {
-		// otherwise, reset the session
 		u.Session = models.Session{}
 	}
 	h.userUpsert(c, u)
-	return sendResult(c, reg, singles)
+	return sendResult(c, reg, nil)
+}
+
+// roleSwitch handles the switch of the roles in the couple
+func (h *Handlers) roleSwitch(c tele.Context, eventID string) error {
+	u := h.userGet(c)
+
+	reg, err := h.events.RoleSwitch(h.ctx(c), eventID, &u.Profile)
+	if err != nil {
+		h.log.Error("[handlers] failed to switch roles: "+err.Error(),
+			"event_id", eventID,
+			"profile", u.Profile.LogValue(),
+			telelog.Trace(c))
+		return h.sendErr(c, locale.ErrSomethingWrong)
+	}
+	h.log.Info("[handlers] role switch", "", reg, telelog.Trace(c))
+
+	u.Session = models.Session{}
+	h.userUpsert(c, u)
+	return sendResult(c, reg, nil)
 }
 
 // singleAdd handles the single signup action
@@ -357,10 +2121,14 @@ func (h *Handlers) singleAdd(c tele.Context, eventID string, role models.Role) e
 	}
 	h.log.Info("[handlers] single add", "", reg, telelog.Trace(c))
 
-	// if the result is retryable, update the session
+	// if the result is retryable, update the session.
+	// The switch dancer keeps the role chosen in the post.
 	var singles []models.SessionSingle
 	if reg.Result.IsRetryable() {
-		singles = fmtSingles(reg.Event.Singles, role.Opposite())
+		if role == models.RoleSwitch {
+			role = u.Session.Role
+		}
+		singles = fmtSingles(reg.Event.Singles, role, &u.Profile)
 		u.Session = models.Session{
 			Action:  models.SessionSignup,
 			EventID: eventID,
@@ -375,6 +2143,41 @@ func (h *Handlers) singleAdd(c tele.Context, eventID string, role models.Role) e
 	return sendResult(c, reg, singles)
 }
 
+// participantAdd handles the solo event signup action
+func (h *Handlers) participantAdd(c tele.Context, eventID string, role models.Role) error {
+	u := h.userGet(c)
+	profile := models.NewProfile(*c.Sender())
+
+	reg, err := h.events.ParticipantAdd(h.ctx(c), eventID, &profile, role)
+	if err != nil {
+		h.log.Error("[handlers] failed to add participant: "+err.Error(),
+			"event_id", eventID,
+			"profile", profile.LogValue(),
+			telelog.Trace(c))
+		return h.sendErr(c, locale.ErrSomethingWrong)
+	}
+	h.log.Info("[handlers] participant add", "", reg, telelog.Trace(c))
+
+	u.Session = models.Session{}
+	h.userUpsert(c, u)
+	return sendResult(c, reg, nil)
+}
+
+// dancerClaim handles the claim of the registration by the partner registered by name.
+func (h *Handlers) dancerClaim(c tele.Context, eventID, token string) error {
+	profile := models.NewProfile(*c.Sender())
+	reg, err := h.events.DancerClaim(h.ctx(c), eventID, &profile, token)
+	if err != nil {
+		h.log.Error("[handlers] failed to claim registration: "+err.Error(),
+			"event_id", eventID,
+			"profile", profile.LogValue(),
+			telelog.Trace(c))
+		return h.sendErr(c, locale.ErrSomethingWrong)
+	}
+	h.log.Info("[handlers] registration claim", "", reg, telelog.Trace(c))
+	return sendResult(c, reg, nil)
+}
+
 // dancerRemove handles the dancer remove action
 func (h *Handlers) dancerRemove(c tele.Context, eventID string) error {
 	u := h.userGet(c)
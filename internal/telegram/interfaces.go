@@ -2,6 +2,7 @@ package telegram
 
 import (
 	"context"
+	"time"
 
 	"github.com/ofstudio/dancegobot/internal/models"
 )
@@ -9,15 +10,51 @@ import (
 type UserService interface {
 	Get(ctx context.Context, profile models.Profile) (*models.User, error)
 	Upsert(ctx context.Context, user *models.User) error
+	BlocklistAdd(ctx context.Context, user *models.User, other any) error
+	BlocklistRemove(ctx context.Context, user *models.User, key string) error
+	TemplateAdd(ctx context.Context, user *models.User, tmpl models.EventTemplate) error
+	TemplateRemove(ctx context.Context, user *models.User, name string) error
+	EventMute(ctx context.Context, user *models.User, eventID, title string) error
+	EventUnmute(ctx context.Context, user *models.User, eventID string) error
 }
 
 type EventService interface {
-	Create(ctx context.Context, caption string, owner models.Profile, settings models.EventSettings) (*models.Event, error)
+	Draft(caption string, owner models.Profile, settings models.EventSettings) (*models.Event, error)
 	Get(ctx context.Context, id string) (*models.Event, error)
+	GetByManager(ctx context.Context, profileID int64, limit, offset int) ([]*models.Event, error)
+	GetManaged(ctx context.Context, id string, profile *models.Profile) (*models.Event, error)
 	PostAdd(ctx context.Context, eventID string, inlineMessageID string) (*models.Event, *models.Post, error)
 	PostChatAdd(ctx context.Context, eventID string, chat *models.Chat, chatMessageID int) (*models.Event, *models.Post, error)
-	RegistrationGet(event *models.Event, profile *models.Profile, role models.Role) *models.Registration
+	RegistrationGet(ctx context.Context, event *models.Event, profile *models.Profile, role models.Role) (*models.Registration, error)
 	CoupleAdd(ctx context.Context, eventID string, profile *models.Profile, role models.Role, other any) (*models.Registration, error)
 	SingleAdd(ctx context.Context, eventID string, profile *models.Profile, role models.Role) (*models.Registration, error)
+	ParticipantAdd(ctx context.Context, eventID string, profile *models.Profile, role models.Role) (*models.Registration, error)
 	DancerRemove(ctx context.Context, eventID string, profile *models.Profile) (*models.Registration, error)
+	PartnerReplace(ctx context.Context, eventID string, profile *models.Profile, other any) (*models.Registration, error)
+	RoleSwitch(ctx context.Context, eventID string, profile *models.Profile) (*models.Registration, error)
+	DancerClaim(ctx context.Context, eventID string, profile *models.Profile, token string) (*models.Registration, error)
+	PendingAccept(ctx context.Context, eventID string, profile *models.Profile, requesterID int64) (*models.Registration, error)
+	PendingDecline(ctx context.Context, eventID string, profile *models.Profile, requesterID int64) (bool, error)
+	SingleRefresh(ctx context.Context, eventID string, profile *models.Profile) (bool, error)
+	CoupleAddByOrganizer(ctx context.Context, eventID string, profile *models.Profile, leader, follower any) (*models.Registration, error)
+	SingleAddByOrganizer(ctx context.Context, eventID string, profile *models.Profile, role models.Role, other any) (*models.Registration, error)
+	SinglesPair(ctx context.Context, eventID string, profile *models.Profile, leader, follower *models.Dancer) (*models.Registration, error)
+	DancerRemoveByOrganizer(ctx context.Context, eventID string, profile *models.Profile, dancer *models.Dancer) (*models.Registration, error)
+	LimitSet(ctx context.Context, eventID string, profile *models.Profile, limit int) (*models.Event, error)
+	CaptionSet(ctx context.Context, eventID string, profile *models.Profile, caption string) (*models.Event, error)
+	StartsAtSet(ctx context.Context, eventID string, profile *models.Profile, startsAt *time.Time) (*models.Event, error)
+	RecurrenceSet(ctx context.Context, eventID string, profile *models.Profile, recurrence models.Recurrence) (*models.Event, error)
+	LevelsSet(ctx context.Context, eventID string, profile *models.Profile, minLevel, maxLevel models.Level) (*models.Event, error)
+	SoloSet(ctx context.Context, eventID string, profile *models.Profile, solo bool) (*models.Event, error)
+	DeadlinesSet(ctx context.Context, eventID string, profile *models.Profile, signup, cancel time.Duration) (*models.Event, error)
+	SingleTTLSet(ctx context.Context, eventID string, profile *models.Profile, ttl time.Duration) (*models.Event, error)
+	ConsentSet(ctx context.Context, eventID string, profile *models.Profile, consent bool) (*models.Event, error)
+	Clone(ctx context.Context, eventID string, profile *models.Profile, withCouples bool) (*models.Event, error)
+	CoupleRepeatSet(ctx context.Context, eventID string, profile *models.Profile, repeat bool) (*models.Registration, error)
+	ClosedForSet(ctx context.Context, eventID string, profile *models.Profile, closedFor models.ClosedFor) (*models.Event, error)
+	DancerForbid(ctx context.Context, eventID string, profile *models.Profile, other any) (*models.Event, error)
+	DancerAllow(ctx context.Context, eventID string, profile *models.Profile, key string) (*models.Event, error)
+	InviteCreate(ctx context.Context, eventID string, profile *models.Profile, renew bool) (*models.Event, error)
+	InviteAccept(ctx context.Context, eventID string, token string, profile *models.Profile) (*models.Event, error)
+	CoOrganizerRemove(ctx context.Context, eventID string, profile *models.Profile, profileID int64) (*models.Event, error)
 }
@@ -20,6 +20,21 @@ func Notify(api tele.API) func(n *models.Notification) error {
 			return err
 		}
 		rm := btnChatLink(n.Payload.Event)
+		if n.TmplCode == models.TmplNextEventCreated {
+			rm = btnPublish(n.Payload.Event)
+		}
+		if n.TmplCode == models.TmplConsentRequest && n.Payload.Partner != nil && n.Payload.Partner.Profile != nil {
+			rm = btnConsent(n.Payload.Event.ID, n.Payload.Partner.Profile.ID)
+		}
+		if n.TmplCode == models.TmplSingleJoined && n.Payload.Partner != nil && n.Payload.Partner.Profile != nil {
+			rm = btnPairWith(n.Payload.Event.ID, n.Payload.Partner)
+		}
+		if n.TmplCode == models.TmplSingleExpiring {
+			rm = btnSingleRefresh(n.Payload.Event.ID)
+		}
+		if n.TmplCode.EventMutable() && n.Payload.Event != nil {
+			rm = btnMuteEvent(rm, n.Payload.Event.ID)
+		}
 
 		// Send notification
 		user := &tele.User{ID: n.Recipient.ID}
@@ -52,6 +67,13 @@ func init() {
 		"urlTo": func(p *models.Profile) template.URL {
 			return template.URL(fmtProfileURL(p))
 		},
+		"startsAt": fmtStartsAt,
+		"activity": func(a models.HistoryAction) string {
+			return locale.OwnerActivity[a]
+		},
+		"digest": func(a models.HistoryAction) string {
+			return locale.OwnerDigest[a]
+		},
 	}).Parse(locale.NotificationsBase)
 	if err != nil {
 		panic(fmt.Sprintf("failed to parse notification base template: %v", err))
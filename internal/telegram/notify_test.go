@@ -2,6 +2,7 @@ package telegram
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -69,6 +70,203 @@ func Test_notifyText(t *testing.T) {
 			"🔔 Test Event\n\n<a href=\"tg://user?id=1\">Test Partner</a> отменил вашу регистрацию. \nЯ записал тебя вместе с <a href=\"https://t.me/new_partner\">New Partner</a> 👌",
 			text.String())
 	})
+
+	t.Run("TmplWaitlistPromoted", func(t *testing.T) {
+		n := &models.Notification{
+			TmplCode: models.TmplWaitlistPromoted,
+			Payload:  testPayload,
+		}
+		text, err := notifyText(n)
+		require.NoError(t, err)
+		assert.Equal(t,
+			"🔔 Test Event\n\nОсвободилось место! Вы с <a href=\"tg://user?id=1\">Test Partner</a> перешли из листа ожидания в список пар 🎉",
+			text.String())
+	})
+
+	t.Run("TmplDancerForbidden", func(t *testing.T) {
+		n := &models.Notification{
+			TmplCode: models.TmplDancerForbidden,
+			Payload:  testPayload,
+		}
+		text, err := notifyText(n)
+		require.NoError(t, err)
+		assert.Equal(t,
+			"🔔 Test Event\n\nОрганизатор запретил тебе записываться на это мероприятие. Твоя регистрация удалена 😔",
+			text.String())
+	})
+
+	t.Run("TmplPartnerRemoved", func(t *testing.T) {
+		n := &models.Notification{
+			TmplCode: models.TmplPartnerRemoved,
+			Payload:  testPayload,
+		}
+		text, err := notifyText(n)
+		require.NoError(t, err)
+		assert.Equal(t,
+			"🔔 Test Event\n\nОрганизатор удалил <a href=\"tg://user?id=1\">Test Partner</a> из списка участников, поэтому ваша регистрация отменена 😔",
+			text.String())
+	})
+
+	t.Run("TmplPartnerRemovedWithSingle", func(t *testing.T) {
+		n := &models.Notification{
+			TmplCode: models.TmplPartnerRemovedWithSingle,
+			Payload:  testPayload,
+		}
+		text, err := notifyText(n)
+		require.NoError(t, err)
+		assert.Equal(t,
+			"🔔 Test Event\n\nОрганизатор удалил <a href=\"tg://user?id=1\">Test Partner</a> из списка участников. Я вернул тебя в список ищущих пару 🤗",
+			text.String())
+	})
+
+	t.Run("TmplPartnerRemovedAutoPair", func(t *testing.T) {
+		n := &models.Notification{
+			TmplCode: models.TmplPartnerRemovedAutoPair,
+			Payload:  testPayload,
+		}
+		text, err := notifyText(n)
+		require.NoError(t, err)
+		assert.Equal(t,
+			"🔔 Test Event\n\nОрганизатор удалил <a href=\"tg://user?id=1\">Test Partner</a> из списка участников. \nЯ записал тебя вместе с <a href=\"https://t.me/new_partner\">New Partner</a> 👌",
+			text.String())
+	})
+
+	t.Run("TmplCoupleAddedByOrganizer", func(t *testing.T) {
+		n := &models.Notification{
+			TmplCode: models.TmplCoupleAddedByOrganizer,
+			Payload:  testPayload,
+		}
+		text, err := notifyText(n)
+		require.NoError(t, err)
+		assert.Equal(t,
+			"🔔 Test Event\n\nОрганизатор записал тебя в пару с <a href=\"tg://user?id=1\">Test Partner</a> 🎉",
+			text.String())
+	})
+
+	t.Run("TmplSingleAddedByOrganizer", func(t *testing.T) {
+		n := &models.Notification{
+			TmplCode: models.TmplSingleAddedByOrganizer,
+			Payload:  testPayload,
+		}
+		text, err := notifyText(n)
+		require.NoError(t, err)
+		assert.Equal(t,
+			"🔔 Test Event\n\nОрганизатор записал тебя в список ищущих пару 🙋",
+			text.String())
+	})
+
+	t.Run("TmplRemovedByOrganizer", func(t *testing.T) {
+		n := &models.Notification{
+			TmplCode: models.TmplRemovedByOrganizer,
+			Payload:  testPayload,
+		}
+		text, err := notifyText(n)
+		require.NoError(t, err)
+		assert.Equal(t,
+			"🔔 Test Event\n\nОрганизатор удалил тебя из списка участников 😔",
+			text.String())
+	})
+
+	t.Run("TmplPairedByOrganizer", func(t *testing.T) {
+		n := &models.Notification{
+			TmplCode: models.TmplPairedByOrganizer,
+			Payload:  testPayload,
+		}
+		text, err := notifyText(n)
+		require.NoError(t, err)
+		assert.Equal(t,
+			"🔔 Test Event\n\nОрганизатор подобрал тебе пару: <a href=\"tg://user?id=1\">Test Partner</a> 👌",
+			text.String())
+	})
+
+	t.Run("TmplCoOrganizerAdded", func(t *testing.T) {
+		n := &models.Notification{
+			TmplCode: models.TmplCoOrganizerAdded,
+			Payload:  testPayload,
+		}
+		text, err := notifyText(n)
+		require.NoError(t, err)
+		assert.Equal(t,
+			"🔔 Test Event\n\n<a href=\"tg://user?id=1\">Test Partner</a> теперь соорганизатор мероприятия 👥",
+			text.String())
+	})
+
+	t.Run("TmplNextEventCreated", func(t *testing.T) {
+		n := &models.Notification{
+			TmplCode: models.TmplNextEventCreated,
+			Payload:  testPayload,
+		}
+		text, err := notifyText(n)
+		require.NoError(t, err)
+		assert.Equal(t,
+			"🔁 Test Event\n\nЯ подготовил анонс следующего мероприятия. Нажми кнопку ниже и выбери чат, чтобы опубликовать его 📣",
+			text.String())
+	})
+
+	t.Run("TmplReminderCouple", func(t *testing.T) {
+		n := &models.Notification{
+			TmplCode: models.TmplReminderCouple,
+			Payload:  testPayloadStartsAt,
+		}
+		text, err := notifyText(n)
+		require.NoError(t, err)
+		assert.Equal(t,
+			"🔔 Test Event\n\n⏰ Напоминаю, что вы с <a href=\"tg://user?id=1\">Test Partner</a> записаны на это мероприятие. Начало: 31.12.2025 19:30 (Europe/Moscow)",
+			text.String())
+	})
+
+	t.Run("TmplReminderSingle", func(t *testing.T) {
+		n := &models.Notification{
+			TmplCode: models.TmplReminderSingle,
+			Payload:  testPayloadStartsAt,
+		}
+		text, err := notifyText(n)
+		require.NoError(t, err)
+		assert.Equal(t,
+			"🔔 Test Event\n\n⏰ Напоминаю, что ты записан на это мероприятие и ищешь пару. Начало: 31.12.2025 19:30 (Europe/Moscow)",
+			text.String())
+	})
+	t.Run("TmplOwnerActivity", func(t *testing.T) {
+		n := &models.Notification{
+			TmplCode: models.TmplOwnerActivity,
+			Payload: models.NotificationPayload{
+				Event: testPayload.Event,
+				Activity: []models.ActivityItem{
+					{Action: models.HistoryCoupleAdded, Dancers: []models.Dancer{*testPayload.Partner, *testPayload.NewPartner}},
+					{Action: models.HistorySingleRemoved, Dancers: []models.Dancer{{FullName: "Jane"}}},
+				},
+			},
+		}
+		text, err := notifyText(n)
+		require.NoError(t, err)
+		assert.Equal(t,
+			"🔔 Test Event\n\n👫 Новая пара: <a href=\"tg://user?id=1\">Test Partner</a> и <a href=\"https://t.me/new_partner\">New Partner</a>"+
+				"\n👋 Больше не ищет пару: <a href=\"\">Jane</a>",
+			text.String())
+	})
+
+	t.Run("TmplOwnerDigest", func(t *testing.T) {
+		n := &models.Notification{
+			TmplCode: models.TmplOwnerDigest,
+			Payload: models.NotificationPayload{
+				Digest: []models.DigestItem{
+					{Caption: "Test Event", Counts: map[models.HistoryAction]int{
+						models.HistorySingleAdded: 1,
+						models.HistoryCoupleAdded: 3,
+					}},
+					{Caption: "Other Event", Counts: map[models.HistoryAction]int{
+						models.HistoryCoupleRemoved: 2,
+					}},
+				},
+			},
+		}
+		text, err := notifyText(n)
+		require.NoError(t, err)
+		assert.Equal(t,
+			"📊 <b>Сводка за сутки</b>\n\n<b>Test Event</b>\n👫 Новых пар: 3\n🙋 Новых ищущих пару: 1\n"+
+				"\n<b>Other Event</b>\n🗑 Отменено пар: 2\n",
+			text.String())
+	})
 }
 
 var testPayload = models.NotificationPayload{
@@ -89,3 +287,14 @@ var testPayload = models.NotificationPayload{
 		FullName: "New Partner",
 	},
 }
+
+var testStartsAt = time.Date(2025, 12, 31, 16, 30, 0, 0, time.UTC)
+
+var testPayloadStartsAt = models.NotificationPayload{
+	Event: &models.Event{
+		Caption:  "Test Event",
+		StartsAt: &testStartsAt,
+		Timezone: "Europe/Moscow",
+	},
+	Partner: testPayload.Partner,
+}
@@ -2,6 +2,7 @@ package telegram
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -21,7 +22,7 @@ func RenderPost(api tele.API) func(*models.Event, string) error {
 // render renders the event post.
 func render(api tele.API, event *models.Event, inlineMessageID string) error {
 	textSB := renderText(event)
-	rm := btnPostURL(event.ID)
+	rm := btnPostURL(event.ID, event.Settings.Solo)
 	msg := &tele.InlineResult{MessageID: inlineMessageID}
 	opts := &tele.SendOptions{
 		ReplyMarkup:           rm,
@@ -40,12 +41,36 @@ func renderText(event *models.Event) *strings.Builder {
 	sb.WriteString(event.Caption)
 	sb.WriteString("\n\n")
 
+	if banner, ok := locale.PostClosed[event.Settings.ClosedFor]; ok {
+		sb.WriteString(banner)
+		sb.WriteString("\n\n")
+	}
+
+	sbDeadlines(sb, event)
+
+	if event.Settings.Solo {
+		sbParticipants(sb, event.Participants)
+		return sb
+	}
+
 	if len(event.Couples) > 0 {
 		sb.WriteString(locale.PostCouples)
 		sbCouples(sb, event.Couples)
 		sb.WriteByte('\n')
 	}
 
+	if len(event.Waitlist) > 0 {
+		sb.WriteString(locale.PostWaitlist)
+		sbCouples(sb, event.Waitlist)
+		sb.WriteByte('\n')
+	}
+
+	if len(event.Pending) > 0 {
+		sb.WriteString(locale.PostPending)
+		sbPending(sb, event.Pending)
+		sb.WriteByte('\n')
+	}
+
 	if len(event.Singles) > 0 {
 		leaders, followers := singlesByRole(event.Singles)
 		if len(leaders) > len(followers) {
@@ -59,6 +84,41 @@ func renderText(event *models.Event) *strings.Builder {
 	return sb
 }
 
+// sbParticipants writes the numbered list of the solo event participants
+// followed by the headcount by role.
+func sbParticipants(sb *strings.Builder, participants []models.Dancer) {
+	if len(participants) == 0 {
+		return
+	}
+	sb.WriteString(locale.PostParticipants)
+	sb.WriteString(fmtParticipantsList(participants))
+	var leaders, followers int
+	for _, p := range participants {
+		switch p.Role {
+		case models.RoleLeader:
+			leaders++
+		case models.RoleFollower:
+			followers++
+		}
+	}
+	sb.WriteString(fmt.Sprintf(locale.PostHeadcount, len(participants), leaders, followers))
+}
+
+// sbDeadlines writes the registration and cancellation deadlines of the event (if any).
+func sbDeadlines(sb *strings.Builder, event *models.Event) {
+	signup, cancel := event.SignupDeadlineAt(), event.CancelDeadlineAt()
+	if signup == nil && cancel == nil {
+		return
+	}
+	if signup != nil {
+		sb.WriteString(fmt.Sprintf(locale.PostSignupDeadline, fmtEventTime(event, *signup)))
+	}
+	if cancel != nil {
+		sb.WriteString(fmt.Sprintf(locale.PostCancelDeadline, fmtEventTime(event, *cancel)))
+	}
+	sb.WriteByte('\n')
+}
+
 func sbCouples(sb *strings.Builder, couples []models.Couple) {
 	for i, c := range couples {
 		sb.WriteString(strconv.Itoa(i + 1))
@@ -70,6 +130,18 @@ func sbCouples(sb *strings.Builder, couples []models.Couple) {
 	}
 }
 
+// sbPending writes the couples waiting for the partner confirmation in italics.
+func sbPending(sb *strings.Builder, couples []models.Couple) {
+	for i, c := range couples {
+		sb.WriteString(strconv.Itoa(i + 1))
+		sb.WriteString(". <i>")
+		sb.WriteString(fmtDancer(&c.Dancers[0]))
+		sb.WriteString(" – ")
+		sb.WriteString(fmtDancer(&c.Dancers[1]))
+		sb.WriteString("</i>\n")
+	}
+}
+
 func sbSingles(sb *strings.Builder, s1, s2 []models.Dancer) {
 	for i, s := range s1 {
 		sbSingle(sb, i+1, s)
@@ -86,15 +158,25 @@ func sbSingle(sb *strings.Builder, i int, single models.Dancer) {
 	sb.WriteString(strconv.Itoa(i))
 	sb.WriteString(". ")
 	sb.WriteString(fmtDancer(&single))
+	if single.Role == models.RoleSwitch {
+		sb.WriteByte(' ')
+		sb.WriteString(locale.RoleIcon[models.RoleSwitch])
+	}
 	sb.WriteByte('\n')
 }
 
+// singlesByRole splits the singles by role.
+// The switch dancers are listed both as leaders and followers.
 func singlesByRole(singles []models.Dancer) ([]models.Dancer, []models.Dancer) {
 	var leaders, followers []models.Dancer
 	for _, d := range singles {
-		if d.Role == models.RoleLeader {
+		switch d.Role {
+		case models.RoleLeader:
 			leaders = append(leaders, d)
-		} else {
+		case models.RoleSwitch:
+			leaders = append(leaders, d)
+			followers = append(followers, d)
+		default:
 			followers = append(followers, d)
 		}
 	}
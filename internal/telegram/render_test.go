@@ -0,0 +1,133 @@
+package telegram
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ofstudio/dancegobot/internal/locale"
+	"github.com/ofstudio/dancegobot/internal/models"
+)
+
+func Test_renderText(t *testing.T) {
+	t.Run("open event", func(t *testing.T) {
+		event := &models.Event{Caption: "Test Event"}
+		assert.Equal(t, "Test Event\n\n", renderText(event).String())
+	})
+
+	t.Run("closed event", func(t *testing.T) {
+		event := &models.Event{
+			Caption:  "Test Event",
+			Settings: models.EventSettings{ClosedFor: models.ClosedForAll},
+		}
+		assert.Equal(t,
+			"Test Event\n\n"+locale.PostClosed[models.ClosedForAll]+"\n\n",
+			renderText(event).String())
+	})
+
+	t.Run("closed for singles", func(t *testing.T) {
+		event := &models.Event{
+			Caption:  "Test Event",
+			Settings: models.EventSettings{ClosedFor: models.ClosedForSingles},
+			Couples: []models.Couple{{Dancers: []models.Dancer{
+				{FullName: "John Doe", Role: models.RoleLeader},
+				{FullName: "Jane Doe", Role: models.RoleFollower},
+			}}},
+		}
+		assert.Equal(t,
+			"Test Event\n\n"+locale.PostClosed[models.ClosedForSingles]+"\n\n"+
+				locale.PostCouples+"1. John Doe – Jane Doe\n\n",
+			renderText(event).String())
+	})
+	t.Run("with waitlist", func(t *testing.T) {
+		event := &models.Event{
+			Caption: "Test Event",
+			Couples: []models.Couple{{Dancers: []models.Dancer{
+				{FullName: "John Doe", Role: models.RoleLeader},
+				{FullName: "Jane Doe", Role: models.RoleFollower},
+			}}},
+			Waitlist: []models.Couple{{Dancers: []models.Dancer{
+				{FullName: "Jack Smith", Role: models.RoleLeader},
+				{FullName: "Jill Smith", Role: models.RoleFollower},
+			}}},
+		}
+		assert.Equal(t,
+			"Test Event\n\n"+
+				locale.PostCouples+"1. John Doe – Jane Doe\n\n"+
+				locale.PostWaitlist+"1. Jack Smith – Jill Smith\n\n",
+			renderText(event).String())
+	})
+
+	t.Run("with switch single", func(t *testing.T) {
+		event := &models.Event{
+			Caption: "Test Event",
+			Singles: []models.Dancer{
+				{FullName: "John Doe", Role: models.RoleLeader},
+				{FullName: "Alex Smith", Role: models.RoleSwitch},
+			},
+		}
+		assert.Equal(t,
+			"Test Event\n\n"+
+				locale.PostSingles[models.RoleLeader]+
+				"1. John Doe\n2. Alex Smith "+locale.RoleIcon[models.RoleSwitch]+"\n\n"+
+				"1. Alex Smith "+locale.RoleIcon[models.RoleSwitch]+"\n",
+			renderText(event).String())
+	})
+
+	t.Run("solo event", func(t *testing.T) {
+		event := &models.Event{
+			Caption:  "Test Event",
+			Settings: models.EventSettings{Solo: true},
+			Participants: []models.Dancer{
+				{FullName: "John Doe", Role: models.RoleLeader},
+				{FullName: "Alex Smith"},
+				{FullName: "Jane Doe", Role: models.RoleFollower},
+			},
+		}
+		assert.Equal(t,
+			"Test Event\n\n"+
+				locale.PostParticipants+
+				"1. John Doe "+locale.RoleIcon[models.RoleLeader]+"\n"+
+				"2. Alex Smith\n"+
+				"3. Jane Doe "+locale.RoleIcon[models.RoleFollower]+"\n"+
+				fmt.Sprintf(locale.PostHeadcount, 3, 1, 1),
+			renderText(event).String())
+	})
+
+	t.Run("pending couples", func(t *testing.T) {
+		event := &models.Event{
+			Caption: "Test Event",
+			Couples: []models.Couple{
+				{Dancers: []models.Dancer{{FullName: "John Doe"}, {FullName: "Jane Doe"}}},
+			},
+			Pending: []models.Couple{
+				{Dancers: []models.Dancer{{FullName: "Alex Smith"}, {FullName: "Anna Smith"}}},
+			},
+		}
+		assert.Equal(t,
+			"Test Event\n\n"+
+				locale.PostCouples+
+				"1. John Doe – Jane Doe\n\n"+
+				locale.PostPending+
+				"1. <i>Alex Smith – Anna Smith</i>\n\n",
+			renderText(event).String())
+	})
+
+	t.Run("deadlines", func(t *testing.T) {
+		startsAt := time.Date(2025, 12, 31, 16, 30, 0, 0, time.UTC)
+		event := &models.Event{
+			Caption:  "Test Event",
+			StartsAt: &startsAt,
+			Timezone: "Europe/Moscow",
+			Settings: models.EventSettings{SignupDeadline: 3 * time.Hour, CancelDeadline: 24 * time.Hour},
+		}
+		assert.Equal(t,
+			"Test Event\n\n"+
+				fmt.Sprintf(locale.PostSignupDeadline, "31.12.2025 16:30")+
+				fmt.Sprintf(locale.PostCancelDeadline, "30.12.2025 19:30")+
+				"\n",
+			renderText(event).String())
+	})
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"math/rand"
 	"regexp"
+	"slices"
 	"strconv"
+	"strings"
 	"unicode/utf8"
 
 	tele "gopkg.in/telebot.v4"
@@ -40,21 +42,60 @@ func fmtDancer(d *models.Dancer) string {
 	return "<a href='" + fmtProfileURL(d.Profile) + "'>" + d.FullName + "</a>"
 }
 
-// fmtSingles makes [models.SessionSingle] from the list of singles with given role.
+// fmtDancersList formats the numbered list of dancers with links to the Telegram profiles.
+func fmtDancersList(dancers []models.Dancer) string {
+	var sb strings.Builder
+	for i, d := range dancers {
+		sb.WriteString(strconv.Itoa(i+1) + ". " + fmtDancer(&d) + "\n")
+	}
+	return sb.String()
+}
+
+// fmtCouplesList formats the numbered list of couples with links to the Telegram profiles.
+func fmtCouplesList(couples []models.Couple) string {
+	var sb strings.Builder
+	for i, couple := range couples {
+		sb.WriteString(strconv.Itoa(i+1) + ". " + fmtDancer(&couple.Dancers[0]) + " + " + fmtDancer(&couple.Dancers[1]) + "\n")
+	}
+	return sb.String()
+}
+
+// fmtParticipantsList formats the numbered list of the solo event participants
+// with the role icons and links to the Telegram profiles.
+func fmtParticipantsList(participants []models.Dancer) string {
+	var sb strings.Builder
+	for i, d := range participants {
+		sb.WriteString(strconv.Itoa(i+1) + ". " + fmtDancer(&d))
+		if icon, ok := locale.RoleIcon[d.Role]; ok {
+			sb.WriteString(" " + icon)
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// fmtSingles makes [models.SessionSingle] from the list of singles
+// who can dance with the given role, except the dancer with the given profile.
 // Returns the list of profiles with reply button captions.
-// Caption format: "1. Full Name (@username)"
-// or just "1. Full Name" if no Telegram username.
-func fmtSingles(singles []models.Dancer, role models.Role) []models.SessionSingle {
+// Caption format: "1. Full Name (@username) 🔄 · level"
+// or just "1. Full Name" if no Telegram username, no switch role and no dance level.
+func fmtSingles(singles []models.Dancer, role models.Role, self *models.Profile) []models.SessionSingle {
 	var s []models.SessionSingle
 	for i, d := range singles {
-		if d.Profile == nil {
+		if d.Profile == nil || (self != nil && d.Profile.ID == self.ID) {
 			continue
 		}
-		if d.Role == role {
+		if role.Pairs(d.Role) {
 			caption := strconv.Itoa(i+1) + ". " + d.FullName
 			if d.Profile.Username != "" {
 				caption += " (@" + d.Profile.Username + ")"
 			}
+			if d.Role == models.RoleSwitch {
+				caption += " " + locale.RoleIcon[models.RoleSwitch]
+			}
+			if d.Level != models.LevelNone {
+				caption += " · " + locale.Level[d.Level]
+			}
 			s = append(s, models.SessionSingle{
 				Caption: caption,
 				Profile: *d.Profile,
@@ -84,35 +125,51 @@ func btnTry() *tele.ReplyMarkup {
 var BtnCbSignup = tele.Btn{Unique: models.SessionSignup.String()}
 
 // btnPostCb creates callback buttons for the event post.
-func btnPostCb(eventID string) *tele.ReplyMarkup {
+// The solo event post has an additional button to sign up without a role.
+func btnPostCb(eventID string, solo bool) *tele.ReplyMarkup {
 	rm := &tele.ReplyMarkup{}
-	rm.Inline(
-		rm.Row(
-			rm.Data(locale.RoleIcon[models.RoleLeader],
-				BtnCbSignup.Unique,
-				eventID, models.RoleLeader.String(), randtoken.New(4)),
-			rm.Data(locale.RoleIcon[models.RoleFollower],
-				BtnCbSignup.Unique,
-				eventID, models.RoleFollower.String(), randtoken.New(4)),
-		),
+	var row tele.Row
+	if solo {
+		row = append(row, rm.Data(locale.BtnParticipant,
+			BtnCbSignup.Unique,
+			eventID, "", randtoken.New(4)))
+	}
+	row = append(row,
+		rm.Data(locale.RoleIcon[models.RoleLeader],
+			BtnCbSignup.Unique,
+			eventID, models.RoleLeader.String(), randtoken.New(4)),
+		rm.Data(locale.RoleIcon[models.RoleFollower],
+			BtnCbSignup.Unique,
+			eventID, models.RoleFollower.String(), randtoken.New(4)),
 	)
+	rm.Inline(row)
 	return rm
 }
 
 // btnPostURL creates URL buttons for the event post.
-func btnPostURL(eventID string) *tele.ReplyMarkup {
+// The solo event post has an additional button to sign up without a role.
+func btnPostURL(eventID string, solo bool) *tele.ReplyMarkup {
 	rm := &tele.ReplyMarkup{}
 	dlLeader := Deeplink{Action: models.SessionSignup, EventID: eventID, Role: models.RoleLeader}
 	dlFollower := Deeplink{Action: models.SessionSignup, EventID: eventID, Role: models.RoleFollower}
-	rm.Inline(rm.Row(
+	var row tele.Row
+	if solo {
+		dl := Deeplink{Action: models.SessionSignup, EventID: eventID}
+		row = append(row, rm.URL(locale.BtnParticipant, dl.String()))
+	}
+	row = append(row,
 		rm.URL(locale.RoleIcon[models.RoleLeader], dlLeader.String()),
 		rm.URL(locale.RoleIcon[models.RoleFollower], dlFollower.String()),
-	))
+	)
+	rm.Inline(row)
 	return rm
 }
 
 // btnSignupScene creates buttons for the signup scene.
 func btnSignupScene(reg *models.Registration, singles []models.SessionSingle) *tele.ReplyMarkup {
+	if reg.Event.Settings.Solo {
+		return btnSignupSoloScene(reg)
+	}
 	rm := &tele.ReplyMarkup{
 		ResizeKeyboard: true,
 		Placeholder:    locale.SignupPlaceholder,
@@ -142,6 +199,17 @@ func btnSignupScene(reg *models.Registration, singles []models.SessionSingle) *t
 	if reg.Status == models.StatusNotRegistered &&
 		(reg.Event.Settings.AutoPairing || len(singles) == 0) {
 		rows = append(rows, rm.Row(rm.Text(locale.BtnAsSingle[reg.Role])))
+		rows = append(rows, rm.Row(rm.Text(locale.BtnAsSingle[models.RoleSwitch])))
+	}
+
+	// Add "repeat" button if the dancer is in couple for the recurring event
+	if reg.Status == models.StatusInCouple && reg.Event.Recurrence != models.RecurrenceNone {
+		rows = append(rows, rm.Row(rm.Text(locale.BtnRepeat[isRepeat(reg)])))
+	}
+
+	// Add "replace partner" and "switch roles" buttons if the dancer is in couple
+	if reg.Status == models.StatusInCouple {
+		rows = append(rows, rm.Row(rm.Text(locale.BtnReplacePartner), rm.Text(locale.BtnRoleSwitch)))
 	}
 
 	// Add "remove" button if the dancer is already registered
@@ -156,6 +224,108 @@ func btnSignupScene(reg *models.Registration, singles []models.SessionSingle) *t
 	return rm
 }
 
+// btnReplaceScene creates buttons for the partner replacement scene:
+// user sharing button and "close" button.
+func btnReplaceScene() *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{
+		ResizeKeyboard: true,
+		Placeholder:    locale.SignupPlaceholder,
+	}
+	rm.Reply(
+		rm.Row(rm.User(locale.BtnSignupContact, &tele.ReplyRecipient{
+			ID:              rand.Int31(),
+			Quantity:        1,
+			Bot:             tele.Flag(false),
+			RequestName:     tele.Flag(true),
+			RequestUsername: tele.Flag(true),
+		})),
+		rm.Row(rm.Text(locale.BtnClose)),
+	)
+	return rm
+}
+
+// btnSignupSoloScene creates buttons for the signup scene of the solo event:
+// only "register" or "remove" and "close" buttons.
+func btnSignupSoloScene(reg *models.Registration) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{
+		ResizeKeyboard: true,
+	}
+	var rows []tele.Row
+	switch {
+	case reg.Status == models.StatusNotRegistered:
+		rows = append(rows, rm.Row(rm.Text(locale.BtnRegister)))
+	case reg.Status.IsRegistered():
+		rows = append(rows, rm.Row(rm.Text(locale.BtnRemove)))
+	}
+	rows = append(rows, rm.Row(rm.Text(locale.BtnClose)))
+	rm.Reply(rows...)
+	return rm
+}
+
+// isRepeat returns true if the couple of the registered dancer
+// opted in to be signed up for the next recurring event.
+func isRepeat(reg *models.Registration) bool {
+	if reg.Dancer == nil || reg.Profile == nil {
+		return false
+	}
+	for _, couple := range reg.Event.Couples {
+		for _, d := range couple.Dancers {
+			if d.Profile != nil && d.Profile.ID == reg.Profile.ID {
+				return couple.Repeat
+			}
+		}
+	}
+	return false
+}
+
+var (
+	BtnCbConsentAccept  = tele.Btn{Unique: "consent_accept"}
+	BtnCbConsentDecline = tele.Btn{Unique: "consent_decline"}
+)
+
+// btnConsent creates inline buttons for the partner to accept or decline
+// the couple registration requested by the dancer with the given profile ID.
+func btnConsent(eventID string, requesterID int64) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{}
+	id := strconv.FormatInt(requesterID, 10)
+	rm.Inline(rm.Row(
+		rm.Data(locale.BtnConsentAnswer[true], BtnCbConsentAccept.Unique, eventID, id, randtoken.New(4)),
+		rm.Data(locale.BtnConsentAnswer[false], BtnCbConsentDecline.Unique, eventID, id, randtoken.New(4)),
+	))
+	return rm
+}
+
+var BtnCbSingleRefresh = tele.Btn{Unique: "single_refresh"}
+
+// btnSingleRefresh creates an inline button for the single dancer
+// to confirm that they are still looking for a partner.
+func btnSingleRefresh(eventID string) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{}
+	rm.Inline(rm.Row(
+		rm.Data(locale.BtnSingleStill, BtnCbSingleRefresh.Unique, eventID, randtoken.New(4)),
+	))
+	return rm
+}
+
+var BtnCbNotifyMute = tele.Btn{Unique: "notify_mute"}
+
+// btnMuteEvent appends an inline button to mute the notifications about the event.
+func btnMuteEvent(rm *tele.ReplyMarkup, eventID string) *tele.ReplyMarkup {
+	rm.InlineKeyboard = append(rm.InlineKeyboard, []tele.InlineButton{
+		*rm.Data(locale.BtnMuteEvent, BtnCbNotifyMute.Unique, eventID, randtoken.New(4)).Inline(),
+	})
+	return rm
+}
+
+// btnPairWith creates an inline button with a deeplink
+// to sign up for the event in couple with the given single.
+func btnPairWith(eventID string, single *models.Dancer) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{}
+	dl := Deeplink{Action: models.SessionPair, EventID: eventID, PartnerID: single.Profile.ID}
+	rm.Inline(rm.Row(rm.URL(locale.BtnPairWith, dl.String())))
+	return rm
+}
+
 // btnChatLink creates an inline button with a link to the chat.
 //
 // Known Telegram limitations:
@@ -178,28 +348,51 @@ func btnSignupScene(reg *models.Registration, singles []models.SessionSingle) *t
 // Which gives us the link: https://t.me/c/1234567890/1234
 func btnChatLink(event *models.Event) *tele.ReplyMarkup {
 	rm := &tele.ReplyMarkup{}
+	url, ok := fmtPostURL(event)
+	if !ok {
+		return rm
+	}
+	rm.Inline(rm.Row(
+		rm.URL(locale.BtnChatLink, url),
+	))
+	return rm
+}
 
+// fmtPostURL formats the link to the event post in the chat.
+// Returns false if the link can not be created. See [btnChatLink] for details.
+func fmtPostURL(event *models.Event) (string, bool) {
 	if event == nil ||
 		event.Post == nil ||
 		event.Post.Chat == nil ||
 		event.Post.ChatMessageID == 0 ||
 		(event.Post.Chat.Type != models.ChatSuper && event.Post.Chat.Type != models.ChatChannel) {
-		return rm
+		return "", false
 	}
 
 	chatLinkId := -event.Post.Chat.ID - 1000000000000
-	url := fmt.Sprintf("https://t.me/c/%d/%d", chatLinkId, event.Post.ChatMessageID)
-	rm.Inline(rm.Row(
-		rm.URL(locale.BtnChatLink, url),
-	))
-	return rm
-
+	return fmt.Sprintf("https://t.me/c/%d/%d", chatLinkId, event.Post.ChatMessageID), true
 }
 
 var (
-	BtnCbSettingsAutoPair = tele.Btn{Unique: "settings_auto_pair"}
-	BtnCbSettingsHelp     = tele.Btn{Unique: "settings_help"}
-	BtnCbSettingsBack     = tele.Btn{Unique: "settings_back"}
+	BtnCbSettingsAutoPair       = tele.Btn{Unique: "settings_auto_pair"}
+	BtnCbSettingsPairing        = tele.Btn{Unique: "settings_pairing"}
+	BtnCbSettingsHelp           = tele.Btn{Unique: "settings_help"}
+	BtnCbSettingsBack           = tele.Btn{Unique: "settings_back"}
+	BtnCbSettingsBlocklist      = tele.Btn{Unique: models.SessionBlocklist.String()}
+	BtnCbSettingsUnblock        = tele.Btn{Unique: "settings_unblock"}
+	BtnCbSettingsTemplates      = tele.Btn{Unique: "settings_templates"}
+	BtnCbSettingsTemplateApply  = tele.Btn{Unique: "settings_template_apply"}
+	BtnCbSettingsTemplateRemove = tele.Btn{Unique: "settings_template_remove"}
+
+	BtnCbSettingsCoOrganizers      = tele.Btn{Unique: "settings_co_organizers"}
+	BtnCbSettingsCoOrganizerRemove = tele.Btn{Unique: "settings_co_organizer_remove"}
+	BtnCbSettingsDancer            = tele.Btn{Unique: "settings_dancer"}
+	BtnCbSettingsDancerSet         = tele.Btn{Unique: "settings_dancer_set"}
+	BtnCbSettingsSingleAlerts      = tele.Btn{Unique: "settings_single_alerts"}
+	BtnCbSettingsOwnerAlerts       = tele.Btn{Unique: "settings_owner_alerts"}
+	BtnCbSettingsNotify            = tele.Btn{Unique: "settings_notify"}
+	BtnCbSettingsNotifyCategory    = tele.Btn{Unique: "settings_notify_category"}
+	BtnCbSettingsNotifyUnmute      = tele.Btn{Unique: "settings_notify_unmute"}
 )
 
 // btnSettingsScene creates buttons for the settings scene.
@@ -207,16 +400,181 @@ func btnSettingsScene(settings *models.UserSettings) *tele.ReplyMarkup {
 	rm := &tele.ReplyMarkup{
 		RemoveKeyboard: true,
 	}
-	rm.Inline(
+	rows := []tele.Row{
 		rm.Row(
 			rm.Data(locale.BtnAutoPairing[settings.Event.AutoPairing],
 				BtnCbSettingsAutoPair.Unique,
 				randtoken.New(4)),
 		),
+	}
+	if settings.Event.AutoPairing {
+		rows = append(rows, rm.Row(
+			rm.Data(locale.BtnPairingMode[nextPairing(settings.Event.Pairing)],
+				BtnCbSettingsPairing.Unique,
+				randtoken.New(4)),
+		))
+	}
+	rows = append(rows,
+		rm.Row(
+			rm.Data(locale.BtnOwnerAlerts[nextOwnerAlerts(settings.OwnerAlerts)],
+				BtnCbSettingsOwnerAlerts.Unique,
+				randtoken.New(4)),
+		),
+		rm.Row(
+			rm.Data(locale.BtnNotifications, BtnCbSettingsNotify.Unique, randtoken.New(4)),
+		),
+		rm.Row(
+			rm.Data(locale.BtnDancerProfile, BtnCbSettingsDancer.Unique, randtoken.New(4)),
+		),
+		rm.Row(
+			rm.Data(locale.BtnBlocklist, BtnCbSettingsBlocklist.Unique, randtoken.New(4)),
+		),
+		rm.Row(
+			rm.Data(locale.BtnDefaultCoOrgs, BtnCbSettingsCoOrganizers.Unique, randtoken.New(4)),
+		),
+		rm.Row(
+			rm.Data(locale.BtnTemplates, BtnCbSettingsTemplates.Unique, randtoken.New(4)),
+		),
 		rm.Row(
 			rm.Data(locale.BtnSettingsHelp, BtnCbSettingsHelp.Unique, randtoken.New(4)),
 		),
 	)
+	rm.Inline(rows...)
+	return rm
+}
+
+// nextPairing returns the pairing strategy following the given one in [models.Pairings].
+func nextPairing(pairing models.Pairing) models.Pairing {
+	i := slices.Index(models.Pairings, pairing)
+	return models.Pairings[(i+1)%len(models.Pairings)]
+}
+
+// nextOwnerAlerts returns the owner notifications mode following the given one.
+func nextOwnerAlerts(alerts models.OwnerAlerts) models.OwnerAlerts {
+	i := slices.Index(models.OwnerAlertsAll, alerts)
+	return models.OwnerAlertsAll[(i+1)%len(models.OwnerAlertsAll)]
+}
+
+// btnBlocklistScene creates buttons for the user blocklist scene.
+// Each blocked dancer has a button to remove the dancer from the blocklist.
+func btnBlocklistScene(settings *models.UserSettings) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{
+		RemoveKeyboard: true,
+	}
+	var rows []tele.Row
+	for _, d := range settings.Blocklist {
+		rows = append(rows, rm.Row(
+			rm.Data(locale.BtnAllow+d.FullName, BtnCbSettingsUnblock.Unique, d.Key(), randtoken.New(4)),
+		))
+	}
+	rows = append(rows, rm.Row(
+		rm.Data(locale.BtnBack, BtnCbSettingsBack.Unique, randtoken.New(4)),
+	))
+	rm.Inline(rows...)
+	return rm
+}
+
+// btnDefaultCoOrganizersScene creates buttons for the default co-organizers scene.
+// Each default co-organizer has a button to remove the co-organizer from the defaults.
+func btnDefaultCoOrganizersScene(settings *models.UserSettings) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{
+		RemoveKeyboard: true,
+	}
+	var rows []tele.Row
+	for _, p := range settings.Event.CoOrganizers {
+		rows = append(rows, rm.Row(
+			rm.Data(locale.BtnRemoveItem+p.FullName(),
+				BtnCbSettingsCoOrganizerRemove.Unique,
+				strconv.FormatInt(p.ID, 10), randtoken.New(4)),
+		))
+	}
+	rows = append(rows, rm.Row(
+		rm.Data(locale.BtnBack, BtnCbSettingsBack.Unique, randtoken.New(4)),
+	))
+	rm.Inline(rows...)
+	return rm
+}
+
+// btnNotificationsScene creates buttons for the notification preferences scene.
+// Each category has a toggle button and each muted event has a button to unmute it.
+func btnNotificationsScene(settings *models.UserSettings) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{
+		RemoveKeyboard: true,
+	}
+	prefs := &settings.Notifications
+	var rows []tele.Row
+	for _, category := range models.NotificationCategories {
+		enabled := !slices.Contains(prefs.MutedCategories, category)
+		rows = append(rows, rm.Row(
+			rm.Data(fmt.Sprintf(locale.BtnNotificationCategory[enabled], locale.NotificationCategory[category]),
+				BtnCbSettingsNotifyCategory.Unique,
+				string(category),
+				randtoken.New(4)),
+		))
+	}
+	for _, e := range prefs.MutedEvents {
+		rows = append(rows, rm.Row(
+			rm.Data(locale.BtnUnmute+e.Title, BtnCbSettingsNotifyUnmute.Unique, e.EventID, randtoken.New(4)),
+		))
+	}
+	rows = append(rows, rm.Row(
+		rm.Data(locale.BtnBack, BtnCbSettingsBack.Unique, randtoken.New(4)),
+	))
+	rm.Inline(rows...)
+	return rm
+}
+
+// btnTemplatesScene creates buttons for the event templates scene.
+// Each template has a button to apply its settings to new events and a button to remove it.
+func btnTemplatesScene(settings *models.UserSettings) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{
+		RemoveKeyboard: true,
+	}
+	var rows []tele.Row
+	for _, t := range settings.Templates {
+		rows = append(rows, rm.Row(
+			rm.Data(locale.BtnTemplateApply+t.Name, BtnCbSettingsTemplateApply.Unique, t.ID, randtoken.New(4)),
+			rm.Data(locale.BtnRemoveItem, BtnCbSettingsTemplateRemove.Unique, t.ID, randtoken.New(4)),
+		))
+	}
+	rows = append(rows, rm.Row(
+		rm.Data(locale.BtnBack, BtnCbSettingsBack.Unique, randtoken.New(4)),
+	))
+	rm.Inline(rows...)
+	return rm
+}
+
+// levelsAny is the list of all dance levels with the empty one first.
+var levelsAny = append([]models.Level{models.LevelNone}, models.Levels...)
+
+// btnDancerProfileScene creates buttons for the user dance profile scene.
+// Each row has buttons to set the level of the user and the preferred level of the partner,
+// the current levels are checked.
+func btnDancerProfileScene(settings *models.UserSettings) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{
+		RemoveKeyboard: true,
+	}
+	var rows []tele.Row
+	for _, l := range levelsAny {
+		level, partner := locale.BtnDancerLevel[l], locale.BtnPartnerLevel[l]
+		if l == settings.Dancer.Level {
+			level = locale.BtnCheckMark + level
+		}
+		if l == settings.Dancer.PartnerLevel {
+			partner = locale.BtnCheckMark + partner
+		}
+		rows = append(rows, rm.Row(
+			rm.Data(level, BtnCbSettingsDancerSet.Unique, "level", string(l), randtoken.New(4)),
+			rm.Data(partner, BtnCbSettingsDancerSet.Unique, "partner", string(l), randtoken.New(4)),
+		))
+	}
+	rows = append(rows, rm.Row(
+		rm.Data(locale.BtnSingleAlerts[settings.Dancer.SingleAlerts], BtnCbSettingsSingleAlerts.Unique, randtoken.New(4)),
+	))
+	rows = append(rows, rm.Row(
+		rm.Data(locale.BtnBack, BtnCbSettingsBack.Unique, randtoken.New(4)),
+	))
+	rm.Inline(rows...)
 	return rm
 }
 
@@ -231,6 +589,454 @@ func btnSettingsBack() *tele.ReplyMarkup {
 	return rm
 }
 
+var (
+	BtnCbEvents          = tele.Btn{Unique: "events"}
+	BtnCbEventManage     = tele.Btn{Unique: models.SessionManage.String()}
+	BtnCbEventClose      = tele.Btn{Unique: "event_close"}
+	BtnCbEventLimit      = tele.Btn{Unique: "event_limit"}
+	BtnCbEventCaption    = tele.Btn{Unique: "event_caption"}
+	BtnCbEventStartsAt   = tele.Btn{Unique: "event_starts_at"}
+	BtnCbEventRecurrence = tele.Btn{Unique: "event_recurrence"}
+	BtnCbEventLevels     = tele.Btn{Unique: "event_levels"}
+	BtnCbEventLevelsSet  = tele.Btn{Unique: "event_levels_set"}
+	BtnCbEventSolo       = tele.Btn{Unique: "event_solo"}
+	BtnCbEventConsent    = tele.Btn{Unique: "event_consent"}
+	BtnCbEventDeadlines  = tele.Btn{Unique: "event_deadlines"}
+	BtnCbEventSingleTTL  = tele.Btn{Unique: "event_single_ttl"}
+	BtnCbEventClone      = tele.Btn{Unique: "event_clone"}
+	BtnCbEventTemplate   = tele.Btn{Unique: "event_template"}
+
+	BtnCbEventDancers      = tele.Btn{Unique: "event_dancers"}
+	BtnCbEventDancerAdd    = tele.Btn{Unique: "event_dancer_add"}
+	BtnCbEventDancerPair   = tele.Btn{Unique: "event_dancer_pair"}
+	BtnCbEventDancerRemove = tele.Btn{Unique: "event_dancer_remove"}
+	BtnCbEventPairing      = tele.Btn{Unique: "event_pairing"}
+	BtnCbNoop              = tele.Btn{Unique: "noop"}
+	BtnCbEventForbid       = tele.Btn{Unique: models.SessionForbid.String()}
+	BtnCbEventAllow        = tele.Btn{Unique: "event_allow"}
+
+	BtnCbEventCoOrganizers       = tele.Btn{Unique: "event_co_organizers"}
+	BtnCbEventCoOrganizerRemove  = tele.Btn{Unique: "event_co_organizer_remove"}
+	BtnCbEventInviteRenew        = tele.Btn{Unique: "event_invite_renew"}
+	BtnCbEventCoOrganizerDefault = tele.Btn{Unique: "event_co_organizer_default"}
+)
+
+// btnEventsList creates buttons for the page of the owner events list.
+func btnEventsList(events []*models.Event, page int, more bool) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{
+		RemoveKeyboard: true,
+	}
+	var rows []tele.Row
+	for _, event := range events {
+		rows = append(rows, rm.Row(
+			rm.Data(fmtEventTitle(event), BtnCbEventManage.Unique, event.ID, randtoken.New(4)),
+		))
+	}
+
+	var nav tele.Row
+	if page > 0 {
+		nav = append(nav, rm.Data(locale.BtnPrev, BtnCbEvents.Unique, strconv.Itoa(page-1), randtoken.New(4)))
+	}
+	if more {
+		nav = append(nav, rm.Data(locale.BtnNext, BtnCbEvents.Unique, strconv.Itoa(page+1), randtoken.New(4)))
+	}
+	if len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+
+	rm.Inline(rows...)
+	return rm
+}
+
+// btnEventScene creates buttons for the event management scene.
+// The singles pairing button is shown to the event owner only.
+func btnEventScene(event *models.Event, profile *models.Profile) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{
+		RemoveKeyboard: true,
+	}
+	var rows []tele.Row
+	if url, ok := fmtPostURL(event); ok {
+		rows = append(rows, rm.Row(rm.URL(locale.BtnChatLink, url)))
+	}
+
+	// closure mode buttons, the current mode is checked
+	for _, mode := range models.ClosedForModes {
+		text := locale.BtnClosedFor[mode]
+		if mode == event.Settings.ClosedFor {
+			text = locale.BtnCheckMark + text
+		}
+		rows = append(rows, rm.Row(
+			rm.Data(text, BtnCbEventClose.Unique, event.ID, string(mode), randtoken.New(4)),
+		))
+	}
+
+	rows = append(rows, rm.Row(
+		rm.Data(locale.BtnCaption, BtnCbEventCaption.Unique, event.ID, randtoken.New(4)),
+	))
+	rows = append(rows, rm.Row(
+		rm.Data(locale.BtnStartsAt, BtnCbEventStartsAt.Unique, event.ID, randtoken.New(4)),
+	))
+	// deadline buttons switch to the next deadline, available only with the start time
+	if event.StartsAt != nil {
+		signup, cancel := event.Settings.SignupDeadline, event.Settings.CancelDeadline
+		rows = append(rows, rm.Row(
+			rm.Data(fmt.Sprintf(locale.BtnSignupDeadline, fmtDeadline(signup)), BtnCbEventDeadlines.Unique,
+				event.ID, fmtHours(nextDeadline(signup)), fmtHours(cancel), randtoken.New(4)),
+			rm.Data(fmt.Sprintf(locale.BtnCancelDeadline, fmtDeadline(cancel)), BtnCbEventDeadlines.Unique,
+				event.ID, fmtHours(signup), fmtHours(nextDeadline(cancel)), randtoken.New(4)),
+		))
+	}
+	// recurrence buttons, the current rule is checked
+	var recurrence tele.Row
+	for _, r := range models.Recurrences {
+		text := locale.BtnRecurrence[r]
+		if r == event.Recurrence {
+			text = locale.BtnCheckMark + text
+		}
+		recurrence = append(recurrence,
+			rm.Data(text, BtnCbEventRecurrence.Unique, event.ID, string(r), randtoken.New(4)))
+	}
+	rows = append(rows, recurrence)
+	rows = append(rows, rm.Row(
+		rm.Data(locale.BtnSolo[event.Settings.Solo], BtnCbEventSolo.Unique,
+			event.ID, strconv.FormatBool(!event.Settings.Solo), randtoken.New(4)),
+	))
+	// consent, singles TTL, couples limit, levels and pairing are not used by the solo event
+	if !event.Settings.Solo {
+		rows = append(rows, rm.Row(
+			rm.Data(locale.BtnConsent[event.Settings.Consent], BtnCbEventConsent.Unique,
+				event.ID, strconv.FormatBool(!event.Settings.Consent), randtoken.New(4)),
+		))
+		rows = append(rows, rm.Row(
+			rm.Data(fmt.Sprintf(locale.BtnSingleTTL, fmtSingleTTL(event.Settings.SingleTTL)), BtnCbEventSingleTTL.Unique,
+				event.ID, fmtHours(nextSingleTTL(event.Settings.SingleTTL)), randtoken.New(4)),
+		))
+		rows = append(rows, rm.Row(
+			rm.Data(locale.BtnLimit, BtnCbEventLimit.Unique, event.ID, randtoken.New(4)),
+		))
+	}
+	if event.Settings.AutoPairing && !event.Settings.Solo {
+		rows = append(rows, rm.Row(
+			rm.Data(locale.BtnLevels, BtnCbEventLevels.Unique, event.ID, randtoken.New(4)),
+		))
+	}
+	rows = append(rows, rm.Row(
+		rm.Data(locale.BtnDancers, BtnCbEventDancers.Unique, event.ID, randtoken.New(4)),
+	))
+	if profile.ID == event.Owner.ID && !event.Settings.Solo {
+		rows = append(rows, rm.Row(
+			rm.Data(locale.BtnPairing, BtnCbEventPairing.Unique, event.ID, pairingNone, pairingNone, randtoken.New(4)),
+		))
+	}
+	rows = append(rows, rm.Row(
+		rm.Data(locale.BtnForbidden, BtnCbEventForbid.Unique, event.ID, randtoken.New(4)),
+	))
+	rows = append(rows, rm.Row(
+		rm.Data(locale.BtnCoOrganizers, BtnCbEventCoOrganizers.Unique, event.ID, randtoken.New(4)),
+	))
+	rows = append(rows, rm.Row(
+		rm.Data(locale.BtnClone, BtnCbEventClone.Unique, event.ID, randtoken.New(4)),
+	))
+	rows = append(rows, rm.Row(
+		rm.Data(locale.BtnTemplateSave, BtnCbEventTemplate.Unique, event.ID, randtoken.New(4)),
+	))
+
+	rows = append(rows, rm.Row(
+		rm.Data(locale.BtnBack, BtnCbEvents.Unique, "0", randtoken.New(4)),
+	))
+	rm.Inline(rows...)
+	return rm
+}
+
+// btnLevelsScene creates buttons for the event level range scene.
+// Each row has buttons to set the minimum and the maximum level, the current levels are checked.
+// If the new bound crosses the other one, the other bound is moved along.
+func btnLevelsScene(event *models.Event) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{
+		RemoveKeyboard: true,
+	}
+	minLevel, maxLevel := event.Settings.MinLevel, event.Settings.MaxLevel
+	var rows []tele.Row
+	for _, l := range levelsAny {
+		minText, maxText := locale.BtnLevelMin[l], locale.BtnLevelMax[l]
+		if l == minLevel {
+			minText = locale.BtnCheckMark + minText
+		}
+		if l == maxLevel {
+			maxText = locale.BtnCheckMark + maxText
+		}
+		// new minimum level with the maximum level adjusted
+		newMax := maxLevel
+		if l != models.LevelNone && maxLevel != models.LevelNone && maxLevel.Rank() < l.Rank() {
+			newMax = l
+		}
+		// new maximum level with the minimum level adjusted
+		newMin := minLevel
+		if l != models.LevelNone && minLevel.Rank() > l.Rank() {
+			newMin = l
+		}
+		rows = append(rows, rm.Row(
+			rm.Data(minText, BtnCbEventLevelsSet.Unique, event.ID, string(l), string(newMax), randtoken.New(4)),
+			rm.Data(maxText, BtnCbEventLevelsSet.Unique, event.ID, string(newMin), string(l), randtoken.New(4)),
+		))
+	}
+	rows = append(rows, rm.Row(
+		rm.Data(locale.BtnBack, BtnCbEventManage.Unique, event.ID, randtoken.New(4)),
+	))
+	rm.Inline(rows...)
+	return rm
+}
+
+// btnForbiddenScene creates buttons for the forbidden dancers scene.
+// Each forbidden dancer has a button to allow the dancer to sign in again.
+func btnForbiddenScene(event *models.Event) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{
+		RemoveKeyboard: true,
+	}
+	var rows []tele.Row
+	for _, d := range event.Forbidden {
+		rows = append(rows, rm.Row(
+			rm.Data(locale.BtnAllow+d.FullName, BtnCbEventAllow.Unique, event.ID, d.Key(), randtoken.New(4)),
+		))
+	}
+	rows = append(rows, rm.Row(
+		rm.Data(locale.BtnBack, BtnCbEventManage.Unique, event.ID, randtoken.New(4)),
+	))
+	rm.Inline(rows...)
+	return rm
+}
+
+// dancersCouple is used in the callback data of the event dancers scene
+// to add the couple instead of the single.
+const dancersCouple = "couple"
+
+// btnDancersScene creates buttons for the page of the event dancers scene.
+// Each dancer has a button to remove the registration,
+// each single has a button to be paired with a new partner.
+// The dancers of the solo event are added one by one.
+func btnDancersScene(event *models.Event, page, size int) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{
+		RemoveKeyboard: true,
+	}
+	var rows []tele.Row
+	for _, couples := range [][]models.Couple{event.Couples, event.Waitlist} {
+		for _, couple := range couples {
+			var row tele.Row
+			for _, d := range couple.Dancers {
+				row = append(row, rm.Data(locale.BtnRemoveItem+d.FullName, BtnCbEventDancerRemove.Unique,
+					event.ID, d.Key(), randtoken.New(4)))
+			}
+			rows = append(rows, row)
+		}
+	}
+	for _, d := range event.Singles {
+		rows = append(rows, rm.Row(
+			rm.Data(locale.BtnRemoveItem+d.FullName, BtnCbEventDancerRemove.Unique, event.ID, d.Key(), randtoken.New(4)),
+			rm.Data(locale.BtnDancerPair, BtnCbEventDancerPair.Unique, event.ID, d.Key(), randtoken.New(4)),
+		))
+	}
+	for _, d := range event.Participants {
+		rows = append(rows, rm.Row(
+			rm.Data(locale.BtnRemoveItem+d.FullName, BtnCbEventDancerRemove.Unique, event.ID, d.Key(), randtoken.New(4)),
+		))
+	}
+
+	// Telegram limits the number of buttons in the message, so the dancers are split into pages
+	page = max(0, min(page, (len(rows)-1)/size))
+	more := len(rows) > (page+1)*size
+	rows = rows[page*size : min(len(rows), (page+1)*size)]
+	var nav tele.Row
+	if page > 0 {
+		nav = append(nav, rm.Data(locale.BtnPrev, BtnCbEventDancers.Unique, event.ID, strconv.Itoa(page-1), randtoken.New(4)))
+	}
+	if more {
+		nav = append(nav, rm.Data(locale.BtnNext, BtnCbEventDancers.Unique, event.ID, strconv.Itoa(page+1), randtoken.New(4)))
+	}
+	if len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+
+	var add tele.Row
+	if !event.Settings.Solo {
+		add = append(add, rm.Data(locale.BtnDancerCoupleAdd, BtnCbEventDancerAdd.Unique,
+			event.ID, dancersCouple, randtoken.New(4)))
+	}
+	add = append(add,
+		rm.Data(locale.BtnDancerSingleAdd[models.RoleLeader], BtnCbEventDancerAdd.Unique,
+			event.ID, models.RoleLeader.String(), randtoken.New(4)),
+		rm.Data(locale.BtnDancerSingleAdd[models.RoleFollower], BtnCbEventDancerAdd.Unique,
+			event.ID, models.RoleFollower.String(), randtoken.New(4)),
+	)
+	if !event.Settings.Solo {
+		add = append(add, rm.Data(locale.BtnDancerSingleAdd[models.RoleSwitch], BtnCbEventDancerAdd.Unique,
+			event.ID, models.RoleSwitch.String(), randtoken.New(4)))
+	}
+	rows = append(rows, add)
+	rows = append(rows, rm.Row(
+		rm.Data(locale.BtnBack, BtnCbEventManage.Unique, event.ID, randtoken.New(4)),
+	))
+	rm.Inline(rows...)
+	return rm
+}
+
+// pairingNone is used in the callback data of the singles pairing scene
+// instead of the dancer key when nothing is selected.
+const pairingNone = "-"
+
+// btnPairingScene creates buttons for the singles pairing scene:
+// the leaders and the followers from the singles list side by side.
+// The keys of the selected leader and follower are passed in the callback data.
+func btnPairingScene(event *models.Event, leaderKey, followerKey string) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{
+		RemoveKeyboard: true,
+	}
+	leaders, followers := singlesByRole(event.Singles)
+	// btn creates the button of the dancer at index i of the singles list
+	// with the callback data of the next selection: tap on the selected dancer deselects it
+	btn := func(singles []models.Dancer, i int, selected string, next func(key string) (string, string)) tele.Btn {
+		if i >= len(singles) {
+			return rm.Data(locale.BtnPairingEmpty, BtnCbNoop.Unique, randtoken.New(4))
+		}
+		key := singles[i].Key()
+		text := locale.RoleIcon[singles[i].Role] + " " + singles[i].FullName
+		if key == selected {
+			text = locale.BtnCheckMark + text
+			key = pairingNone
+		}
+		l, f := next(key)
+		return rm.Data(text, BtnCbEventPairing.Unique, event.ID, l, f, randtoken.New(4))
+	}
+	nextLeader := func(key string) (string, string) { return key, followerKey }
+	nextFollower := func(key string) (string, string) { return leaderKey, key }
+
+	var rows []tele.Row
+	for i := range max(len(leaders), len(followers)) {
+		rows = append(rows, rm.Row(
+			btn(leaders, i, leaderKey, nextLeader),
+			btn(followers, i, followerKey, nextFollower),
+		))
+	}
+	rows = append(rows, rm.Row(
+		rm.Data(locale.BtnBack, BtnCbEventManage.Unique, event.ID, randtoken.New(4)),
+	))
+	rm.Inline(rows...)
+	return rm
+}
+
+// btnDancersBack creates a button to return to the event dancers scene.
+func btnDancersBack(eventID string) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{
+		RemoveKeyboard: true,
+	}
+	rm.Inline(rm.Row(
+		rm.Data(locale.BtnBack, BtnCbEventDancers.Unique, eventID, randtoken.New(4)),
+	))
+	return rm
+}
+
+// dancerByKey returns the registered dancer of the event with the given key.
+// Returns false if there is no such dancer.
+func dancerByKey(event *models.Event, key string) (*models.Dancer, bool) {
+	for _, couples := range [][]models.Couple{event.Couples, event.Waitlist} {
+		for i := range couples {
+			for j := range couples[i].Dancers {
+				if couples[i].Dancers[j].Key() == key {
+					return &couples[i].Dancers[j], true
+				}
+			}
+		}
+	}
+	for i := range event.Participants {
+		if event.Participants[i].Key() == key {
+			return &event.Participants[i], true
+		}
+	}
+	return singleByKey(event, key)
+}
+
+// singleByKey returns the single of the event with the given key.
+// Returns false if there is no such single.
+func singleByKey(event *models.Event, key string) (*models.Dancer, bool) {
+	for i := range event.Singles {
+		if event.Singles[i].Key() == key {
+			return &event.Singles[i], true
+		}
+	}
+	return nil, false
+}
+
+// btnCoOrganizersScene creates buttons for the co-organizers scene.
+// Each co-organizer has a button to remove the co-organizer from the event
+// and a button to add or remove the co-organizer from the default co-organizers of the user.
+func btnCoOrganizersScene(event *models.Event, settings *models.UserSettings) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{
+		RemoveKeyboard: true,
+	}
+	var rows []tele.Row
+	for _, p := range event.CoOrganizers {
+		profileID := strconv.FormatInt(p.ID, 10)
+		rows = append(rows, rm.Row(
+			rm.Data(locale.BtnRemoveItem+p.FullName(),
+				BtnCbEventCoOrganizerRemove.Unique,
+				event.ID, profileID, randtoken.New(4)),
+			rm.Data(locale.BtnCoOrganizerDefault[isDefaultCoOrganizer(settings, p.ID)],
+				BtnCbEventCoOrganizerDefault.Unique,
+				event.ID, profileID, randtoken.New(4)),
+		))
+	}
+	rows = append(rows,
+		rm.Row(rm.Data(locale.BtnInviteRenew, BtnCbEventInviteRenew.Unique, event.ID, randtoken.New(4))),
+		rm.Row(rm.Data(locale.BtnBack, BtnCbEventManage.Unique, event.ID, randtoken.New(4))),
+	)
+	rm.Inline(rows...)
+	return rm
+}
+
+// btnPublish creates a button to publish the draft event via inline query in a chosen chat.
+func btnPublish(event *models.Event) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{}
+	rm.Inline(rm.Row(rm.Query(locale.BtnPublish, queryDraftPrefix+event.ID)))
+	return rm
+}
+
+// btnEventCloned creates buttons for the cloned event scene.
+func btnEventCloned(event *models.Event, sourceID string) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{
+		RemoveKeyboard: true,
+	}
+	rm.Inline(
+		rm.Row(rm.Query(locale.BtnPublish, queryDraftPrefix+event.ID)),
+		rm.Row(rm.Data(locale.BtnBack, BtnCbEventManage.Unique, sourceID, randtoken.New(4))),
+	)
+	return rm
+}
+
+// btnEventBack creates a button to return to the event management scene.
+func btnEventBack(eventID string) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{
+		RemoveKeyboard: true,
+	}
+	rm.Inline(rm.Row(
+		rm.Data(locale.BtnBack, BtnCbEventManage.Unique, eventID, randtoken.New(4)),
+	))
+	return rm
+}
+
+// fmtEventTitle formats the short event title for the buttons.
+// Title format: "02.01 First line of the caption…"
+func fmtEventTitle(event *models.Event) string {
+	caption := reHTMLTag.ReplaceAllString(event.Caption, "")
+	caption, _, _ = strings.Cut(strings.TrimSpace(caption), "\n")
+	if r := []rune(caption); len(r) > eventTitleMaxLen {
+		caption = strings.TrimSpace(string(r[:eventTitleMaxLen])) + "…"
+	}
+	return event.CreatedAt.Format("02.01") + " " + caption
+}
+
+const eventTitleMaxLen = 40
+
+var reHTMLTag = regexp.MustCompile(`<[^>]*>`)
+
 // sendStart sends a welcome message.
 func sendStart(c tele.Context) error {
 	rm := btnTry()
@@ -248,11 +1054,20 @@ func sendSignupScene(c tele.Context, reg *models.Registration, singles []models.
 
 	switch reg.Status {
 	case models.StatusNotRegistered:
+		if reg.Event.Settings.Solo {
+			return c.Send(locale.SignupSolo, opts)
+		}
 		return c.Send(locale.SignupNotRegistered, opts)
+	case models.StatusParticipant:
+		return c.Send(locale.SignupParticipant, opts)
+	case models.StatusPending:
+		return c.Send(fmt.Sprintf(locale.SignupPending, fmtDancer(reg.Partner)), opts)
 	case models.StatusAsSingle:
 		return c.Send(fmt.Sprintf(locale.SignupSingle, locale.IconSingle[reg.Role]), opts)
 	case models.StatusInCouple:
-		return c.Send(fmt.Sprintf(locale.SignupInCouple, fmtDancer(reg.Partner)), opts)
+		return c.Send(fmt.Sprintf(locale.SignupInCouple, fmtDancer(reg.Partner))+fmtClaimLink(reg), opts)
+	case models.StatusInWaitlist:
+		return c.Send(fmt.Sprintf(locale.SignupInWaitlist, fmtDancer(reg.Partner))+fmtClaimLink(reg), opts)
 	case models.StatusForbidden:
 		return c.Send(locale.SignupForbidden, opts)
 	default:
@@ -277,7 +1092,33 @@ func sendResult(c tele.Context, reg *models.Registration, singles []models.Sessi
 	case models.ResultRegisteredAsSingle:
 		return c.Send(fmt.Sprintf(locale.ResultSuccessSingle, locale.IconSingle[reg.Role]), opts)
 	case models.ResultRegisteredInCouple:
-		return c.Send(fmt.Sprintf(locale.ResultSuccessCouple, fmtDancer(reg.Partner)), opts)
+		return c.Send(fmt.Sprintf(locale.ResultSuccessCouple, fmtDancer(reg.Partner))+fmtClaimLink(reg), opts)
+	case models.ResultRegisteredInWaitlist:
+		return c.Send(fmt.Sprintf(locale.ResultSuccessWaitlist, fmtDancer(reg.Partner))+fmtClaimLink(reg), opts)
+	case models.ResultPartnerReplaced:
+		return c.Send(fmt.Sprintf(locale.ResultSuccessReplaced, fmtDancer(reg.Partner))+fmtClaimLink(reg), opts)
+	case models.ResultRoleSwitched:
+		return c.Send(fmt.Sprintf(locale.ResultSuccessRoleSwitched,
+			locale.RoleIcon[reg.Role], fmtDancer(reg.Partner), locale.RoleIcon[reg.Partner.Role]), opts)
+	case models.ResultDeadlinePassed:
+		return c.Send(locale.ResultDeadlinePassed, opts)
+	case models.ResultCancelClosed:
+		owner := &models.Dancer{Profile: &reg.Event.Owner, FullName: reg.Event.Owner.FullName()}
+		return c.Send(fmt.Sprintf(locale.ResultCancelClosed, fmtDancer(owner)), opts)
+	case models.ResultClaimed:
+		return c.Send(fmt.Sprintf(locale.ResultSuccessClaimed, fmtDancer(reg.Partner)), opts)
+	case models.ResultClaimNotFound:
+		return c.Send(locale.ResultClaimNotFound, opts)
+	case models.ResultRegisteredAsParticipant:
+		return c.Send(locale.ResultSuccessParticipant, opts)
+	case models.ResultAlreadyParticipant:
+		return c.Send(locale.ResultAlreadyParticipant, opts)
+	case models.ResultPendingConsent:
+		return c.Send(fmt.Sprintf(locale.ResultSuccessPending, fmtDancer(reg.Partner)), opts)
+	case models.ResultAlreadyPending:
+		return c.Send(locale.ResultAlreadyPending, opts)
+	case models.ResultPendingNotFound:
+		return c.Send(locale.ResultPendingNotFound, opts)
 	case models.ResultRegistrationRemoved:
 		return c.Send(locale.ResultSuccessRemoved, opts)
 	case models.ResultAlreadyAsSingle:
@@ -310,6 +1151,16 @@ func sendResult(c tele.Context, reg *models.Registration, singles []models.Sessi
 	}
 }
 
+// fmtClaimLink formats the link to claim the registration by the partner registered by name.
+// Returns an empty string if the partner has nothing to claim.
+func fmtClaimLink(reg *models.Registration) string {
+	if reg.Partner == nil || reg.Partner.Profile != nil || reg.Partner.ClaimToken == "" {
+		return ""
+	}
+	dl := Deeplink{Action: models.SessionClaim, EventID: reg.Event.ID, Token: reg.Partner.ClaimToken}
+	return fmt.Sprintf(locale.ClaimLink, dl.String())
+}
+
 // sendCloseOK sends a message on user session close.
 func sendCloseOK(c tele.Context) error {
 	return c.Send(locale.Ok, tele.RemoveKeyboard)
@@ -319,15 +1170,230 @@ func sendCloseOK(c tele.Context) error {
 func msgSettingsScene(settings *models.UserSettings) (string, *tele.ReplyMarkup) {
 	text := locale.SettingsCaption +
 		locale.SettingsAutoPairing[settings.Event.AutoPairing]
+	if settings.Event.AutoPairing {
+		text += locale.SettingsPairing[settings.Event.Pairing]
+	}
+	text += locale.SettingsOwnerAlerts[settings.OwnerAlerts]
+	if settings.Dancer.Level != models.LevelNone {
+		text += fmt.Sprintf(locale.SettingsLevel, locale.Level[settings.Dancer.Level])
+	}
+	if muted := len(settings.Notifications.MutedCategories) + len(settings.Notifications.MutedEvents); muted > 0 {
+		text += fmt.Sprintf(locale.SettingsMuted, muted)
+	}
+	if len(settings.Blocklist) > 0 {
+		text += fmt.Sprintf(locale.SettingsBlocklist, len(settings.Blocklist))
+	}
+	if len(settings.Event.CoOrganizers) > 0 {
+		text += fmt.Sprintf(locale.SettingsCoOrganizers, len(settings.Event.CoOrganizers))
+	}
+	if len(settings.Templates) > 0 {
+		text += fmt.Sprintf(locale.SettingsTemplates, len(settings.Templates))
+	}
 	rm := btnSettingsScene(settings)
 	return text, rm
 }
 
+// msgDancerProfileScene returns a message with the user dance profile.
+func msgDancerProfileScene(settings *models.UserSettings) (string, *tele.ReplyMarkup) {
+	text := fmt.Sprintf(locale.DancerProfile,
+		locale.Level[settings.Dancer.Level],
+		locale.PartnerLevel[settings.Dancer.PartnerLevel])
+	return text, btnDancerProfileScene(settings)
+}
+
+// msgNotificationsScene returns a message with the user notification preferences.
+func msgNotificationsScene(settings *models.UserSettings) (string, *tele.ReplyMarkup) {
+	text := locale.NotifySettings
+	if len(settings.Notifications.MutedEvents) > 0 {
+		var sb strings.Builder
+		for i, e := range settings.Notifications.MutedEvents {
+			sb.WriteString(strconv.Itoa(i+1) + ". " + e.Title + "\n")
+		}
+		text += locale.NotifySettingsMuted + sb.String()
+	}
+	return text, btnNotificationsScene(settings)
+}
+
+// msgBlocklistScene returns a message with the user blocklist.
+func msgBlocklistScene(settings *models.UserSettings) (string, *tele.ReplyMarkup) {
+	text := locale.Blocklist
+	if len(settings.Blocklist) > 0 {
+		text += locale.BlocklistList + fmtDancersList(settings.Blocklist)
+	}
+	return text, btnBlocklistScene(settings)
+}
+
+// msgDefaultCoOrganizersScene returns a message with the default co-organizers of the user.
+func msgDefaultCoOrganizersScene(settings *models.UserSettings) (string, *tele.ReplyMarkup) {
+	text := locale.DefaultCoOrganizers
+	if len(settings.Event.CoOrganizers) > 0 {
+		dancers := make([]models.Dancer, len(settings.Event.CoOrganizers))
+		for i, p := range settings.Event.CoOrganizers {
+			dancers[i] = models.Dancer{Profile: &p, FullName: p.FullName()}
+		}
+		text += locale.CoOrganizersList + fmtDancersList(dancers)
+	}
+	return text, btnDefaultCoOrganizersScene(settings)
+}
+
+// msgTemplatesScene returns a message with the user event templates.
+func msgTemplatesScene(settings *models.UserSettings) (string, *tele.ReplyMarkup) {
+	text := locale.Templates
+	if len(settings.Templates) > 0 {
+		var sb strings.Builder
+		for i, t := range settings.Templates {
+			sb.WriteString(strconv.Itoa(i+1) + ". " + t.Name + "\n")
+		}
+		text += locale.TemplatesList + sb.String()
+	}
+	return text, btnTemplatesScene(settings)
+}
+
 // msgSettingsHelp returns a message with the user settings help.
 func msgSettingsHelp() (string, *tele.ReplyMarkup) {
 	return locale.SettingsHelp, btnSettingsBack()
 }
 
+// msgEventsList returns a message with the page of the owner events list.
+func msgEventsList(events []*models.Event, page int, more bool) (string, *tele.ReplyMarkup) {
+	if len(events) == 0 && page == 0 {
+		return fmt.Sprintf(locale.EventsEmpty, config.BotProfile().Username), &tele.ReplyMarkup{RemoveKeyboard: true}
+	}
+	return locale.EventsCaption, btnEventsList(events, page, more)
+}
+
+// msgEventScene returns a message with the event management scene for the given user profile.
+func msgEventScene(event *models.Event, profile *models.Profile) (string, *tele.ReplyMarkup) {
+	text := fmt.Sprintf(locale.EventScene, event.Caption, len(event.Couples), len(event.Singles))
+	if event.Settings.Solo {
+		text = fmt.Sprintf(locale.EventSceneSolo, event.Caption, len(event.Participants))
+	}
+	if event.StartsAt != nil {
+		text += fmt.Sprintf(locale.EventStartsAt, fmtStartsAt(event))
+	}
+	if deadline := event.SignupDeadlineAt(); deadline != nil {
+		text += fmt.Sprintf(locale.EventSignupDeadline, fmtEventTime(event, *deadline))
+	}
+	if deadline := event.CancelDeadlineAt(); deadline != nil {
+		text += fmt.Sprintf(locale.EventCancelDeadline, fmtEventTime(event, *deadline))
+	}
+	if r, ok := locale.Recurrence[event.Recurrence]; ok {
+		text += fmt.Sprintf(locale.EventRecurrence, r)
+	}
+	if event.Settings.SingleTTL > 0 && !event.Settings.Solo {
+		text += fmt.Sprintf(locale.EventSingleTTL, fmtSingleTTL(event.Settings.SingleTTL))
+	}
+	if len(event.Waitlist) > 0 {
+		text += fmt.Sprintf(locale.EventWaitlist, len(event.Waitlist))
+	}
+	if len(event.Pending) > 0 {
+		text += fmt.Sprintf(locale.EventPending, len(event.Pending))
+	}
+	if event.Settings.Limit > 0 {
+		text += fmt.Sprintf(locale.EventLimit, event.Settings.Limit)
+	}
+	if event.Settings.AutoPairing && (event.Settings.MinLevel != models.LevelNone || event.Settings.MaxLevel != models.LevelNone) {
+		text += fmtLevels(event)
+	}
+	if len(event.Forbidden) > 0 {
+		text += fmt.Sprintf(locale.EventForbiddenCount, len(event.Forbidden))
+	}
+	if banner, ok := locale.PostClosed[event.Settings.ClosedFor]; ok {
+		text += "\n\n" + banner
+	}
+	return text, btnEventScene(event, profile)
+}
+
+// fmtLevels formats the level range of the event.
+func fmtLevels(event *models.Event) string {
+	return fmt.Sprintf(locale.EventLevels,
+		locale.PartnerLevel[event.Settings.MinLevel],
+		locale.PartnerLevel[event.Settings.MaxLevel])
+}
+
+// msgLevelsScene returns a message with the level range of the event.
+func msgLevelsScene(event *models.Event) (string, *tele.ReplyMarkup) {
+	return locale.EventLevelsScene + "\n" + fmtLevels(event), btnLevelsScene(event)
+}
+
+// msgForbiddenScene returns a message with the list of dancers forbidden to sign in for the event.
+func msgForbiddenScene(event *models.Event) (string, *tele.ReplyMarkup) {
+	text := locale.EventForbidden
+	if len(event.Forbidden) > 0 {
+		text += locale.EventForbiddenList + fmtDancersList(event.Forbidden)
+	}
+	return text, btnForbiddenScene(event)
+}
+
+// msgDancersScene returns a message with the couples, waitlist and singles of the event
+// and the page of the dancers buttons.
+func msgDancersScene(event *models.Event, page, size int) (string, *tele.ReplyMarkup) {
+	text := locale.EventDancers
+	if len(event.Couples) > 0 {
+		text += locale.EventDancersCouples + fmtCouplesList(event.Couples)
+	}
+	if len(event.Waitlist) > 0 {
+		text += locale.EventDancersWaitlist + fmtCouplesList(event.Waitlist)
+	}
+	if len(event.Singles) > 0 {
+		var sb strings.Builder
+		for i, d := range event.Singles {
+			sb.WriteString(strconv.Itoa(i+1) + ". " + locale.RoleIcon[d.Role] + " " + fmtDancer(&d) + "\n")
+		}
+		text += locale.EventDancersSingles + sb.String()
+	}
+	if len(event.Participants) > 0 {
+		text += locale.EventDancersSolo + fmtParticipantsList(event.Participants)
+	}
+	return text, btnDancersScene(event, page, size)
+}
+
+// msgPairingScene returns a message with the singles pairing scene.
+func msgPairingScene(event *models.Event, leaderKey, followerKey string) (string, *tele.ReplyMarkup) {
+	text := locale.EventPairing
+	if leaders, followers := singlesByRole(event.Singles); len(leaders) == 0 || len(followers) == 0 {
+		text += locale.EventPairingEmpty
+	}
+	return text, btnPairingScene(event, leaderKey, followerKey)
+}
+
+// resultByOrganizer returns the message to the organizer on the failed registration change.
+func resultByOrganizer(result models.RegistrationResult) string {
+	if text, ok := locale.ResultByOrganizer[result]; ok {
+		return text
+	}
+	return locale.ErrSomethingWrong
+}
+
+// msgCoOrganizersScene returns a message with the co-organizers of the event and the invite link.
+func msgCoOrganizersScene(event *models.Event, settings *models.UserSettings) (string, *tele.ReplyMarkup) {
+	dl := Deeplink{Action: models.SessionInvite, EventID: event.ID, Token: event.InviteToken}
+	text := fmt.Sprintf(locale.CoOrganizers, dl.String())
+	if len(event.CoOrganizers) > 0 {
+		dancers := make([]models.Dancer, len(event.CoOrganizers))
+		for i, p := range event.CoOrganizers {
+			dancers[i] = models.Dancer{Profile: &p, FullName: p.FullName()}
+		}
+		text += locale.CoOrganizersList + fmtDancersList(dancers)
+	}
+	return text, btnCoOrganizersScene(event, settings)
+}
+
+// isDefaultCoOrganizer returns true if the profile is one of the default co-organizers of the user.
+func isDefaultCoOrganizer(settings *models.UserSettings, profileID int64) bool {
+	for _, p := range settings.Event.CoOrganizers {
+		if p.ID == profileID {
+			return true
+		}
+	}
+	return false
+}
+
+// msgEventCloned returns a message with the cloned event ready to be published.
+func msgEventCloned(event *models.Event, sourceID string) (string, *tele.ReplyMarkup) {
+	return fmt.Sprintf(locale.EventCloned, event.Caption), btnEventCloned(event, sourceID)
+}
+
 // answerQueryEmpty sends a response to the empty inline query.
 func answerQueryEmpty(c tele.Context, thumb string) error {
 	return c.Answer(&tele.QueryResponse{
@@ -342,13 +1408,21 @@ func answerQueryEmpty(c tele.Context, thumb string) error {
 	})
 }
 
-// answerQuery sends a response to the non-empty inline query.
-func answerQuery(c tele.Context, eventID, thumb string) error {
-	text := c.Query().Text
+// queryDraftPrefix - prefix of the inline query to publish the existing draft event.
+// Query format: "#[event ID]"
+const queryDraftPrefix = "#"
+
+// answerQuery sends a response to the non-empty inline query with the given results.
+func answerQuery(c tele.Context, results ...tele.Result) error {
+	return c.Answer(&tele.QueryResponse{Results: results})
+}
+
+// queryResult creates the inline query result with the event post with the given text.
+func queryResult(c tele.Context, eventID, text, thumb string) tele.Result {
 	var desc string
 
-	// Show warning in description if the text is too long.
-	r := 255 - utf8.RuneCountInString(text)
+	// Show warning in description if the query is too long.
+	r := 255 - utf8.RuneCountInString(c.Query().Text)
 	switch {
 	case r < 0:
 		desc = locale.QueryOverflow
@@ -357,24 +1431,32 @@ func answerQuery(c tele.Context, eventID, thumb string) error {
 	default:
 		desc = locale.QueryDescription
 	}
+	return articleResult(eventID, text, text, desc, thumb)
+}
 
-	return c.Answer(&tele.QueryResponse{
-		Results: tele.Results{
-			&tele.ArticleResult{
-				ResultBase: tele.ResultBase{
-					ID: eventID,
-					Content: &tele.InputTextMessageContent{
-						Text:           text,
-						ParseMode:      tele.ModeHTML,
-						PreviewOptions: &tele.PreviewOptions{Disabled: true},
-					},
-					ReplyMarkup: btnPostCb(eventID),
-				},
-				Title:       text,
-				Description: desc,
-				ThumbURL:    thumb,
-				HideURL:     true,
+// queryTemplateResult creates the inline query result with the event post
+// created with the given template.
+func queryTemplateResult(eventID string, tmpl *models.EventTemplate, text, thumb string) tele.Result {
+	result := articleResult(eventID, text, locale.QueryTemplate+tmpl.Name, text, thumb)
+	result.ReplyMarkup = btnPostCb(eventID, tmpl.Settings.Solo)
+	return result
+}
+
+// articleResult creates the inline query article with the event post.
+func articleResult(eventID, text, title, desc, thumb string) *tele.ArticleResult {
+	return &tele.ArticleResult{
+		ResultBase: tele.ResultBase{
+			ID: eventID,
+			Content: &tele.InputTextMessageContent{
+				Text:           text,
+				ParseMode:      tele.ModeHTML,
+				PreviewOptions: &tele.PreviewOptions{Disabled: true},
 			},
+			ReplyMarkup: btnPostCb(eventID, false),
 		},
-	})
+		Title:       title,
+		Description: desc,
+		ThumbURL:    thumb,
+		HideURL:     true,
+	}
 }